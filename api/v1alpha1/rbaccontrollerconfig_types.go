@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControllerConfigName is the name of the only RBACControllerConfig the
+// controller reads.
+const ControllerConfigName = "cluster"
+
+// SecretReference is the namespace and name of a Secret.
+type SecretReference struct {
+	// +required
+	Namespace string `json:"namespace"`
+	// +required
+	Name string `json:"name"`
+}
+
+// RBACControllerConfigSpec is the runtime policy of the controller. Fields
+// left unset keep the value given by the controller's flags.
+type RBACControllerConfigSpec struct {
+	// Namespaces in which the controller never creates bindings or
+	// ServiceAccounts.
+	// +listType=set
+	// +optional
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+
+	// How long before their EndTime rules are reported as Expiring.
+	// +optional
+	ExpiringWindow *metav1.Duration `json:"expiringWindow,omitempty"`
+
+	// Lifetime given to rules without an EndTime , counted from their
+	// StartTime. Rules without an EndTime never expire when it is 0.
+	// +optional
+	DefaultTTL *metav1.Duration `json:"defaultTTL,omitempty"`
+
+	// The longest lifetime , from StartTime to EndTime , a rule can have. It
+	// isn't enforced when 0.
+	// +optional
+	MaxTTL *metav1.Duration `json:"maxTTL,omitempty"`
+
+	// Maximum lifetimes overriding maxTTL for the rules binding the given
+	// Role , ClusterRole or bundle.
+	// +optional
+	MaxTTLOverrides map[string]metav1.Duration `json:"maxTTLOverrides,omitempty"`
+
+	// The longest lifetime of break-glass rules , whatever roles they bind.
+	// It isn't enforced when 0.
+	// +optional
+	BreakGlassMaxTTL *metav1.Duration `json:"breakGlassMaxTTL,omitempty"`
+
+	// Whether rules must have a justification.
+	// +optional
+	RequireJustification *bool `json:"requireJustification,omitempty"`
+
+	// The pattern the whole ticket URL of rules must match , rules are
+	// required to have one when it isn't empty.
+	// +optional
+	TicketURLPattern *string `json:"ticketURLPattern,omitempty"`
+
+	// ClusterRoles rules can't bind , entries ending with * match the
+	// ClusterRoles starting with what precedes it.
+	// +listType=set
+	// +optional
+	BlockedClusterRoles []string `json:"blockedClusterRoles,omitempty"`
+
+	// The only ClusterRoles rules can bind , entries ending with * match the
+	// ClusterRoles starting with what precedes it.
+	// +listType=set
+	// +optional
+	AllowedClusterRoles []string `json:"allowedClusterRoles,omitempty"`
+
+	// Limits on the size of rules , they aren't enforced when 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxBindingsPerRule *int32 `json:"maxBindingsPerRule,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSubjectsPerRule *int32 `json:"maxSubjectsPerRule,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxNamespacesPerRule *int32 `json:"maxNamespacesPerRule,omitempty"`
+	// The number of rules labeled for the same team , it isn't enforced when
+	// 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRulesPerTeam *int32 `json:"maxRulesPerTeam,omitempty"`
+
+	// Whether requesters can only grant what the RBACRequesterPolicies
+	// applying to them allow.
+	// +optional
+	EnforceRequesterPolicies *bool `json:"enforceRequesterPolicies,omitempty"`
+
+	// The namespace given to the ServiceAccount subjects and the Roles of
+	// rules that don't select any.
+	// +optional
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+
+	// The Secret holding the webhooks notifications are posted to.
+	// +optional
+	NotifierSecret *SecretReference `json:"notifierSecret,omitempty"`
+
+	// Prefixes prepended to the names of User and Group subjects , by kind ,
+	// e.g oidc: for Users. Names already starting with the prefix are kept.
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k in ['User', 'Group'])",message="only User and Group subjects can be prefixed"
+	// +optional
+	SubjectPrefixes map[string]string `json:"subjectPrefixes,omitempty"`
+
+	// The Go template the names of User subjects are rendered with , before
+	// being prefixed , e.g {{ .name }}@corp.example.com. Names are kept when
+	// it is empty.
+	// +optional
+	UsernameTemplate *string `json:"usernameTemplate,omitempty"`
+
+	// The risk level , as assessed by the webhook , from which rules require
+	// approvals before they are applied.
+	// +kubebuilder:validation:Enum=Low;Medium;High
+	// +optional
+	RiskApprovalLevel string `json:"riskApprovalLevel,omitempty"`
+	// The approvals rules from riskApprovalLevel require on top of their own
+	// approvalsRequired.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RiskApprovals *int32 `json:"riskApprovals,omitempty"`
+}
+
+// Condition types and reasons of RBACControllerConfigs , which also use
+// ReasonApplied.
+const (
+	// ConditionApplied is True when the controller runs with the config.
+	ConditionApplied = "Applied"
+	// ReasonInvalidConfig is used when the config can't be applied , e.g its
+	// ticket URL pattern doesn't compile. The controller keeps running with
+	// the last valid one.
+	ReasonInvalidConfig = "InvalidConfig"
+)
+
+// RBACControllerConfigStatus is the state of the config.
+type RBACControllerConfigStatus struct {
+	// The generation of the config last read by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The Applied condition of the config.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'cluster'",message="the config must be named cluster"
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=`.status.conditions[?(@.type=="Applied")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RBACControllerConfig is the runtime policy of the controller , it is applied
+// without restarting the controller. There is a single one , named cluster.
+type RBACControllerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the policy
+	// +optional
+	Spec RBACControllerConfigSpec `json:"spec,omitzero"`
+
+	// status reports whether the policy was applied
+	// +optional
+	Status RBACControllerConfigStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// RBACControllerConfigList contains a list of RBACControllerConfig
+type RBACControllerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []RBACControllerConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RBACControllerConfig{}, &RBACControllerConfigList{})
+}
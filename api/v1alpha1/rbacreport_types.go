@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReportSubject is the subject an RBACReport is about.
+type ReportSubject struct {
+	// +required
+	Kind SubjectType `json:"kind"`
+	// +required
+	Name string `json:"name"`
+	// The namespace of ServiceAccount subjects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Grant is a role held by a subject through a rule.
+type Grant struct {
+	// The rule granting the role.
+	// +required
+	Rule string `json:"rule"`
+
+	// The kind of the role , Role or ClusterRole.
+	// +required
+	Kind string `json:"kind"`
+
+	// The name of the role.
+	// +required
+	Role string `json:"role"`
+
+	// Whether the role is held cluster wide , through a ClusterRoleBinding.
+	// +optional
+	ClusterWide bool `json:"clusterWide,omitempty"`
+
+	// The namespaces in which the role is held , through RoleBindings.
+	// +listType=atomic
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.subject.kind`
+// +kubebuilder:printcolumn:name="Subject",type=string,JSONPath=`.subject.name`
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.subject.namespace`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RBACReport summarizes the roles a subject holds through the rules. Reports
+// are published by the controller , one per subject.
+type RBACReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// The subject the report is about.
+	// +required
+	Subject ReportSubject `json:"subject"`
+
+	// The roles held by the subject.
+	// +listType=atomic
+	// +optional
+	Grants []Grant `json:"grants,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RBACReportList contains a list of RBACReport
+type RBACReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []RBACReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RBACReport{}, &RBACReportList{})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Requesters are the users and groups a requester policy applies to. Names
+// can end with a * matching any suffix , e.g system:serviceaccount:ci:*.
+// +kubebuilder:validation:XValidation:rule="has(self.users) || has(self.groups)",message="at least one of users or groups must be set"
+type Requesters struct {
+	// The usernames of the requesters.
+	// +listType=set
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// The groups of the requesters.
+	// +listType=set
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// RBACRequesterPolicySpec defines what the requesters of a policy may grant.
+// All the names can end with a * matching any suffix.
+type RBACRequesterPolicySpec struct {
+	// The requesters the policy applies to.
+	// +required
+	Requesters Requesters `json:"requesters"`
+
+	// The ClusterRoles the requesters may bind.
+	// +listType=set
+	// +optional
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+
+	// The Roles the requesters may bind.
+	// +listType=set
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// The role bundles the requesters may bind.
+	// +listType=set
+	// +optional
+	Bundles []string `json:"bundles,omitempty"`
+
+	// The role templates the requesters may bind.
+	// +listType=set
+	// +optional
+	RoleTemplates []string `json:"roleTemplates,omitempty"`
+
+	// The namespaces in which the requesters may grant access. Rules
+	// selecting namespaces by labels or expressions can only be created by
+	// requesters allowed in all namespaces , with *.
+	// +listType=set
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Whether the requesters may grant access cluster wide , through
+	// ClusterRoleBindings.
+	// +optional
+	ClusterWide bool `json:"clusterWide,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Cluster Wide",type=boolean,JSONPath=`.spec.clusterWide`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RBACRequesterPolicy maps requesters to the roles and namespaces their rules
+// may grant. When requester policies are enforced , requesters can only add
+// to rules what one of the policies applying to them allows.
+type RBACRequesterPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines what the requesters of the policy may grant
+	// +required
+	Spec RBACRequesterPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// RBACRequesterPolicyList contains a list of RBACRequesterPolicy
+type RBACRequesterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []RBACRequesterPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RBACRequesterPolicy{}, &RBACRequesterPolicyList{})
+}
@@ -17,52 +17,247 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// +kubebuilder:validation:Enum=User;Group;ServiceAccount
+// +kubebuilder:validation:Enum=User;Group;ServiceAccount;AllServiceAccounts
 type SubjectType string
 
 var (
 	User           SubjectType = "User"
 	Group          SubjectType = "Group"
 	ServiceAccount SubjectType = "ServiceAccount"
+	// AllServiceAccounts binds every ServiceAccount of the subject's
+	// namespaces.
+	AllServiceAccounts SubjectType = "AllServiceAccounts"
 )
 
-// +kubebuilder:validation:XValidation:rule="(has(self.namespaces) || has(self.nameSpaceSelector) || has(self.namespaceMatchExpression))",message="at least one namespace must be specified"
+// +kubebuilder:validation:Enum=Group;Enumerate
+type SubjectRendering string
+
+const (
+	// SubjectRenderingGroup binds the system:serviceaccounts:<namespace>
+	// group of each namespace , ServiceAccounts created later are bound too.
+	SubjectRenderingGroup SubjectRendering = "Group"
+	// SubjectRenderingEnumerate binds each existing ServiceAccount of the
+	// namespaces , the bindings are updated as ServiceAccounts come and go.
+	SubjectRenderingEnumerate SubjectRendering = "Enumerate"
+)
+
+// +kubebuilder:validation:Enum=Retain;Delete
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyRetain keeps the namespaces created by the controller
+	// once the rule is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyDelete deletes the namespaces created by the controller
+	// once the rule is deleted, as long as they don't hold any workloads.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+)
+
+// +kubebuilder:validation:Enum=Delete;Retain
+type ExpiredRulePolicy string
+
+const (
+	// ExpiredRulePolicyDelete deletes the rule once its end time went by ,
+	// revoking what it granted.
+	ExpiredRulePolicyDelete ExpiredRulePolicy = "Delete"
+	// ExpiredRulePolicyRetain revokes what the rule granted once its end time
+	// went by but keeps the rule , with an Expired condition , as a record of
+	// the access.
+	ExpiredRulePolicyRetain ExpiredRulePolicy = "Retain"
+)
+
+// +kubebuilder:validation:Enum=Create;RequireExisting;Skip
+type NamespacePolicy string
+
+const (
+	// NamespacePolicyCreate creates the missing namespaces of ServiceAccount
+	// subjects.
+	NamespacePolicyCreate NamespacePolicy = "Create"
+	// NamespacePolicyRequireExisting fails the rule with a Degraded condition
+	// when a ServiceAccount subject targets a missing namespace.
+	NamespacePolicyRequireExisting NamespacePolicy = "RequireExisting"
+	// NamespacePolicySkip skips the ServiceAccount subjects targeting a missing
+	// namespace.
+	NamespacePolicySkip NamespacePolicy = "Skip"
+)
+
+// +kubebuilder:validation:Enum=Never;IfLabeled;Always
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyNever leaves the ServiceAccounts and bindings the
+	// controller didn't create untouched.
+	AdoptionPolicyNever AdoptionPolicy = "Never"
+	// AdoptionPolicyIfLabeled takes ownership of the ServiceAccounts and
+	// bindings the controller didn't create only when they carry the rule
+	// label with the rule's name.
+	AdoptionPolicyIfLabeled AdoptionPolicy = "IfLabeled"
+	// AdoptionPolicyAlways takes ownership of the ServiceAccounts and bindings
+	// the controller didn't create , they are deleted along with the rule.
+	AdoptionPolicyAlways AdoptionPolicy = "Always"
+)
+
+// +kubebuilder:validation:Enum=Cluster;SelectedNamespaces
+type ClusterRoleBindingScope string
+
+const (
+	// ClusterRoleBindingScopeCluster grants the ClusterRole cluster wide
+	// through a ClusterRoleBinding.
+	ClusterRoleBindingScopeCluster ClusterRoleBindingScope = "Cluster"
+	// ClusterRoleBindingScopeSelectedNamespaces grants the ClusterRole through
+	// a RoleBinding in each namespace matched by the selector.
+	ClusterRoleBindingScopeSelectedNamespaces ClusterRoleBindingScope = "SelectedNamespaces"
+)
+
+// +kubebuilder:validation:XValidation:rule="(has(self.namespaces) || has(self.nameSpaceSelector) || has(self.namespaceMatchExpression) || has(self.serviceAccountSelector))",message="at least one namespace must be specified"
+// +kubebuilder:validation:XValidation:rule="self.kind == 'AllServiceAccounts' ? !has(self.name) && !has(self.names) && !has(self.serviceAccountSelector) : [has(self.name), has(self.names), has(self.serviceAccountSelector)].filter(x, x).size() == 1",message="exactly one of name , names and serviceAccountSelector must be specified , AllServiceAccounts subjects have none"
+// +kubebuilder:validation:XValidation:rule="!has(self.serviceAccountSelector) || self.kind == 'ServiceAccount'",message="serviceAccountSelector is only supported by ServiceAccount subjects"
+// +kubebuilder:validation:XValidation:rule="!has(self.rendering) || self.kind == 'AllServiceAccounts'",message="rendering is only supported by AllServiceAccounts subjects"
+// +kubebuilder:validation:XValidation:rule="!(has(self.serviceAccountSelector) || self.kind == 'AllServiceAccounts') || !((has(self.createSA) && self.createSA) || (has(self.generateToken) && self.generateToken))",message="the ServiceAccounts selected by serviceAccountSelector or AllServiceAccounts can't be created nor get a token"
 type Subject struct {
 	// +required
 	Kind SubjectType `json:"kind"`
-	// +required
-	Name string `json:"name"`
+	// The name of the subject. The names of ServiceAccounts can be shell
+	// patterns , e.g ci-runner-* , binding the existing ServiceAccounts they
+	// match.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// The names of several subjects of the same kind , expanded with the same
+	// namespaces. Used instead of name , patterns are supported likewise.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Names []string `json:"names,omitempty"`
+	// How AllServiceAccounts subjects are bound , through the group of the
+	// ServiceAccounts of each namespace (default) or by enumerating them.
+	// +optional
+	Rendering SubjectRendering `json:"rendering,omitempty"`
+	// Selects the existing ServiceAccounts bound by the subject , in its
+	// namespaces or in every namespace when none is specified. They are
+	// never created by the controller.
+	// +optional
+	ServiceAccountSelector *metav1.LabelSelector `json:"serviceAccountSelector,omitempty"`
 	// +optional
 	Namespaces []string `json:"namespaces,omitempty"`
 	// +optional
 	NameSpaceSelector metav1.LabelSelector `json:"nameSpaceSelector,omitempty"`
+	// A regular expression matched against the whole name of namespaces.
 	// +optional
 	NamespaceMatchExpression string `json:"namespaceMatchExpression,omitempty"`
+	// Namespaces the subject is never resolved to , even when matched by the
+	// selector or listed in namespaces.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// Namespaces matching this selector are excluded from the subject's
+	// namespaces.
+	// +optional
+	ExcludeNamespaceSelector metav1.LabelSelector `json:"excludeNamespaceSelector,omitempty"`
+	// Whether the ServiceAccount is created when it doesn't exist. When false ,
+	// a missing ServiceAccount marks the rule Degraded.
 	// +optional
 	CreateSA bool `json:"createSA,omitempty"`
+
+	// If set , a token is requested for the ServiceAccount and stored in the
+	// <name>-token Secret of each of its namespaces. The token expires with
+	// the rule's EndTime.
+	// +optional
+	GenerateToken bool `json:"generateToken,omitempty"`
+
+	// Lifetime of the generated token , 3600 seconds by default. The token is
+	// renewed before it expires and never lives past the rule's EndTime ,
+	// except within the last 600 seconds of the grant since the TokenRequest
+	// API refuses shorter tokens , such a token is revoked along with its
+	// Secret when the rule expires.
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	TokenExpirationSeconds *int64 `json:"tokenExpirationSeconds,omitempty"`
+
+	// Metadata applied to the ServiceAccounts created for this subject , e.g
+	// the annotations required by cloud workload identity integrations.
+	// +optional
+	ServiceAccountTemplate *ServiceAccountTemplate `json:"serviceAccountTemplate,omitempty"`
+}
+
+// ServiceAccountTemplate describes the ServiceAccounts created by the controller.
+type ServiceAccountTemplate struct {
+	// Labels added to the ServiceAccount.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the ServiceAccount , such as
+	// eks.amazonaws.com/role-arn or iam.gke.io/gcp-service-account.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Whether pods running as the ServiceAccount get its token mounted
+	// automatically.
+	// +optional
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+
+	// Secrets used to pull the images of pods running as the ServiceAccount.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="(has(self.namespaces) || has(self.nameSpaceSelector) || has(self.namespaceMatchExpression))",message="at least one namespace must be specified"
-// +kubebuilder:validation:XValidation:rule="(has(self.role) || has(self.clusterRole))",message="at least one role must be specified"
+// +kubebuilder:validation:XValidation:rule="(has(self.role) || has(self.clusterRole) || has(self.bundle) || has(self.roleTemplate))",message="at least one role must be specified"
 type RoleBinding struct {
 	// +optional
 	Role string `json:"role,omitempty"`
 	// +optional
 	ClusterRole string `json:"clusterRole,omitempty"`
+	// Name of a role bundle of the controller's catalog , a RoleBinding is
+	// created for each of its ClusterRoles.
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
+	// Name of a role template , a ConfigMap of the controller's role templates
+	// namespace. A ClusterRole is generated from its rules and bound in the
+	// namespaces.
+	// +optional
+	RoleTemplate string `json:"roleTemplate,omitempty"`
 	// +optional
 	Namespaces []string `json:"namespaces,omitempty"`
 	// +optional
 	NameSpaceSelector metav1.LabelSelector `json:"nameSpaceSelector,omitempty"`
+	// A regular expression matched against the whole name of namespaces.
 	// +optional
 	NamespaceMatchExpression string `json:"namespaceMatchExpression,omitempty"`
+	// Namespaces in which no RoleBinding is created , even when matched by the
+	// selector or listed in namespaces.
+	// +optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// Namespaces matching this selector are excluded from the RoleBinding's
+	// namespaces.
+	// +optional
+	ExcludeNamespaceSelector metav1.LabelSelector `json:"excludeNamespaceSelector,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="[has(self.clusterRole), has(self.bundle), has(self.roleTemplate)].filter(x, x).size() == 1",message="exactly one of clusterRole, bundle or roleTemplate must be specified"
+// +kubebuilder:validation:XValidation:rule="(has(self.scope) && self.scope == 'SelectedNamespaces') == has(self.nameSpaceSelector)",message="nameSpaceSelector must be specified exactly when scope is SelectedNamespaces"
 type ClusterRoleBinding struct {
-	// +required
-	ClusterRole string `json:"clusterRole"`
+	// +optional
+	ClusterRole string `json:"clusterRole,omitempty"`
+	// Name of a role bundle of the controller's catalog , a
+	// ClusterRoleBinding is created for each of its ClusterRoles.
+	// +optional
+	Bundle string `json:"bundle,omitempty"`
+	// Name of a role template , a ConfigMap of the controller's role templates
+	// namespace. A ClusterRole is generated from its rules and bound.
+	// +optional
+	RoleTemplate string `json:"roleTemplate,omitempty"`
+	// Where the ClusterRole is granted , cluster wide by default.
+	// SelectedNamespaces limits the grant to the namespaces matching
+	// nameSpaceSelector , through a RoleBinding in each of them.
+	// +optional
+	Scope ClusterRoleBindingScope `json:"scope,omitempty"`
+	// The namespaces the ClusterRole is granted in when scope is
+	// SelectedNamespaces.
+	// +optional
+	NameSpaceSelector *metav1.LabelSelector `json:"nameSpaceSelector,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="(has(self.roleBindings) || has(self.clusterRoleBindings))",message="RoleBindings or ClusterRoleBindings should be specified"
@@ -75,9 +270,41 @@ type Binding struct {
 	RoleBindings []RoleBinding `json:"roleBindings,omitempty"`
 	// +optional
 	ClusterRoleBindings []ClusterRoleBinding `json:"clusterRoleBindings,omitempty"`
+
+	// Overrides createSA for all the ServiceAccount subjects of the binding.
+	// +optional
+	CreateSA *bool `json:"createSA,omitempty"`
+
+	// Labels added to the ServiceAccounts and bindings generated for the
+	// binding , they override the rule's commonLabels.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the ServiceAccounts and bindings generated for the
+	// binding , they override the rule's commonAnnotations.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Overrides revokeIfUnusedFor for the binding.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="revokeIfUnusedFor must be positive"
+	RevokeIfUnusedFor *metav1.Duration `json:"revokeIfUnusedFor,omitempty"`
+}
+
+// NamespaceTemplate describes the namespaces created by the controller.
+type NamespaceTemplate struct {
+	// Labels added to the namespace.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the namespace.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // RBACRuleSpec defines the desired state of RBACRule
+// +kubebuilder:validation:XValidation:rule="!has(self.breakGlass) || !self.breakGlass || (has(self.reason) && size(self.reason) > 0)",message="a reason is required for break-glass rules"
+// +kubebuilder:validation:XValidation:rule="!has(self.breakGlass) || !self.breakGlass || has(self.endTime)",message="an end time is required for break-glass rules"
 type RBACRuleSpec struct {
 	// +required
 	Bindings []Binding `json:"bindings"`
@@ -91,8 +318,344 @@ type RBACRuleSpec struct {
 	// +optional
 	// +kubebuilder:validation:Format="date-time"
 	EndTime metav1.Time `json:"endTime,omitempty,omitzero"`
+
+	// How long before its EndTime the rule warns that its access is about to
+	// be revoked , through an Expiring condition , a warning event and a
+	// notification. Overrides the controller's expiring window.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="revocationGracePeriod must be positive"
+	RevocationGracePeriod *metav1.Duration `json:"revocationGracePeriod,omitempty"`
+
+	// Controls what happens to the namespaces created by the controller when
+	// the rule is deleted. Namespaces that still hold pods or persistent volume
+	// claims are always retained.
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Controls what happens to the rule once its end time went by.
+	// +optional
+	// +kubebuilder:default=Delete
+	ExpiredRulePolicy ExpiredRulePolicy `json:"expiredRulePolicy,omitempty"`
+
+	// Controls what happens when a ServiceAccount subject targets a namespace
+	// that doesn't exist.
+	// +optional
+	// +kubebuilder:default=Create
+	NamespacePolicy NamespacePolicy `json:"namespacePolicy,omitempty"`
+
+	// Controls whether the controller takes ownership of the existing
+	// ServiceAccounts and bindings it didn't create. Adopted objects are
+	// updated by the controller and deleted along with the rule.
+	// +optional
+	// +kubebuilder:default=IfLabeled
+	AdoptExisting AdoptionPolicy `json:"adoptExisting,omitempty"`
+
+	// Metadata applied to the namespaces created by the controller , e.g team
+	// ownership or pod security labels.
+	// +optional
+	NamespaceTemplate *NamespaceTemplate `json:"namespaceTemplate,omitempty"`
+
+	// Labels added to every ServiceAccount and binding generated for the rule ,
+	// e.g cost center or ticket metadata.
+	// +optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// Annotations added to every ServiceAccount and binding generated for the
+	// rule.
+	// +optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// Bindings that aren't used for this long , since they were applied or
+	// last used , are revoked early. Updating the rule grants them again. It
+	// requires the controller to record the use of bindings.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="revokeIfUnusedFor must be positive"
+	RevokeIfUnusedFor *metav1.Duration `json:"revokeIfUnusedFor,omitempty"`
+
+	// The number of distinct users , other than its creator , who must
+	// approve the rule before it is applied. Approvals are reset whenever
+	// the spec changes.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ApprovalsRequired int32 `json:"approvalsRequired,omitempty"`
+
+	// Marks the rule as an emergency elevation. Break-glass rules require a
+	// reason , their lifetime is capped by the controller , and their
+	// activation is notified and audited prominently.
+	// +optional
+	BreakGlass bool `json:"breakGlass,omitempty"`
+
+	// Why the access is needed , e.g the incident being handled. It is
+	// required for break-glass rules.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Why the access is granted. It is set as an annotation on every
+	// generated ServiceAccount and binding , and can be required by the
+	// controller.
+	// +optional
+	Justification string `json:"justification,omitempty"`
+
+	// Link to the ticket the access was requested in. It is set as an
+	// annotation on every generated ServiceAccount and binding , and can be
+	// required to match a cluster wide pattern.
+	// +optional
+	// +kubebuilder:validation:Format=uri
+	TicketURL string `json:"ticketURL,omitempty"`
+
+	// Whom to contact about the rule (e.g an email or a chat channel). It is
+	// included in the events emitted for the rule.
+	// +optional
+	OwnerContact string `json:"ownerContact,omitempty"`
+
+	// Link to documentation explaining the rule. It is included in the events
+	// emitted for the rule.
+	// +optional
+	// +kubebuilder:validation:Format=uri
+	DocsURL string `json:"docsURL,omitempty"`
+
+	// Selects the member clusters , by the labels of their kubeconfig Secret in
+	// the cluster registry , on which the bindings are also created. The
+	// namespaces are resolved on the cluster running the controller.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+}
+
+// BindingStatus is the state of one of the rule's bindings.
+type BindingStatus struct {
+	// The name of the binding.
+	// +required
+	Name string `json:"name"`
+
+	// The Ready condition of the binding.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The role bindings established for the binding , in the form of
+	// namespace/name.
+	// +listType=atomic
+	// +optional
+	RoleBindings []string `json:"roleBindings,omitempty"`
+
+	// The cluster role bindings established for the binding.
+	// +listType=atomic
+	// +optional
+	ClusterRoleBindings []string `json:"clusterRoleBindings,omitempty"`
+
+	// The ServiceAccounts bound by the binding , in the form of
+	// namespace/name.
+	// +listType=atomic
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// The namespaces the binding granted access in that were deleted , or
+	// are being deleted. Its resources are created in them again once they
+	// are recreated.
+	// +listType=atomic
+	// +optional
+	DeletedNamespaces []string `json:"deletedNamespaces,omitempty"`
+
+	// Why the binding couldn't be fully applied , empty when it was.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// When a request was last authorized through one of the binding's
+	// RoleBindings or ClusterRoleBindings , as reported by the API server
+	// audit events. It is updated with a one minute granularity.
+	// +optional
+	LastUsed *metav1.Time `json:"lastUsed,omitempty"`
+
+	// When the binding's resources were applied , since it was last granted.
+	// +optional
+	AppliedAt *metav1.Time `json:"appliedAt,omitempty"`
+
+	// The access reviews run to verify a sample of the grants of the binding
+	// are effective.
+	// +listType=atomic
+	// +optional
+	Verifications []GrantVerification `json:"verifications,omitempty"`
 }
 
+// GrantVerification is the result of a SubjectAccessReview checking that a
+// subject is allowed an access granted by a binding.
+type GrantVerification struct {
+	// The reviewed subject , in the form of kind:name or
+	// ServiceAccount:namespace/name.
+	// +required
+	Subject string `json:"subject"`
+
+	// The verb of the reviewed access.
+	// +required
+	Verb string `json:"verb"`
+
+	// The API group of the reviewed resource.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// The reviewed resource.
+	// +required
+	Resource string `json:"resource"`
+
+	// The namespace of the reviewed access , empty for cluster wide accesses.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Whether the access is allowed.
+	Allowed bool `json:"allowed"`
+
+	// Why the access is allowed or denied , as reported by the API server.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClusterStatus is the state of the bindings on a member cluster.
+type ClusterStatus struct {
+	// The name of the member cluster.
+	// +required
+	Name string `json:"name"`
+
+	// The number of role bindings established on the cluster.
+	// +optional
+	RoleBindingCount int32 `json:"roleBindingCount,omitempty"`
+
+	// The number of cluster role bindings established on the cluster.
+	// +optional
+	ClusterRoleBindingCount int32 `json:"clusterRoleBindingCount,omitempty"`
+
+	// Why the bindings couldn't be established on the cluster , empty when
+	// they were.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// When the state of the bindings on the cluster last changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty,omitzero"`
+}
+
+// RBACRulePhase is a coarse summary of where the rule is in its lifecycle.
+// +kubebuilder:validation:Enum=Pending;Active;Expiring;Expired;Failed;Deleting
+type RBACRulePhase string
+
+const (
+	// RBACRulePhasePending means the rule's StartTime wasn't reached yet.
+	RBACRulePhasePending RBACRulePhase = "Pending"
+	// RBACRulePhaseActive means the rule's bindings are in place.
+	RBACRulePhaseActive RBACRulePhase = "Active"
+	// RBACRulePhaseExpiring means the rule's bindings are in place , but its
+	// EndTime is close.
+	RBACRulePhaseExpiring RBACRulePhase = "Expiring"
+	// RBACRulePhaseExpired means the rule's EndTime went by.
+	RBACRulePhaseExpired RBACRulePhase = "Expired"
+	// RBACRulePhaseFailed means the rule couldn't be applied , see the
+	// Degraded condition for the reason.
+	RBACRulePhaseFailed RBACRulePhase = "Failed"
+	// RBACRulePhaseDeleting means the rule is being deleted and its bindings
+	// revoked.
+	RBACRulePhaseDeleting RBACRulePhase = "Deleting"
+)
+
+// Condition types of RBACRules.
+const (
+	// ConditionDegraded is True when the rule couldn't be fully applied.
+	ConditionDegraded = "Degraded"
+	// ConditionReady is True when all the resources of a binding were applied.
+	ConditionReady = "Ready"
+	// ConditionExpired is True when the end time of a retained rule went by
+	// and what it granted was revoked. It turns False when the rule is
+	// reactivated by editing its times.
+	ConditionExpired = "Expired"
+	// ConditionExpiring is True once the rule entered the grace period
+	// before its EndTime. It turns False when the EndTime is pushed back or
+	// removed.
+	ConditionExpiring = "Expiring"
+	// ConditionApproved is False while a rule requiring approvals is missing
+	// some , it isn't applied until it turns True.
+	ConditionApproved = "Approved"
+	// ConditionBlocked is True when the policy hook didn't allow some
+	// bindings of the rule. It is only set when a policy hook is configured.
+	ConditionBlocked = "Blocked"
+	// ConditionRolesBroadened is True when the roles the rule binds grant
+	// more than the risk level recorded at admission. It is only set when
+	// the roles are re-validated.
+	ConditionRolesBroadened = "RolesBroadened"
+)
+
+// Condition reasons of RBACRules.
+const (
+	ReasonReconciled        = "Reconciled"
+	ReasonNamespaceNotFound = "NamespaceNotFound"
+	// ReasonServiceAccountNotFound is used when a ServiceAccount subject doesn't
+	// exist and the rule isn't allowed to create it.
+	ReasonServiceAccountNotFound = "ServiceAccountNotFound"
+	// ReasonApplied is used when all the resources of a binding were applied.
+	ReasonApplied = "Applied"
+	// ReasonInvalidBinding is used when a binding can't be parsed , e.g it
+	// references an undefined role bundle.
+	ReasonInvalidBinding = "InvalidBinding"
+	// ReasonInvalidSelector is used when a label selector or match expression
+	// of a binding can't be compiled.
+	ReasonInvalidSelector = "InvalidSelector"
+	// ReasonNamespaceListFailed is used when the namespaces selected by a
+	// binding couldn't be listed.
+	ReasonNamespaceListFailed = "NamespaceListFailed"
+	// ReasonNoNamespaceMatched is used when namespace selections of a binding
+	// match no namespace.
+	ReasonNoNamespaceMatched = "NoNamespaceMatched"
+	// ReasonApplyFailed is used when a resource of a binding couldn't be
+	// created or updated.
+	ReasonApplyFailed = "ApplyFailed"
+	// ReasonRoleNotFound is used when a Role or ClusterRole referenced by a
+	// binding doesn't exist.
+	ReasonRoleNotFound = "RoleNotFound"
+	// ReasonInsufficientControllerPermissions is used when the controller
+	// isn't allowed to write a binding , e.g to bind its role.
+	ReasonInsufficientControllerPermissions = "InsufficientControllerPermissions"
+	// ReasonCreatorUnknown is used when bindings are written on behalf of the
+	// rule's creator but it wasn't recorded.
+	ReasonCreatorUnknown = "CreatorUnknown"
+	// ReasonGrantNotEffective is used when an access review denies an access
+	// granted by a binding.
+	ReasonGrantNotEffective = "GrantNotEffective"
+	// ReasonUnused is used when a binding was revoked because it wasn't used
+	// within its revokeIfUnusedFor window.
+	ReasonUnused = "Unused"
+	// ReasonBlocked is used when the policy hook didn't allow a binding.
+	ReasonBlocked = "Blocked"
+	// ReasonAllowed is used when the policy hook allowed every binding.
+	ReasonAllowed = "Allowed"
+	// ReasonEndTimeReached is used when the end time of a retained rule went
+	// by.
+	ReasonEndTimeReached = "EndTimeReached"
+	// ReasonReactivated is used when the times of an expired rule that was
+	// retained were edited so it is active again.
+	ReasonReactivated = "Reactivated"
+	// ReasonEndTimeApproaching is used when the rule entered the grace period
+	// before its EndTime.
+	ReasonEndTimeApproaching = "EndTimeApproaching"
+	// ReasonEndTimeExtended is used when the EndTime of an expiring rule was
+	// pushed back or removed.
+	ReasonEndTimeExtended = "EndTimeExtended"
+	// ReasonAwaitingApproval is used when a rule is missing approvals.
+	ReasonAwaitingApproval = "AwaitingApproval"
+	// ReasonApproved is used when a rule got the approvals it requires.
+	ReasonApproved = "Approved"
+	// ReasonNamespaceDeleted is used when namespaces a binding granted access
+	// in were deleted.
+	ReasonNamespaceDeleted = "NamespaceDeleted"
+	// ReasonQuotaExceeded is used when a rule exceeds the size limits , or
+	// its team the number of rules it can have.
+	ReasonQuotaExceeded = "QuotaExceeded"
+	// ReasonRiskIncreased is used when the roles of a rule were broadened
+	// beyond its recorded risk level.
+	ReasonRiskIncreased = "RiskIncreased"
+	// ReasonWithinRisk is used when the roles of a rule are within its
+	// recorded risk level.
+	ReasonWithinRisk = "WithinRisk"
+)
+
 // RBACRuleStatus defines the observed state of RBACRule.
 type RBACRuleStatus struct {
 	// conditions represent the current state of the RBACRule resource.
@@ -108,19 +671,51 @@ type RBACRuleStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
-	// A list of the established role bindings , in the form of Role/Namespace.
-	// +listType=atomic
+
+	// The state of each of the rule's bindings.
+	// +listType=map
+	// +listMapKey=name
 	// +optional
-	RoleBindings []string `json:"roleBindings,omitempty"`
-	// +listType=atomic
+	Bindings []BindingStatus `json:"bindings,omitempty"`
+
+	// The phase of the rule , computed on each reconcile. It is a summary of
+	// the conditions meant for dashboards and scripts.
 	// +optional
-	// A list of the established cluster role bindings.
-	ClusterRoleBindings []string `json:"clusterRoleBindings,omitempty"`
+	Phase RBACRulePhase `json:"phase,omitempty"`
+
+	// The last time the controller processed the rule. It is refreshed at most
+	// every few minutes when nothing else in the status changed , a rule whose
+	// lastReconcileTime is old isn't being processed anymore.
+	// +optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// The last time every binding of the rule was successfully applied.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// The number of established role bindings.
+	// +optional
+	RoleBindingCount int32 `json:"roleBindingCount,omitempty"`
+
+	// The number of established cluster role bindings.
+	// +optional
+	ClusterRoleBindingCount int32 `json:"clusterRoleBindingCount,omitempty"`
+
+	// The state of the bindings on each selected member cluster.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Clusters []ClusterStatus `json:"clusters,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Expires",type=string,JSONPath=`.spec.endTime`
+// +kubebuilder:printcolumn:name="RoleBindings",type=integer,JSONPath=`.status.roleBindingCount`
+// +kubebuilder:printcolumn:name="ClusterRoleBindings",type=integer,JSONPath=`.status.clusterRoleBindingCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // RBACRule is the Schema for the rbacrules API
 type RBACRule struct {
@@ -21,7 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -45,7 +46,33 @@ func (in *Binding) DeepCopyInto(out *Binding) {
 	if in.ClusterRoleBindings != nil {
 		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
 		*out = make([]ClusterRoleBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreateSA != nil {
+		in, out := &in.CreateSA, &out.CreateSA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RevokeIfUnusedFor != nil {
+		in, out := &in.RevokeIfUnusedFor, &out.RevokeIfUnusedFor
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
@@ -59,9 +86,69 @@ func (in *Binding) DeepCopy() *Binding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindingStatus) DeepCopyInto(out *BindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterRoleBindings != nil {
+		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeletedNamespaces != nil {
+		in, out := &in.DeletedNamespaces, &out.DeletedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUsed != nil {
+		in, out := &in.LastUsed, &out.LastUsed
+		*out = (*in).DeepCopy()
+	}
+	if in.AppliedAt != nil {
+		in, out := &in.AppliedAt, &out.AppliedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Verifications != nil {
+		in, out := &in.Verifications, &out.Verifications
+		*out = make([]GrantVerification, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingStatus.
+func (in *BindingStatus) DeepCopy() *BindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRoleBinding) DeepCopyInto(out *ClusterRoleBinding) {
 	*out = *in
+	if in.NameSpaceSelector != nil {
+		in, out := &in.NameSpaceSelector, &out.NameSpaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBinding.
@@ -74,6 +161,445 @@ func (in *ClusterRoleBinding) DeepCopy() *ClusterRoleBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Grant) DeepCopyInto(out *Grant) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Grant.
+func (in *Grant) DeepCopy() *Grant {
+	if in == nil {
+		return nil
+	}
+	out := new(Grant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantVerification) DeepCopyInto(out *GrantVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantVerification.
+func (in *GrantVerification) DeepCopy() *GrantVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceTemplate) DeepCopyInto(out *NamespaceTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplate.
+func (in *NamespaceTemplate) DeepCopy() *NamespaceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACControllerConfig) DeepCopyInto(out *RBACControllerConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACControllerConfig.
+func (in *RBACControllerConfig) DeepCopy() *RBACControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RBACControllerConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACControllerConfigList) DeepCopyInto(out *RBACControllerConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RBACControllerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACControllerConfigList.
+func (in *RBACControllerConfigList) DeepCopy() *RBACControllerConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACControllerConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RBACControllerConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACControllerConfigSpec) DeepCopyInto(out *RBACControllerConfigSpec) {
+	*out = *in
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiringWindow != nil {
+		in, out := &in.ExpiringWindow, &out.ExpiringWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxTTLOverrides != nil {
+		in, out := &in.MaxTTLOverrides, &out.MaxTTLOverrides
+		*out = make(map[string]metav1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BreakGlassMaxTTL != nil {
+		in, out := &in.BreakGlassMaxTTL, &out.BreakGlassMaxTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RequireJustification != nil {
+		in, out := &in.RequireJustification, &out.RequireJustification
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TicketURLPattern != nil {
+		in, out := &in.TicketURLPattern, &out.TicketURLPattern
+		*out = new(string)
+		**out = **in
+	}
+	if in.BlockedClusterRoles != nil {
+		in, out := &in.BlockedClusterRoles, &out.BlockedClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedClusterRoles != nil {
+		in, out := &in.AllowedClusterRoles, &out.AllowedClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxBindingsPerRule != nil {
+		in, out := &in.MaxBindingsPerRule, &out.MaxBindingsPerRule
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSubjectsPerRule != nil {
+		in, out := &in.MaxSubjectsPerRule, &out.MaxSubjectsPerRule
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxNamespacesPerRule != nil {
+		in, out := &in.MaxNamespacesPerRule, &out.MaxNamespacesPerRule
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRulesPerTeam != nil {
+		in, out := &in.MaxRulesPerTeam, &out.MaxRulesPerTeam
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnforceRequesterPolicies != nil {
+		in, out := &in.EnforceRequesterPolicies, &out.EnforceRequesterPolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NotifierSecret != nil {
+		in, out := &in.NotifierSecret, &out.NotifierSecret
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.SubjectPrefixes != nil {
+		in, out := &in.SubjectPrefixes, &out.SubjectPrefixes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UsernameTemplate != nil {
+		in, out := &in.UsernameTemplate, &out.UsernameTemplate
+		*out = new(string)
+		**out = **in
+	}
+	if in.RiskApprovals != nil {
+		in, out := &in.RiskApprovals, &out.RiskApprovals
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACControllerConfigSpec.
+func (in *RBACControllerConfigSpec) DeepCopy() *RBACControllerConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACControllerConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACControllerConfigStatus) DeepCopyInto(out *RBACControllerConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACControllerConfigStatus.
+func (in *RBACControllerConfigStatus) DeepCopy() *RBACControllerConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACControllerConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACReport) DeepCopyInto(out *RBACReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Subject = in.Subject
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]Grant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACReport.
+func (in *RBACReport) DeepCopy() *RBACReport {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RBACReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACReportList) DeepCopyInto(out *RBACReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RBACReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACReportList.
+func (in *RBACReportList) DeepCopy() *RBACReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RBACReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACRequesterPolicy) DeepCopyInto(out *RBACRequesterPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACRequesterPolicy.
+func (in *RBACRequesterPolicy) DeepCopy() *RBACRequesterPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACRequesterPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RBACRequesterPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACRequesterPolicyList) DeepCopyInto(out *RBACRequesterPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RBACRequesterPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACRequesterPolicyList.
+func (in *RBACRequesterPolicyList) DeepCopy() *RBACRequesterPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACRequesterPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RBACRequesterPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACRequesterPolicySpec) DeepCopyInto(out *RBACRequesterPolicySpec) {
+	*out = *in
+	in.Requesters.DeepCopyInto(&out.Requesters)
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleTemplates != nil {
+		in, out := &in.RoleTemplates, &out.RoleTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACRequesterPolicySpec.
+func (in *RBACRequesterPolicySpec) DeepCopy() *RBACRequesterPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACRequesterPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RBACRule) DeepCopyInto(out *RBACRule) {
 	*out = *in
@@ -145,6 +671,40 @@ func (in *RBACRuleSpec) DeepCopyInto(out *RBACRuleSpec) {
 	}
 	in.StartTime.DeepCopyInto(&out.StartTime)
 	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.RevocationGracePeriod != nil {
+		in, out := &in.RevocationGracePeriod, &out.RevocationGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NamespaceTemplate != nil {
+		in, out := &in.NamespaceTemplate, &out.NamespaceTemplate
+		*out = new(NamespaceTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RevokeIfUnusedFor != nil {
+		in, out := &in.RevokeIfUnusedFor, &out.RevokeIfUnusedFor
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACRuleSpec.
@@ -162,29 +722,81 @@ func (in *RBACRuleStatus) DeepCopyInto(out *RBACRuleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RoleBindings != nil {
-		in, out := &in.RoleBindings, &out.RoleBindings
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]BindingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACRuleStatus.
+func (in *RBACRuleStatus) DeepCopy() *RBACRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportSubject) DeepCopyInto(out *ReportSubject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSubject.
+func (in *ReportSubject) DeepCopy() *ReportSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Requesters) DeepCopyInto(out *Requesters) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ClusterRoleBindings != nil {
-		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACRuleStatus.
-func (in *RBACRuleStatus) DeepCopy() *RBACRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Requesters.
+func (in *Requesters) DeepCopy() *Requesters {
 	if in == nil {
 		return nil
 	}
-	out := new(RBACRuleStatus)
+	out := new(Requesters)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -198,6 +810,12 @@ func (in *RoleBinding) DeepCopyInto(out *RoleBinding) {
 		copy(*out, *in)
 	}
 	in.NameSpaceSelector.DeepCopyInto(&out.NameSpaceSelector)
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ExcludeNamespaceSelector.DeepCopyInto(&out.ExcludeNamespaceSelector)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleBinding.
@@ -210,15 +828,95 @@ func (in *RoleBinding) DeepCopy() *RoleBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountTemplate) DeepCopyInto(out *ServiceAccountTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountTemplate.
+func (in *ServiceAccountTemplate) DeepCopy() *ServiceAccountTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subject) DeepCopyInto(out *Subject) {
 	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountSelector != nil {
+		in, out := &in.ServiceAccountSelector, &out.ServiceAccountSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Namespaces != nil {
 		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	in.NameSpaceSelector.DeepCopyInto(&out.NameSpaceSelector)
+	if in.ExcludeNamespaces != nil {
+		in, out := &in.ExcludeNamespaces, &out.ExcludeNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.ExcludeNamespaceSelector.DeepCopyInto(&out.ExcludeNamespaceSelector)
+	if in.TokenExpirationSeconds != nil {
+		in, out := &in.TokenExpirationSeconds, &out.TokenExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceAccountTemplate != nil {
+		in, out := &in.ServiceAccountTemplate, &out.ServiceAccountTemplate
+		*out = new(ServiceAccountTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subject.
@@ -1,16 +1,62 @@
 package app
 
 import (
+	"context"
 	"crypto/tls"
+	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
 
 	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
 	"github.com/GGh41th/rbac-controller/cmd/controller-manager/app/options"
+	"github.com/GGh41th/rbac-controller/internal/applyhook"
+	"github.com/GGh41th/rbac-controller/internal/audit"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/certs"
+	"github.com/GGh41th/rbac-controller/internal/clusters"
+	"github.com/GGh41th/rbac-controller/internal/config"
 	"github.com/GGh41th/rbac-controller/internal/controller"
+	"github.com/GGh41th/rbac-controller/internal/expiry"
+	"github.com/GGh41th/rbac-controller/internal/exporter"
+	"github.com/GGh41th/rbac-controller/internal/health"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+	"github.com/GGh41th/rbac-controller/internal/notifier"
+	"github.com/GGh41th/rbac-controller/internal/policy"
+	"github.com/GGh41th/rbac-controller/internal/policyhook"
+	"github.com/GGh41th/rbac-controller/internal/rego"
+	"github.com/GGh41th/rbac-controller/internal/report"
+	"github.com/GGh41th/rbac-controller/internal/restapi"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+	"github.com/GGh41th/rbac-controller/internal/roletemplates"
+	"github.com/GGh41th/rbac-controller/internal/scheduler"
+	"github.com/GGh41th/rbac-controller/internal/sharding"
+	"github.com/GGh41th/rbac-controller/internal/sweeper"
+	"github.com/GGh41th/rbac-controller/internal/teams"
+	"github.com/GGh41th/rbac-controller/internal/tracing"
+	"github.com/GGh41th/rbac-controller/internal/transform"
+	"github.com/GGh41th/rbac-controller/internal/usage"
+	"github.com/GGh41th/rbac-controller/internal/webhook/bindings"
 	rbaccontrollerv1webhook "github.com/GGh41th/rbac-controller/internal/webhook/v1alpha1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -41,10 +87,14 @@ func NewControllerManagerCommand() *cobra.Command {
 			if err := fs.Parse(args); err != nil {
 				return err
 			}
+			if err := checkLeaderElectionLock(opts); err != nil {
+				return err
+			}
 			return runControllerManager(opts)
 		},
 	}
 	cmd.Flags().AddFlagSet(fs)
+	cmd.AddCommand(newCleanupCommand(), newImportCommand())
 	return cmd
 }
 
@@ -57,6 +107,27 @@ func runControllerManager(opts *options.ControllerManagerOptions) error {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&logOpts)))
 
+	if err := checkNamespaceRestriction(opts); err != nil {
+		setupLog.Error(err, "invalid options")
+		return err
+	}
+
+	if opts.OTLPEndpoint != "" {
+		shutdown, err := tracing.Setup(context.Background(), opts.OTLPEndpoint, opts.OTLPInsecure)
+		if err != nil {
+			setupLog.Error(err, "unable to setup tracing")
+			return err
+		}
+		// flush the pending spans once the manager stops.
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				setupLog.Error(err, "unable to flush traces")
+			}
+		}()
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -72,19 +143,52 @@ func runControllerManager(opts *options.ControllerManagerOptions) error {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
+	electionName := controllerName
+	cfg, err := restConfig(opts.Kubeconfig, opts.KubeContext, opts.KubeAPIQPS, opts.KubeAPIBurst)
+	if err != nil {
+		setupLog.Error(err, "Failed to get kubeconfig")
+		return err
+	}
+
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 	webhookServerOptions := webhook.Options{
 		TLSOpts: webhookTLSOpts,
 	}
 
-	if len(opts.WebhookCertPath) > 0 {
+	// the certificates are watched and reloaded when they change , e.g when
+	// cert-manager renews them.
+	var webhookCertWatcher, metricsCertWatcher *certwatcher.CertWatcher
+	var selfSigner *certs.SelfSigner
+	enableWebhook := os.Getenv("ENABLE_WEBHOOK") != "false"
+	if enableWebhook && len(opts.WebhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
 			"webhook-cert-path", opts.WebhookCertPath, "webhook-cert-name", opts.WebhookCertName, "webhook-cert-key", opts.WebhookCertKey)
 
-		webhookServerOptions.CertDir = opts.WebhookCertPath
-		webhookServerOptions.CertName = opts.WebhookCertName
-		webhookServerOptions.KeyName = opts.WebhookCertKey
+		if opts.WebhookSelfSigned {
+			selfSigner, err = newSelfSigner(cfg, opts)
+			if err != nil {
+				setupLog.Error(err, "Failed to initialize self-signed webhook certificate")
+				return err
+			}
+			// the certificate has to exist before it is watched.
+			if err := selfSigner.Sync(context.Background()); err != nil {
+				setupLog.Error(err, "Failed to generate self-signed webhook certificate")
+				return err
+			}
+		}
+
+		webhookCertWatcher, err = certwatcher.New(
+			filepath.Join(opts.WebhookCertPath, opts.WebhookCertName),
+			filepath.Join(opts.WebhookCertPath, opts.WebhookCertKey),
+		)
+		if err != nil {
+			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
+			return err
+		}
+		webhookServerOptions.TLSOpts = append(webhookServerOptions.TLSOpts, func(c *tls.Config) {
+			c.GetCertificate = webhookCertWatcher.GetCertificate
+		})
 	}
 
 	webhookServer := webhook.NewServer(webhookServerOptions)
@@ -102,31 +206,68 @@ func runControllerManager(opts *options.ControllerManagerOptions) error {
 	// If the certificate is not specified, controller-runtime will automatically
 	// generate self-signed certificates for the metrics server. While convenient for development and testing,
 	// this setup is not recommended for production.
-	// [TODO: Integrate with cert-manager]
-	if len(opts.MetricsCertPath) > 0 {
+	if opts.SecureMetrics && len(opts.MetricsCertPath) > 0 {
 		setupLog.Info("Initializing metrics certificate watcher using provided certificates",
 			"metrics-cert-path", opts.MetricsCertPath, "metrics-cert-name", opts.MetricsCertName, "metrics-cert-key", opts.MetricsCertKey)
 
-		metricsServerOptions.CertDir = opts.MetricsCertPath
-		metricsServerOptions.CertName = opts.MetricsCertName
-		metricsServerOptions.KeyName = opts.MetricsCertKey
+		metricsCertWatcher, err = certwatcher.New(
+			filepath.Join(opts.MetricsCertPath, opts.MetricsCertName),
+			filepath.Join(opts.MetricsCertPath, opts.MetricsCertKey),
+		)
+		if err != nil {
+			setupLog.Error(err, "Failed to initialize metrics certificate watcher")
+			return err
+		}
+		metricsServerOptions.TLSOpts = append(metricsServerOptions.TLSOpts, func(c *tls.Config) {
+			c.GetCertificate = metricsCertWatcher.GetCertificate
+		})
 	}
 
-	electionName := controllerName
-	cfg, err := ctrl.GetConfig()
-	if err != nil {
-		setupLog.Error(err, "Failed to get kubeconfig")
-	}
 	mgr, err := ctrl.NewManager(cfg, manager.Options{
-		Metrics:          metricsServerOptions,
-		LeaderElection:   opts.EnableLeaderElection,
-		LeaderElectionID: electionName,
-		PprofBindAddress: opts.ProbeBindAddress,
-		WebhookServer:    webhookServer,
+		Cache:                      newCacheOptions(opts),
+		Metrics:                    metricsServerOptions,
+		LeaderElection:             opts.EnableLeaderElection,
+		LeaderElectionID:           electionName,
+		LeaderElectionResourceLock: opts.LeaderElectionLock,
+		LeaderElectionNamespace:    opts.LeaderElectionNamespace,
+		PprofBindAddress:           opts.ProbeBindAddress,
+		HealthProbeBindAddress:     opts.HealthProbeBindAddress,
+		WebhookServer:              webhookServer,
+		GracefulShutdownTimeout:    &opts.GracefulShutdownTimeout,
 	})
 
 	if err != nil {
 		setupLog.Error(err, "Failed to create manager")
+		return err
+	}
+
+	if webhookCertWatcher != nil {
+		if err := mgr.Add(webhookCertWatcher); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate watcher to manager")
+			return err
+		}
+		if selfSigner != nil {
+			if err := mgr.Add(selfSigner); err != nil {
+				setupLog.Error(err, "unable to add self-signed certificate rotation to manager")
+				return err
+			}
+		}
+		if opts.WebhookCAInjection || opts.WebhookSelfSigned {
+			injector := certs.NewCABundleInjector(mgr.GetClient(), ctrl.Log.WithName("ca-injector"),
+				filepath.Join(opts.WebhookCertPath, opts.WebhookCAName), opts.MutatingWebhookName, opts.ValidatingWebhookName)
+			// the CA is injected again every time the certificate is renewed.
+			webhookCertWatcher.RegisterCallback(func(tls.Certificate) { injector.Trigger() })
+			if err := mgr.Add(injector); err != nil {
+				setupLog.Error(err, "unable to add CA injector to manager")
+				return err
+			}
+		}
+	}
+	if metricsCertWatcher != nil {
+		if err := mgr.Add(metricsCertWatcher); err != nil {
+			setupLog.Error(err, "unable to add metrics certificate watcher to manager")
+			return err
+		}
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -134,33 +275,412 @@ func runControllerManager(opts *options.ControllerManagerOptions) error {
 		return err
 	}
 
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	// the controller is ready once its caches synced and while the API server
+	// can be reached , and once the webhook server started when it is enabled.
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		return err
+	}
+	if err := mgr.AddReadyzCheck("readyz", health.ReadyChecker(mgr.GetCache(), discoveryClient)); err != nil {
 		setupLog.Error(err, "error adding Readyz checker")
 		return err
 	}
+	// the controller isn't ready while it lacks the permissions it needs.
+	permissions := &health.PermissionChecker{
+		Client:      mgr.GetClient(),
+		Log:         ctrl.Log.WithName("permissions"),
+		Permissions: health.Permissions(opts.Namespaces),
+		Interval:    time.Minute,
+	}
+	if err := mgr.Add(permissions); err != nil {
+		setupLog.Error(err, "unable to add permission checker to manager")
+		return err
+	}
+	if err := mgr.AddReadyzCheck("permissions", permissions.Ready); err != nil {
+		setupLog.Error(err, "error adding permissions Readyz checker")
+		return err
+	}
+	if enableWebhook {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "error adding webhook Readyz checker")
+			return err
+		}
+	}
 
 	if err := rbaccontrollerv1.AddToScheme(mgr.GetScheme()); err != nil {
 		setupLog.Error(err, "unable to register scheme", "api", rbaccontrollerv1.GroupVersion.String())
 		return err
 	}
 
-	// TODO(GGh41th) , wrap the registration with the manager in a helper (e.g Add)
-	// this allows to pass a rawLogger (*logr.Logger) , from which we can
-	// create a new logger at each reconcilation and add values (e.g RBACrule name)
+	maxTTLOverrides := map[string]time.Duration{}
+	for role, value := range opts.MaxTTLOverrides {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			setupLog.Error(err, "invalid maximum TTL override", "role", role)
+			return err
+		}
+		maxTTLOverrides[role] = ttl
+	}
+	var ticketURLPattern *regexp.Regexp
+	if opts.TicketURLPattern != "" {
+		pattern, err := config.CompileTicketURLPattern(opts.TicketURLPattern)
+		if err != nil {
+			setupLog.Error(err, "invalid ticket URL pattern")
+			return err
+		}
+		ticketURLPattern = pattern
+	}
+	if err := config.CheckSubjectPrefixes(opts.SubjectPrefixes); err != nil {
+		setupLog.Error(err, "invalid subject prefixes")
+		return err
+	}
+	var usernameTemplate *template.Template
+	if opts.UsernameTemplate != "" {
+		t, err := config.ParseUsernameTemplate(opts.UsernameTemplate)
+		if err != nil {
+			setupLog.Error(err, "invalid username template")
+			return err
+		}
+		usernameTemplate = t
+	}
+	var riskApprovalLevel risk.Level
+	if opts.RiskApprovalLevel != "" {
+		level, err := risk.ParseLevel(opts.RiskApprovalLevel)
+		if err != nil {
+			setupLog.Error(err, "invalid risk approval level")
+			return err
+		}
+		riskApprovalLevel = level
+	}
+	controllerConfig := &config.Config{
+		ProtectedNamespaces:  opts.ProtectedNamespaces,
+		Namespaces:           opts.Namespaces,
+		ExpiringWindow:       opts.ExpiringWindow,
+		DefaultTTL:           opts.DefaultTTL,
+		MaxTTL:               opts.MaxTTL,
+		MaxTTLOverrides:      maxTTLOverrides,
+		BreakGlassMaxTTL:     opts.BreakGlassMaxTTL,
+		RequireJustification: opts.RequireJustification,
+		TicketURLPattern:     ticketURLPattern,
+		BlockedClusterRoles:  opts.BlockedClusterRoles,
+		AllowedClusterRoles:  opts.AllowedClusterRoles,
+		MaxBindingsPerRule:   opts.MaxBindingsPerRule,
+		MaxSubjectsPerRule:   opts.MaxSubjectsPerRule,
+		MaxNamespacesPerRule: opts.MaxNamespacesPerRule,
+		MaxRulesPerTeam:      opts.MaxRulesPerTeam,
 
-	if err := (&controller.RBACRuleReconciler{
+		EnforceRequesterPolicies: opts.EnforceRequesterPolicies,
+		DefaultNamespace:         opts.DefaultNamespace,
+		SubjectPrefixes:          opts.SubjectPrefixes,
+		UsernameTemplate:         usernameTemplate,
+		RiskApprovalLevel:        riskApprovalLevel,
+		RiskApprovals:            opts.RiskApprovals,
+	}
+	// notifications are disabled unless a Secret is provided , by the flag or
+	// by the RBACControllerConfig.
+	if opts.NotifierSecret != "" {
+		ns, name, found := strings.Cut(opts.NotifierSecret, "/")
+		if !found {
+			err := fmt.Errorf("invalid notifier secret %q , expected namespace/name", opts.NotifierSecret)
+			setupLog.Error(err, "unable to setup notifier")
+			return err
+		}
+		controllerConfig.NotifierSecret = types.NamespacedName{Namespace: ns, Name: name}
+	}
+	// notifications are sent in the background , so reconciliations don't
+	// wait for the chat tools.
+	rbacNotifier := &notifier.Queue{
+		Notifier: &notifier.SecretNotifier{
+			Reader:     mgr.GetAPIReader(),
+			SecretName: controllerConfig.GetNotifierSecret,
+		},
+		Log: ctrl.Log.WithName("notifier"),
+	}
+	if err := mgr.Add(rbacNotifier); err != nil {
+		setupLog.Error(err, "unable to add notifier to manager")
+		return err
+	}
+
+	// the policy is overridden by the RBACControllerConfig , which is applied
+	// without restarting.
+	if err := (&config.Watcher{
 		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Log:    ctrl.Log.WithName("controllers").WithName("RBACRule"),
+		Log:    ctrl.Log.WithName("controllerconfig"),
+		Config: controllerConfig,
 	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to setup the controller config watcher")
+		return err
+	}
+
+	// mutations are audited only when a sink is provided.
+	reconcilerClient := mgr.GetClient()
+	auditClient := func(c client.Client) client.Client { return c }
+	if opts.AuditLogPath != "" {
+		sink, err := audit.NewFileSink(opts.AuditLogPath)
+		if err != nil {
+			setupLog.Error(err, "unable to open audit log")
+			return err
+		}
+		auditClient = func(c client.Client) client.Client {
+			return &audit.Client{
+				Client: c,
+				Sink:   sink,
+				Log:    ctrl.Log.WithName("audit"),
+			}
+		}
+		reconcilerClient = auditClient(reconcilerClient)
+	}
+
+	// bindings are written with the controller's credentials unless the
+	// rules creators are impersonated.
+	var impersonationClients *impersonation.Clients
+	if opts.ImpersonateCreator {
+		impersonationClients = &impersonation.Clients{
+			Config: mgr.GetConfig(),
+			Scheme: mgr.GetScheme(),
+			Mapper: mgr.GetRESTMapper(),
+			Wrap:   auditClient,
+		}
+	}
+
+	// bindings are applied without review unless a policy hook is provided.
+	var policyHook policyhook.Hook
+	if opts.PolicyHookURL != "" {
+		policyHook = &policyhook.HTTPHook{
+			URL:        opts.PolicyHookURL,
+			HTTPClient: &http.Client{Timeout: opts.PolicyHookTimeout},
+		}
+	}
+	// the apply hook is either served over HTTP or run as a command.
+	var applyHook applyhook.Hook
+	switch {
+	case opts.ApplyHookURL != "" && len(opts.ApplyHookCommand) > 0:
+		err := fmt.Errorf("--apply-hook-url and --apply-hook-command are mutually exclusive")
+		setupLog.Error(err, "invalid apply hook")
+		return err
+	case opts.ApplyHookURL != "":
+		applyHook = &applyhook.HTTPHook{
+			URL:        opts.ApplyHookURL,
+			HTTPClient: &http.Client{Timeout: opts.ApplyHookTimeout},
+		}
+	case len(opts.ApplyHookCommand) > 0:
+		applyHook = &applyhook.ExecHook{
+			Command: opts.ApplyHookCommand,
+			Timeout: opts.ApplyHookTimeout,
+		}
+	}
+
+	var rbacExporter exporter.Exporter
+	if opts.GitOpsDir != "" {
+		rbacExporter = &exporter.GitExporter{
+			Dir:  opts.GitOpsDir,
+			Path: opts.GitOpsPath,
+			Push: opts.GitOpsPush,
+		}
+	}
+
+	// bindings can only reference role bundles when a catalog is provided.
+	var roleBundles *bundles.ConfigMapCatalog
+	if opts.RoleBundlesConfigMap != "" {
+		ns, name, found := strings.Cut(opts.RoleBundlesConfigMap, "/")
+		if !found {
+			err := fmt.Errorf("invalid role bundles configmap %q , expected namespace/name", opts.RoleBundlesConfigMap)
+			setupLog.Error(err, "unable to setup role bundles")
+			return err
+		}
+		// the manager only caches the metadata of ConfigMaps.
+		roleBundles = &bundles.ConfigMapCatalog{
+			Reader:    mgr.GetAPIReader(),
+			ConfigMap: types.NamespacedName{Namespace: ns, Name: name},
+		}
+	}
+
+	// rules are only scoped to their team when a catalog of teams is provided.
+	var teamCatalog *teams.ConfigMapCatalog
+	if opts.TeamsConfigMap != "" {
+		ns, name, found := strings.Cut(opts.TeamsConfigMap, "/")
+		if !found {
+			err := fmt.Errorf("invalid teams configmap %q , expected namespace/name", opts.TeamsConfigMap)
+			setupLog.Error(err, "unable to setup teams")
+			return err
+		}
+		teamCatalog = &teams.ConfigMapCatalog{
+			Reader:    mgr.GetAPIReader(),
+			ConfigMap: types.NamespacedName{Namespace: ns, Name: name},
+		}
+	}
+
+	// bindings can only reference role templates when their namespace is
+	// provided.
+	var roleTemplates *roletemplates.Source
+	if opts.RoleTemplatesNamespace != "" {
+		roleTemplates = &roletemplates.Source{
+			Reader:    mgr.GetAPIReader(),
+			Namespace: opts.RoleTemplatesNamespace,
+		}
+	}
+
+	// bindings are propagated to member clusters only when a registry is
+	// provided.
+	var clusterRegistry *clusters.Registry
+	if opts.ClusterRegistryNamespace != "" {
+		clusterRegistry = &clusters.Registry{
+			Reader:    mgr.GetAPIReader(),
+			Scheme:    mgr.GetScheme(),
+			Namespace: opts.ClusterRegistryNamespace,
+		}
+	}
+
+	// the rules are partitioned across the replicas only when asked to.
+	var shards *sharding.Membership
+	if opts.ShardRules {
+		if shards, err = newShards(cfg, opts); err != nil {
+			setupLog.Error(err, "unable to setup sharding")
+			return err
+		}
+		if err := mgr.Add(shards); err != nil {
+			setupLog.Error(err, "unable to add shard membership to manager")
+			return err
+		}
+	}
+
+	if err := controller.Add(mgr, ctrl.Log.WithName("controllers").WithName("RBACRule"), &controller.RBACRuleReconciler{
+		Client:        reconcilerClient,
+		Config:        controllerConfig,
+		Notifier:      rbacNotifier,
+		Exporter:      rbacExporter,
+		Clusters:      clusterRegistry,
+		Bundles:       roleBundles,
+		Impersonation: impersonationClients,
+		GrantSamples:  opts.GrantVerificationSamples,
+		UsageRecorded: enableWebhook && opts.RecordUsage,
+		PolicyHook:    policyHook,
+		ApplyHook:     applyHook,
+		Teams:         teamCatalog,
+		RoleTemplates: roleTemplates,
+		Shards:        shards,
+		Scheduler:     &scheduler.Scheduler{Jitter: opts.RequeueJitter},
+
+		RoleRevalidationInterval: opts.RoleRevalidationInterval,
+		NotifyRolesBroadened:     opts.NotifyRolesBroadened,
+		GracefulShutdownTimeout:  opts.GracefulShutdownTimeout,
+	}); err != nil {
 		setupLog.Error(err, "Failed to setup controller with manager")
 		return err
 	}
-	if os.Getenv("ENABLE_WEBHOOK") != "false" {
-		if err := rbaccontrollerv1webhook.SetupRBACRuleWebhookWithManager(mgr); err != nil {
+	if enableWebhook {
+		// rules are validated against the Rego policies only when they are
+		// served.
+		var policies *rego.Evaluator
+		if opts.RegoURL != "" {
+			policies = &rego.Evaluator{URL: opts.RegoURL}
+		}
+		if err := rbaccontrollerv1webhook.SetupRBACRuleWebhookWithManager(mgr, controllerConfig, policies, teamCatalog, roleBundles, roleTemplates); err != nil {
 			setupLog.Error(err, "unable to register webhook with manager")
 			return err
 		}
+		// the webhook allows every change unless bindings are protected , the
+		// controller itself being exempt.
+		protection := &bindings.Protection{
+			Enforce:       opts.ProtectBindings,
+			Reader:        mgr.GetClient(),
+			Impersonation: opts.ImpersonateCreator,
+		}
+		if opts.ProtectBindings {
+			c, err := client.New(cfg, client.Options{Scheme: mgr.GetScheme()})
+			if err != nil {
+				setupLog.Error(err, "unable to create client")
+				return err
+			}
+			user, err := bindings.WhoAmI(context.Background(), c)
+			if err != nil {
+				setupLog.Error(err, "unable to setup binding protection")
+				return err
+			}
+			protection.Users = append([]string{user}, opts.ProtectBindingsExempt...)
+		}
+		protection.SetupWithManager(mgr)
+
+		if opts.RecordUsage {
+			recorder := &usage.Recorder{
+				Client:        mgr.GetClient(),
+				Log:           ctrl.Log.WithName("usage"),
+				FlushInterval: 30 * time.Second,
+			}
+			if err := recorder.SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to setup usage recording")
+				return err
+			}
+		}
+	} else {
+		if opts.RecordUsage {
+			setupLog.Info("Usage isn't recorded , the audit events are received by the webhook server which is disabled")
+		}
+		if opts.RegoURL != "" {
+			setupLog.Info("Rules aren't validated against the Rego policies , the webhook is disabled")
+		}
+		if opts.EnforceRequesterPolicies {
+			setupLog.Info("Requester policies aren't enforced , the webhook is disabled")
+		}
+	}
+	if opts.ExpireAnnotatedBindings {
+		if err := expiry.SetupWithManager(mgr, auditClient(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to setup binding expiry")
+			return err
+		}
+	}
+	// resources left behind by the controller are swept only when an interval
+	// is provided.
+	if opts.OrphanSweepInterval > 0 {
+		if err := mgr.Add(&sweeper.Sweeper{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("sweeper"),
+			Bundles:  roleBundles,
+			Interval: opts.OrphanSweepInterval,
+			DryRun:   opts.OrphanSweepDryRun,
+		}); err != nil {
+			setupLog.Error(err, "unable to add orphan sweeper to manager")
+			return err
+		}
+	}
+	if opts.ReportInterval > 0 {
+		if err := mgr.Add(&report.Publisher{
+			Client:   mgr.GetClient(),
+			Reader:   mgr.GetAPIReader(),
+			Log:      ctrl.Log.WithName("report"),
+			Interval: opts.ReportInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add reporter to manager")
+			return err
+		}
+	}
+	// the checks that can be expressed in CEL are also enforced by a
+	// ValidatingAdmissionPolicy , e.g when the webhook can't run.
+	if opts.AdmissionPolicy != "" {
+		if err := mgr.Add(&policy.Publisher{
+			Client:        mgr.GetClient(),
+			Log:           ctrl.Log.WithName("admission-policy"),
+			Config:        controllerConfig,
+			Name:          opts.AdmissionPolicy,
+			RetryInterval: time.Minute,
+		}); err != nil {
+			setupLog.Error(err, "unable to add admission policy publisher to manager")
+			return err
+		}
+	}
+
+	// the read-only API is only served when an address is provided.
+	if opts.APIBindAddress != "" {
+		apiServer, err := newAPIServer(mgr, cfg, opts, tlsOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to setup the API server")
+			return err
+		}
+		if err := mgr.Add(apiServer); err != nil {
+			setupLog.Error(err, "unable to add the API server to manager")
+			return err
+		}
 	}
 
 	rootCtx := signals.SetupSignalHandler()
@@ -170,3 +690,198 @@ func runControllerManager(opts *options.ControllerManagerOptions) error {
 	}
 	return nil
 }
+
+// newAPIServer returns the server of the read-only API. Requests are
+// authenticated and authorized against the API server , with the path of the
+// request as a non-resource URL. The certificate in --api-cert-path is served
+// and reloaded when it changes , a self-signed one is served when unset.
+func newAPIServer(mgr ctrl.Manager, cfg *rest.Config, opts *options.ControllerManagerOptions, tlsOpts []func(*tls.Config)) (*restapi.Server, error) {
+	filter, err := filters.WithAuthenticationAndAuthorization(cfg, mgr.GetHTTPClient())
+	if err != nil {
+		return nil, err
+	}
+	tlsOpts = slices.Clone(tlsOpts)
+	if opts.APICertPath != "" {
+		watcher, err := certwatcher.New(
+			filepath.Join(opts.APICertPath, "tls.crt"),
+			filepath.Join(opts.APICertPath, "tls.key"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := mgr.Add(watcher); err != nil {
+			return nil, err
+		}
+		tlsOpts = append(tlsOpts, func(c *tls.Config) {
+			c.GetCertificate = watcher.GetCertificate
+		})
+	}
+	return &restapi.Server{
+		Client:      mgr.GetClient(),
+		APIReader:   mgr.GetAPIReader(),
+		Log:         ctrl.Log.WithName("api"),
+		BindAddress: opts.APIBindAddress,
+		Filter:      filter,
+		TLSOpts:     tlsOpts,
+	}, nil
+}
+
+// restConfig returns the configuration of the API server client , loaded from
+// the given kubeconfig and context when set , and throttled as configured.
+func restConfig(kubeconfig, kubeContext string, qps float32, burst int) (*rest.Config, error) {
+	var cfg *rest.Config
+	var err error
+	if kubeconfig != "" {
+		cfg, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+			&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+		).ClientConfig()
+	} else {
+		cfg, err = ctrlconfig.GetConfigWithContext(kubeContext)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg.QPS = qps
+	cfg.Burst = burst
+	return cfg, nil
+}
+
+// newClient returns a client for the CLI commands , knowing about RBACRules.
+func newClient(kubeconfig, kubeContext string) (client.Client, error) {
+	cfg, err := restConfig(kubeconfig, kubeContext, 20, 30)
+	if err != nil {
+		return nil, err
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := rbaccontrollerv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
+// controllerNamespace returns the namespace the controller runs in.
+func controllerNamespace() (string, error) {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns, nil
+	}
+	b, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", fmt.Errorf("unable to find the controller namespace , set POD_NAMESPACE: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// newShards returns the membership of the replica among the ones sharing the
+// rules , the replicas are identified by their hostname , i.e their pod. The
+// Leases aren't cached , so the members are always up to date.
+func newShards(cfg *rest.Config, opts *options.ControllerManagerOptions) (*sharding.Membership, error) {
+	ns := opts.LeaderElectionNamespace
+	if ns == "" {
+		var err error
+		if ns, err = controllerNamespace(); err != nil {
+			return nil, err
+		}
+	}
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &sharding.Membership{
+		Client:        c,
+		Log:           ctrl.Log.WithName("sharding"),
+		Namespace:     ns,
+		Identity:      identity,
+		LeaseDuration: opts.ShardLeaseDuration,
+	}, nil
+}
+
+// newSelfSigner returns the self-signed webhook certificate rotation , the
+// certificate lives in the controller namespace.
+func newSelfSigner(cfg *rest.Config, opts *options.ControllerManagerOptions) (*certs.SelfSigner, error) {
+	ns, err := controllerNamespace()
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &certs.SelfSigner{
+		Client: c,
+		Log:    ctrl.Log.WithName("self-signer"),
+		Secret: types.NamespacedName{Namespace: ns, Name: opts.WebhookCertSecret},
+		DNSNames: []string{
+			fmt.Sprintf("%s.%s.svc", opts.WebhookService, ns),
+			fmt.Sprintf("%s.%s.svc.cluster.local", opts.WebhookService, ns),
+		},
+		Validity:      opts.WebhookCertValidity,
+		CertDir:       opts.WebhookCertPath,
+		CertName:      opts.WebhookCertName,
+		KeyName:       opts.WebhookCertKey,
+		CAName:        opts.WebhookCAName,
+		CheckInterval: time.Hour,
+	}, nil
+}
+
+// newCacheOptions returns the options of the manager's cache. When the
+// controller only manages some namespaces , the namespaced resources are only
+// cached in them. The cache doesn't hold the managed fields of objects , the
+// controller never reads them.
+func newCacheOptions(opts *options.ControllerManagerOptions) cache.Options {
+	cacheOptions := cache.Options{
+		DefaultTransform: transform.Strip(opts.StripLastAppliedConfig),
+		SyncPeriod:       &opts.SyncPeriod,
+	}
+	if len(opts.Namespaces) > 0 {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{}
+		for _, ns := range opts.Namespaces {
+			cacheOptions.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+	return cacheOptions
+}
+
+// checkLeaderElectionLock rejects the lock types other than leases , the only
+// one the Kubernetes client libraries still support.
+func checkLeaderElectionLock(opts *options.ControllerManagerOptions) error {
+	if opts.LeaderElectionLock != resourcelock.LeasesResourceLock {
+		return fmt.Errorf("invalid --leader-elect-resource-lock %q , only %s is supported", opts.LeaderElectionLock, resourcelock.LeasesResourceLock)
+	}
+	return nil
+}
+
+// checkNamespaceRestriction rejects the features needing cluster wide
+// permissions when the controller only manages some namespaces.
+func checkNamespaceRestriction(opts *options.ControllerManagerOptions) error {
+	if len(opts.Namespaces) == 0 {
+		return nil
+	}
+	var flags []string
+	if opts.RoleTemplatesNamespace != "" {
+		flags = append(flags, "--role-templates-namespace")
+	}
+	if opts.GrantVerificationSamples > 0 {
+		flags = append(flags, "--grant-verification-samples")
+	}
+	if opts.OrphanSweepInterval > 0 {
+		flags = append(flags, "--orphan-sweep-interval")
+	}
+	if opts.ReportInterval > 0 {
+		flags = append(flags, "--report-interval")
+	}
+	if opts.ExpireAnnotatedBindings {
+		flags = append(flags, "--expire-annotated-bindings")
+	}
+	if len(flags) > 0 {
+		return fmt.Errorf("%s can't be used with --namespaces , they need cluster wide permissions", strings.Join(flags, ", "))
+	}
+	return nil
+}
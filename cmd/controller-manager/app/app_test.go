@@ -0,0 +1,49 @@
+package app
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/GGh41th/rbac-controller/cmd/controller-manager/app/options"
+)
+
+// parse returns the options of the controller manager parsed from the args.
+func parse(args ...string) *options.ControllerManagerOptions {
+	opts := &options.ControllerManagerOptions{}
+	fs := pflag.NewFlagSet(controllerName, pflag.ContinueOnError)
+	opts.Addflags(fs)
+	Expect(fs.Parse(args)).To(Succeed())
+	return opts
+}
+
+var _ = Describe("newCacheOptions", func() {
+	It("resyncs the cache every 10 hours by default", func() {
+		Expect(newCacheOptions(parse()).SyncPeriod).To(HaveValue(Equal(10 * time.Hour)))
+	})
+
+	It("resyncs the cache every --sync-period", func() {
+		Expect(newCacheOptions(parse("--sync-period=30m")).SyncPeriod).To(HaveValue(Equal(30 * time.Minute)))
+		Expect(newCacheOptions(parse("--sync-period=0")).SyncPeriod).To(HaveValue(BeZero()))
+	})
+
+	It("only caches the namespaced resources of the managed namespaces", func() {
+		Expect(newCacheOptions(parse()).DefaultNamespaces).To(BeEmpty())
+		Expect(newCacheOptions(parse("--namespaces=team-a,team-b")).DefaultNamespaces).To(HaveKey("team-a"))
+	})
+})
+
+var _ = Describe("checkLeaderElectionLock", func() {
+	It("accepts leases , the default lock", func() {
+		Expect(checkLeaderElectionLock(parse())).To(Succeed())
+		Expect(checkLeaderElectionLock(parse("--leader-elect-resource-lock=leases"))).To(Succeed())
+	})
+
+	It("rejects the lock types the client libraries dropped", func() {
+		Expect(checkLeaderElectionLock(parse("--leader-elect-resource-lock=configmapsleases"))).To(
+			MatchError(ContainSubstring(`invalid --leader-elect-resource-lock "configmapsleases" , only leases is supported`)))
+		Expect(checkLeaderElectionLock(parse("--leader-elect-resource-lock=endpointsleases"))).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/GGh41th/rbac-controller/cmd/controller-manager/app/options"
+	"github.com/GGh41th/rbac-controller/internal/cleanup"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func newCleanupCommand() *cobra.Command {
+	opts := &options.CleanupOptions{}
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Remove the rules and every resource the controller created",
+		Long: `Remove the RBACRules , after stripping their finalizer , along with the
+RoleBindings , ClusterRoleBindings , ServiceAccounts and token Secrets the
+controller created for them. Scale the controller down first , or it recreates
+what is being removed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCleanup(cmd.Context(), opts)
+		},
+	}
+	opts.Addflags(cmd.Flags())
+	return cmd
+}
+
+func runCleanup(ctx context.Context, opts *options.CleanupOptions) error {
+	ctrl.SetLogger(zap.New())
+	log := ctrl.Log.WithName("cleanup")
+	if opts.DryRun {
+		log = log.WithValues("dryRun", true)
+	}
+
+	if len(opts.Rules) > 0 && opts.Selector != "" {
+		return errors.New("--rule and --selector can't be used together")
+	}
+	selector, err := labels.Parse(opts.Selector)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient(opts.Kubeconfig, opts.KubeContext)
+	if err != nil {
+		return err
+	}
+
+	cleaner := &cleanup.Cleaner{
+		Client:           c,
+		Log:              log,
+		DeleteNamespaces: opts.DeleteNamespaces,
+		DryRun:           opts.DryRun,
+	}
+	return cleaner.Clean(ctx, opts.Rules, selector)
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"os"
+
+	"github.com/GGh41th/rbac-controller/cmd/controller-manager/app/options"
+	"github.com/GGh41th/rbac-controller/internal/importer"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func newImportCommand() *cobra.Command {
+	opts := &options.ImportOptions{}
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Convert existing RoleBindings and ClusterRoleBindings to RBACRules",
+		Long: `Print the RBACRules equivalent to the existing RoleBindings and
+ClusterRoleBindings the controller doesn't manage. With --adopt , the rules are
+created and the imported bindings labeled with them , so they are revoked along
+with the rules.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd.Context(), opts)
+		},
+	}
+	opts.Addflags(cmd.Flags())
+	return cmd
+}
+
+func runImport(ctx context.Context, opts *options.ImportOptions) error {
+	// logs go to stderr , stdout holds the rules.
+	ctrl.SetLogger(zap.New(zap.WriteTo(os.Stderr)))
+	log := ctrl.Log.WithName("import")
+
+	selector, err := labels.Parse(opts.Selector)
+	if err != nil {
+		return err
+	}
+	c, err := newClient(opts.Kubeconfig, opts.KubeContext)
+	if err != nil {
+		return err
+	}
+
+	i := &importer.Importer{
+		Client:        c,
+		Log:           log,
+		Namespace:     opts.Namespace,
+		Selector:      selector,
+		IncludeSystem: opts.IncludeSystem,
+	}
+	imported, err := i.Import(ctx)
+	if err != nil {
+		return err
+	}
+	if opts.Adopt {
+		for _, r := range imported {
+			if err := i.Adopt(ctx, r); err != nil {
+				return err
+			}
+			log.Info("Adopted bindings", "rule", r.Rule.Name, "bindings", len(r.Bindings))
+		}
+		return nil
+	}
+	out, err := importer.Render(imported)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
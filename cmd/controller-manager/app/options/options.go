@@ -1,33 +1,223 @@
 package options
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
 )
 
 type ControllerManagerOptions struct {
-	MetricsAddr          string
-	MetricsCertPath      string
-	MetricsCertName      string
-	MetricsCertKey       string
-	EnableLeaderElection bool
-	SecureMetrics        bool
-	EnableHTTP2          bool
-	ProbeBindAddress     string
-	WebhookCertPath      string
-	WebhookCertName      string
-	WebhookCertKey       string
+	MetricsAddr              string
+	MetricsCertPath          string
+	MetricsCertName          string
+	MetricsCertKey           string
+	EnableLeaderElection     bool
+	LeaderElectionLock       string
+	LeaderElectionNamespace  string
+	GracefulShutdownTimeout  time.Duration
+	ShardRules               bool
+	ShardLeaseDuration       time.Duration
+	RequeueJitter            time.Duration
+	StripLastAppliedConfig   bool
+	SyncPeriod               time.Duration
+	SecureMetrics            bool
+	EnableHTTP2              bool
+	ProbeBindAddress         string
+	HealthProbeBindAddress   string
+	APIBindAddress           string
+	APICertPath              string
+	WebhookCertPath          string
+	WebhookCertName          string
+	WebhookCertKey           string
+	WebhookCAInjection       bool
+	WebhookCAName            string
+	MutatingWebhookName      string
+	ValidatingWebhookName    string
+	WebhookSelfSigned        bool
+	WebhookCertSecret        string
+	WebhookService           string
+	WebhookCertValidity      time.Duration
+	ProtectedNamespaces      []string
+	DefaultNamespace         string
+	SubjectPrefixes          map[string]string
+	UsernameTemplate         string
+	Namespaces               []string
+	ExpiringWindow           time.Duration
+	DefaultTTL               time.Duration
+	MaxTTL                   time.Duration
+	MaxTTLOverrides          map[string]string
+	BreakGlassMaxTTL         time.Duration
+	RequireJustification     bool
+	TicketURLPattern         string
+	BlockedClusterRoles      []string
+	AllowedClusterRoles      []string
+	MaxBindingsPerRule       int
+	MaxSubjectsPerRule       int
+	MaxNamespacesPerRule     int
+	MaxRulesPerTeam          int
+	RiskApprovalLevel        string
+	RiskApprovals            int
+	NotifierSecret           string
+	AuditLogPath             string
+	GitOpsDir                string
+	GitOpsPath               string
+	GitOpsPush               bool
+	OTLPEndpoint             string
+	OTLPInsecure             bool
+	ClusterRegistryNamespace string
+	RoleBundlesConfigMap     string
+	TeamsConfigMap           string
+	RoleTemplatesNamespace   string
+	AdmissionPolicy          string
+	OrphanSweepInterval      time.Duration
+	OrphanSweepDryRun        bool
+	Kubeconfig               string
+	KubeContext              string
+	KubeAPIQPS               float32
+	KubeAPIBurst             int
+	ImpersonateCreator       bool
+	ProtectBindings          bool
+	ProtectBindingsExempt    []string
+	GrantVerificationSamples int
+	ReportInterval           time.Duration
+	RecordUsage              bool
+	RoleRevalidationInterval time.Duration
+	NotifyRolesBroadened     bool
+	ExpireAnnotatedBindings  bool
+	PolicyHookURL            string
+	PolicyHookTimeout        time.Duration
+	ApplyHookURL             string
+	ApplyHookCommand         []string
+	ApplyHookTimeout         time.Duration
+	RegoURL                  string
+	EnforceRequesterPolicies bool
 }
 
 func (c *ControllerManagerOptions) Addflags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Kubeconfig, "kubeconfig", "", "the kubeconfig file used to reach the cluster when running out of it. The in-cluster configuration , $KUBECONFIG or ~/.kube/config are used when empty")
+	fs.StringVar(&c.KubeContext, "context", "", "the kubeconfig context to use , the current context is used when empty")
+	fs.Float32Var(&c.KubeAPIQPS, "kube-api-qps", 20, "the maximum queries per second sent to the API server")
+	fs.IntVar(&c.KubeAPIBurst, "kube-api-burst", 30, "the maximum burst of queries sent to the API server")
 	fs.StringVar(&c.MetricsAddr, "metrics-bind-address", ":8080", "the address that the metrics server should bind to")
 	fs.StringVar(&c.MetricsCertPath, "metrics-cert-path", "/tmp/k8s-metrics-server/serving-certs", "the directory that contains the metrics server key and certificate")
 	fs.StringVar(&c.MetricsCertName, "metrics-cert-name", "tls.crt", "the metrics server certificate name")
 	fs.StringVar(&c.MetricsCertKey, "metrics-cert-key", "tls.key", "the metrics server key name")
 	fs.StringVar(&c.ProbeBindAddress, "pprof-bind-address", "", "the TCP address that the manager should bind to for serving pprof")
+	fs.StringVar(&c.HealthProbeBindAddress, "health-probe-bind-address", ":8081", "the address the health probes , /healthz and /readyz , are served on. They aren't served when 0")
+	fs.StringVar(&c.APIBindAddress, "api-bind-address", "", "the address the read-only JSON API over the rules is served on , over HTTPS. Requests are authenticated and authorized against the API server. It isn't served when empty")
+	fs.StringVar(&c.APICertPath, "api-cert-path", "", "the directory holding the tls.crt and tls.key of the API server , a self-signed certificate is served when empty")
 	fs.StringVar(&c.WebhookCertPath, "webhook-cert-path", "/tmp/k8s-webhook-server/serving-certs", "the directory that contains the webhook key and certificate")
 	fs.StringVar(&c.WebhookCertName, "webhook-cert-name", "tls.crt", "the webhook server certificate name")
 	fs.StringVar(&c.WebhookCertKey, "webhook-cert-key", "tls.key", "the webhook server key name")
+	fs.BoolVar(&c.WebhookCAInjection, "webhook-ca-injection", false, "inject the CA found in the webhook certificate directory in the webhook configurations , e.g without the cert-manager CA injector")
+	fs.StringVar(&c.WebhookCAName, "webhook-ca-name", "ca.crt", "the webhook CA certificate name")
+	fs.StringVar(&c.MutatingWebhookName, "mutating-webhook-configuration", "rbac-controller-mutating-webhook-configuration", "the name of the MutatingWebhookConfiguration in which the CA is injected")
+	fs.StringVar(&c.ValidatingWebhookName, "validating-webhook-configuration", "rbac-controller-validating-webhook-configuration", "the name of the ValidatingWebhookConfiguration in which the CA is injected")
+	fs.BoolVar(&c.WebhookSelfSigned, "webhook-self-signed", false, "generate and rotate a self-signed webhook certificate , written to the webhook certificate directory , and inject its CA in the webhook configurations")
+	fs.StringVar(&c.WebhookCertSecret, "webhook-cert-secret", "rbac-controller-webhook-server-cert", "the Secret , in the controller namespace , holding the self-signed webhook certificate")
+	fs.StringVar(&c.WebhookService, "webhook-service", "rbac-controller-webhook-service", "the Service , in the controller namespace , the self-signed webhook certificate is issued for")
+	fs.DurationVar(&c.WebhookCertValidity, "webhook-cert-validity", 365*24*time.Hour, "the validity of the self-signed webhook certificate , it is rotated once two thirds of it went by")
 	fs.BoolVar(&c.EnableLeaderElection, "leader-elect", false, "enable leader election for the controller manager")
+	fs.StringVar(&c.LeaderElectionLock, "leader-elect-resource-lock", "leases", "the type of resource the leader election lock is held in , only leases is supported")
+	fs.StringVar(&c.LeaderElectionNamespace, "leader-elect-namespace", "", "the namespace of the leader election lock , and of the Leases of the replicas sharing the rules. The controller namespace when empty")
+	fs.DurationVar(&c.GracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second, "how long the controller waits , once asked to stop , for in-flight reconciles to complete before exiting. It waits for them without a limit when negative")
+	fs.BoolVar(&c.ShardRules, "shard-rules", false, "partition the rules across every replica of the controller , instead of reconciling them all on the leader. The rules are rebalanced when replicas join or leave")
+	fs.DurationVar(&c.ShardLeaseDuration, "shard-lease-duration", 15*time.Second, "how long a replica keeps its rules without renewing its shard Lease , e.g when it crashed")
+	fs.DurationVar(&c.SyncPeriod, "sync-period", 10*time.Hour, "how often every rule is reconciled again , correcting the drift of the objects it generates. Shorter periods correct drift faster at the cost of more load on the API server. Rules are only reconciled on changes when 0")
+	fs.BoolVar(&c.StripLastAppliedConfig, "strip-last-applied-config", false, "drop the kubectl last-applied-configuration annotation from the objects held by the cache , besides their managed fields , to reduce the memory of the controller. Objects it updates from the cache lose the annotation")
 	fs.BoolVar(&c.SecureMetrics, "secureMetrics", false, "enables serving metrics via https")
 	fs.BoolVar(&c.EnableHTTP2, "enableHTTP2", false, "enable HTTP2")
+	fs.StringSliceVar(&c.ProtectedNamespaces, "protected-namespaces", []string{"kube-system", "kube-public", "kube-node-lease"}, "namespaces in which the controller never creates bindings or service accounts")
+	fs.StringVar(&c.DefaultNamespace, "default-namespace", "default", "the namespace given to the service account subjects and the roles of rules that don't select any")
+	fs.StringToStringVar(&c.SubjectPrefixes, "subject-prefixes", nil, "prefixes prepended to the names of User and Group subjects , by kind , e.g User=oidc:,Group=oidc: to match the prefixes the API server gives to the identities of an OIDC provider")
+	fs.StringVar(&c.UsernameTemplate, "username-template", "", "the Go template the names of User subjects are rendered with , before being prefixed , e.g {{ .name }}@corp.example.com. Names are kept when empty")
+	fs.StringSliceVar(&c.Namespaces, "namespaces", nil, "the only namespaces in which the controller manages bindings and service accounts , so it only needs namespaced permissions on them. ClusterRoles can't be granted cluster wide then. Every namespace is managed when empty")
+	fs.DurationVar(&c.ExpiringWindow, "expiring-window", time.Hour, "how long before their end time rules are reported as Expiring")
+	fs.DurationVar(&c.RequeueJitter, "requeue-jitter", 0, "how long , at most , rules are reconciled past their start time , end time or the start of their expiring window , so the rules sharing these times aren't all reconciled at once. The delay of a rule is derived from its name")
+	fs.DurationVar(&c.MaxTTL, "max-ttl", 0, "the longest lifetime , from their start time to their end time , rules can have. It isn't enforced when 0")
+	fs.StringToStringVar(&c.MaxTTLOverrides, "max-ttl-overrides", nil, "maximum lifetimes of the rules binding a given role , cluster role or bundle , e.g cluster-admin=1h,view=0 , 0 lifting the limit. The shortest limit of the roles of a rule applies")
+	fs.DurationVar(&c.BreakGlassMaxTTL, "break-glass-max-ttl", time.Hour, "the longest lifetime of break-glass rules , whatever roles they bind. Break-glass rules without an end time are given this lifetime. It isn't enforced when 0")
+	fs.BoolVar(&c.RequireJustification, "require-justification", false, "reject , through the webhook , rules without a justification")
+	fs.StringVar(&c.TicketURLPattern, "ticket-url-pattern", "", "the regular expression the whole ticket URL of rules must match , e.g https://jira\\.example\\.com/browse/OPS-[0-9]+ . Rules are required to have a ticket URL when it is set")
+	fs.StringSliceVar(&c.BlockedClusterRoles, "blocked-cluster-roles", []string{"cluster-admin", "system:*"}, "the ClusterRoles , * matching any suffix , rules can only bind when annotated with rbac-controller.io/allow-blocked-roles=true by a requester allowed to bind them")
+	fs.StringSliceVar(&c.AllowedClusterRoles, "allowed-cluster-roles", nil, "the only ClusterRoles , * matching any suffix , rules can bind. Any ClusterRole can be bound when empty")
+	fs.IntVar(&c.MaxBindingsPerRule, "max-bindings-per-rule", 0, "the most bindings a rule can have. It isn't enforced when 0")
+	fs.IntVar(&c.MaxSubjectsPerRule, "max-subjects-per-rule", 0, "the most subjects , across its bindings , a rule can have. It isn't enforced when 0")
+	fs.IntVar(&c.MaxNamespacesPerRule, "max-namespaces-per-rule", 0, "the most namespaces , once its selectors are resolved , a rule can create bindings or service accounts in. It isn't enforced when 0")
+	fs.IntVar(&c.MaxRulesPerTeam, "max-rules-per-team", 0, "the most rules labeled with the same rbac-controller.io/team can exist. It isn't enforced when 0")
+	fs.StringVar(&c.RiskApprovalLevel, "risk-approval-level", "", "the risk level , Low , Medium or High , from which rules require --risk-approvals more approvals before they are applied. Rules aren't gated on their risk when empty")
+	fs.IntVar(&c.RiskApprovals, "risk-approvals", 1, "the approvals rules from --risk-approval-level require on top of their own approvalsRequired")
+	fs.DurationVar(&c.DefaultTTL, "default-ttl", 0, "the lifetime , counted from their start time , given by the webhook to rules without an end time. Rules without an end time never expire when 0")
+	fs.StringVar(&c.NotifierSecret, "notifier-secret", "", "the namespace/name of the Secret holding the Slack or Teams webhook URLs used to notify about rules lifecycle")
+	fs.BoolVar(&c.ImpersonateCreator, "impersonate-creator", false, "create bindings on behalf of the user who created each rule , as recorded by the webhook , so the API server prevents rules from granting more than their creator holds")
+	fs.BoolVar(&c.ProtectBindings, "protect-bindings", false, "reject , through the webhook , manual updates and deletions of the bindings managed by the controller")
+	fs.StringSliceVar(&c.ProtectBindingsExempt, "protect-bindings-exempt-users", []string{
+		"system:serviceaccount:kube-system:generic-garbage-collector",
+		"system:serviceaccount:kube-system:namespace-controller",
+		"system:kube-controller-manager",
+	}, "the users , besides the controller , allowed to update and delete the bindings it manages. The garbage collector and namespace deletions need to be allowed")
+	fs.IntVar(&c.GrantVerificationSamples, "grant-verification-samples", 0, "the number of accesses granted by each generated binding checked through a SubjectAccessReview , the results being recorded in the rule status. Verification is disabled when 0")
+	fs.DurationVar(&c.RoleRevalidationInterval, "role-revalidation-interval", time.Hour, "how often the roles bound by active rules are checked to still exist and to be within the risk level recorded by the webhook. Roles aren't revalidated when 0")
+	fs.BoolVar(&c.NotifyRolesBroadened, "notify-roles-broadened", false, "notify when the roles bound by an active rule were broadened beyond its recorded risk level")
+	fs.BoolVar(&c.RecordUsage, "record-usage", false, "receive the API server audit events on the webhook server , under /audit , to record when each binding was last used in the rule status")
+	fs.StringVar(&c.PolicyHookURL, "policy-hook-url", "", "the URL to which the bindings rendered for each rule are posted before being applied , bindings it doesn't allow are blocked. Reviewing is disabled when empty")
+	fs.DurationVar(&c.PolicyHookTimeout, "policy-hook-timeout", 10*time.Second, "how long to wait for the policy hook to respond")
+	fs.StringVar(&c.ApplyHookURL, "apply-hook-url", "", "the URL to which the bindings rendered for each rule are posted before being applied , the hook may mutate them , and once applied")
+	fs.StringSliceVar(&c.ApplyHookCommand, "apply-hook-command", nil, "the command , followed by its arguments , run with the bindings rendered for each rule on its standard input before they are applied , it may mutate them , and once applied. Mutually exclusive with --apply-hook-url")
+	fs.DurationVar(&c.ApplyHookTimeout, "apply-hook-timeout", 10*time.Second, "how long to wait for the apply hook to respond")
+	fs.StringVar(&c.RegoURL, "rego-url", "", "the Open Policy Agent data API URL of the Rego rule holding the violations of the rules , e.g http://127.0.0.1:8181/v1/data/rbaccontroller/deny. Rules are validated against it by the webhook. Evaluation is disabled when empty")
+	fs.BoolVar(&c.EnforceRequesterPolicies, "enforce-requester-policies", false, "reject , through the webhook , rules granting roles or namespaces that no RBACRequesterPolicy applying to their requester allows")
+	fs.StringVar(&c.AuditLogPath, "audit-log-path", "", "the file to which every RBAC mutation performed by the controller is appended , \"-\" means stdout. Auditing is disabled when empty")
+	fs.StringVar(&c.GitOpsDir, "gitops-dir", "", "the git working copy to which the bindings generated for each rule are committed. Exporting is disabled when empty")
+	fs.StringVar(&c.GitOpsPath, "gitops-path", "rbac", "the directory , relative to the git working copy , holding the exported rules")
+	fs.BoolVar(&c.GitOpsPush, "gitops-push", false, "push the exported bindings to the upstream of the working copy's current branch")
+	fs.StringVar(&c.OTLPEndpoint, "otlp-endpoint", "", "the host:port of the OTLP gRPC collector to which traces are exported. Tracing is disabled when empty")
+	fs.BoolVar(&c.OTLPInsecure, "otlp-insecure", false, "disable TLS when exporting traces")
+	fs.DurationVar(&c.OrphanSweepInterval, "orphan-sweep-interval", 0, "how often the bindings and service accounts labeled by the controller that no rule declares are deleted. Sweeping is disabled when 0")
+	fs.DurationVar(&c.ReportInterval, "report-interval", 0, "how often the RBACReports , summarizing the roles each subject holds through the rules , are published. Reporting is disabled when 0")
+	fs.BoolVar(&c.ExpireAnnotatedBindings, "expire-annotated-bindings", false, "delete the RoleBindings and ClusterRoleBindings annotated with rbac-controller.io/expires-at , an RFC 3339 time , once it went by , whether or not a rule generated them")
+	fs.BoolVar(&c.OrphanSweepDryRun, "orphan-sweep-dry-run", false, "only log the orphaned resources found by the sweeper , without deleting them")
+	fs.StringVar(&c.AdmissionPolicy, "admission-policy", "", "the name of the ValidatingAdmissionPolicy , and of its binding , published to validate rules without the webhook. Set ENABLE_WEBHOOK=false to run without the webhook. Publishing is disabled when empty")
+	fs.StringVar(&c.RoleBundlesConfigMap, "role-bundles-configmap", "", "the namespace/name of the ConfigMap mapping role bundle names to ClusterRoles")
+	fs.StringVar(&c.TeamsConfigMap, "teams-configmap", "", "the namespace/name of the ConfigMap mapping teams to the ClusterRoles their rules may bind. The rules labeled with rbac-controller.io/team only grant access in the team's namespaces when set")
+	fs.StringVar(&c.RoleTemplatesNamespace, "role-templates-namespace", "", "the namespace holding the role templates , ConfigMaps with the PolicyRules of the ClusterRoles generated for the bindings referencing them. Role templates are disabled when empty")
+	fs.StringVar(&c.ClusterRegistryNamespace, "cluster-registry-namespace", "", "the namespace holding the kubeconfig Secrets of the member clusters rules can propagate bindings to. Multi-cluster propagation is disabled when empty")
+}
+
+// CleanupOptions are the options of the cleanup command.
+type CleanupOptions struct {
+	Kubeconfig       string
+	KubeContext      string
+	Rules            []string
+	Selector         string
+	DeleteNamespaces bool
+	DryRun           bool
+}
+
+func (c *CleanupOptions) Addflags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Kubeconfig, "kubeconfig", "", "the kubeconfig file used to reach the cluster. The in-cluster configuration , $KUBECONFIG or ~/.kube/config are used when empty")
+	fs.StringVar(&c.KubeContext, "context", "", "the kubeconfig context to use , the current context is used when empty")
+	fs.StringSliceVar(&c.Rules, "rule", nil, "the rules to remove along with their resources. Every rule , and every resource labeled by the controller , is removed when neither --rule nor --selector is set")
+	fs.StringVarP(&c.Selector, "selector", "l", "", "the label selector of the rules to remove along with their resources")
+	fs.BoolVar(&c.DeleteNamespaces, "delete-namespaces", false, "also delete the namespaces created for ServiceAccount subjects , they are only unlabeled otherwise")
+	fs.BoolVar(&c.DryRun, "dry-run", false, "only print what would be removed")
+}
+
+// ImportOptions are the options of the import command.
+type ImportOptions struct {
+	Kubeconfig    string
+	KubeContext   string
+	Namespace     string
+	Selector      string
+	IncludeSystem bool
+	Adopt         bool
+}
+
+func (c *ImportOptions) Addflags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Kubeconfig, "kubeconfig", "", "the kubeconfig file used to reach the cluster. The in-cluster configuration , $KUBECONFIG or ~/.kube/config are used when empty")
+	fs.StringVar(&c.KubeContext, "context", "", "the kubeconfig context to use , the current context is used when empty")
+	fs.StringVarP(&c.Namespace, "namespace", "n", "", "only import the RoleBindings of this namespace , ClusterRoleBindings are skipped when set")
+	fs.StringVarP(&c.Selector, "selector", "l", "", "the label selector of the bindings to import")
+	fs.BoolVar(&c.IncludeSystem, "include-system", false, "also import the bindings created by the API server and the ones named system:*")
+	fs.BoolVar(&c.Adopt, "adopt", false, "create the rules and label the imported bindings with them , instead of only printing the rules")
 }
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package applyhook calls a plugin before and after the bindings rendered for a
+// rule are applied , so organizations can mutate them or propagate them , e.g
+// to their ticket system. Plugins are served over HTTP or run as commands.
+package applyhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Stage is when the hook is called.
+type Stage string
+
+const (
+	// PreApply hooks are called before the bindings are applied , they may
+	// mutate them.
+	PreApply Stage = "PreApply"
+	// PostApply hooks are called once the bindings were applied.
+	PostApply Stage = "PostApply"
+)
+
+// Request is sent to the hook , as JSON , for each binding of a rule.
+type Request struct {
+	Stage Stage `json:"stage"`
+	// Name of the rule.
+	Rule string `json:"rule"`
+	// Name of the binding within the rule.
+	Binding string `json:"binding"`
+	// Username of the rule's creator , as recorded at admission.
+	Creator       string `json:"creator,omitempty"`
+	Justification string `json:"justification,omitempty"`
+	TicketURL     string `json:"ticketURL,omitempty"`
+	// The ServiceAccounts the binding creates , as namespace/name.
+	ServiceAccounts     []string                    `json:"serviceAccounts,omitempty"`
+	RoleBindings        []rbacv1.RoleBinding        `json:"roleBindings,omitempty"`
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding `json:"clusterRoleBindings,omitempty"`
+}
+
+// Response is returned by the hook. Only the responses to PreApply requests
+// are used.
+type Response struct {
+	// The bindings applied instead of the rendered ones of the same kind ,
+	// each of them must be one of the rendered bindings. The rendered
+	// bindings of a kind are applied as they are when it is omitted , the ones
+	// left out of it aren't applied.
+	RoleBindings        []rbacv1.RoleBinding        `json:"roleBindings"`
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding `json:"clusterRoleBindings"`
+}
+
+// Hook is called before and after the bindings of rules are applied.
+type Hook interface {
+	Call(ctx context.Context, req Request) (Response, error)
+}
+
+// Mutate replaces the bindings of the PreApply request by the ones of the
+// response. Hooks can't create bindings the rule didn't render , or detach
+// them from the rule: their rule label and owner references are kept.
+func Mutate(req *Request, resp Response) error {
+	if resp.RoleBindings != nil {
+		rbs := make([]rbacv1.RoleBinding, 0, len(resp.RoleBindings))
+		for _, rb := range resp.RoleBindings {
+			i := indexOf(req.RoleBindings, func(r rbacv1.RoleBinding) metav1.ObjectMeta { return r.ObjectMeta }, rb.ObjectMeta)
+			if i < 0 {
+				return fmt.Errorf("hook returned RoleBinding %s/%s , it wasn't rendered for the binding", rb.Namespace, rb.Name)
+			}
+			keepOwnership(&rb.ObjectMeta, req.RoleBindings[i].ObjectMeta)
+			rbs = append(rbs, rb)
+		}
+		req.RoleBindings = rbs
+	}
+	if resp.ClusterRoleBindings != nil {
+		crbs := make([]rbacv1.ClusterRoleBinding, 0, len(resp.ClusterRoleBindings))
+		for _, crb := range resp.ClusterRoleBindings {
+			i := indexOf(req.ClusterRoleBindings, func(r rbacv1.ClusterRoleBinding) metav1.ObjectMeta { return r.ObjectMeta }, crb.ObjectMeta)
+			if i < 0 {
+				return fmt.Errorf("hook returned ClusterRoleBinding %s , it wasn't rendered for the binding", crb.Name)
+			}
+			keepOwnership(&crb.ObjectMeta, req.ClusterRoleBindings[i].ObjectMeta)
+			crbs = append(crbs, crb)
+		}
+		req.ClusterRoleBindings = crbs
+	}
+	return nil
+}
+
+// indexOf returns the index of the object named as m among objs , -1 when
+// missing.
+func indexOf[T any](objs []T, metaOf func(T) metav1.ObjectMeta, m metav1.ObjectMeta) int {
+	for i, obj := range objs {
+		if o := metaOf(obj); o.Namespace == m.Namespace && o.Name == m.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// keepOwnership restores the rule label and the owner references of the
+// rendered object on the mutated one.
+func keepOwnership(mutated *metav1.ObjectMeta, rendered metav1.ObjectMeta) {
+	if mutated.Labels == nil {
+		mutated.Labels = map[string]string{}
+	}
+	mutated.Labels[constants.RBACRuleLabel] = rendered.Labels[constants.RBACRuleLabel]
+	mutated.OwnerReferences = rendered.OwnerReferences
+}
+
+// HTTPHook posts the requests to a URL and decodes its responses. Responses
+// with a non 2xx status are errors.
+type HTTPHook struct {
+	URL string
+	// Defaults to a client with a 10 seconds timeout.
+	HTTPClient *http.Client
+}
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+func (h *HTTPHook) Call(ctx context.Context, req Request) (Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return Response{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	c := h.HTTPClient
+	if c == nil {
+		c = defaultHTTPClient
+	}
+	resp, err := c.Do(httpReq)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Response{}, fmt.Errorf("apply hook %s returned %s", httpReq.URL.Host, resp.Status)
+	}
+	out := Response{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Response{}, fmt.Errorf("invalid response from apply hook %s: %w", httpReq.URL.Host, err)
+	}
+	return out, nil
+}
+
+// ExecHook runs a command for each request , writing the request to its
+// standard input and reading the response from its standard output. Commands
+// exiting with a non zero status are errors.
+type ExecHook struct {
+	// The command and its arguments.
+	Command []string
+	// Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+func (h *ExecHook) Call(ctx context.Context, req Request) (Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, err
+	}
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return Response{}, fmt.Errorf("apply hook %s failed: %w: %s", h.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	out := Response{}
+	// commands printing nothing don't mutate the bindings.
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return Response{}, fmt.Errorf("invalid response from apply hook %s: %w", h.Command[0], err)
+	}
+	return out, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applyhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Hooks", func() {
+	ctx := context.Background()
+
+	owner := []metav1.OwnerReference{{Kind: "RBACRule", Name: "oncall", UID: "oncall-uid"}}
+	request := func() Request {
+		return Request{
+			Stage:   PreApply,
+			Rule:    "oncall",
+			Binding: "sre",
+			RoleBindings: []rbacv1.RoleBinding{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "oncall-sre-view",
+					Namespace:       "team-a",
+					Labels:          map[string]string{constants.RBACRuleLabel: "oncall"},
+					OwnerReferences: owner,
+				},
+				Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}},
+				RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "oncall-sre-view",
+					Namespace:       "team-b",
+					Labels:          map[string]string{constants.RBACRuleLabel: "oncall"},
+					OwnerReferences: owner,
+				},
+				Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}},
+				RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+			}},
+			ClusterRoleBindings: []rbacv1.ClusterRoleBinding{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "oncall-sre-admin",
+					Labels:          map[string]string{constants.RBACRuleLabel: "oncall"},
+					OwnerReferences: owner,
+				},
+				RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+			}},
+		}
+	}
+
+	Context("Mutate", func() {
+		It("should keep the rendered bindings when the hook returns none", func() {
+			req := request()
+			Expect(Mutate(&req, Response{})).To(Succeed())
+			Expect(req).To(Equal(request()))
+		})
+
+		It("should apply the bindings returned by the hook", func() {
+			req := request()
+			mutated := req.RoleBindings[1]
+			mutated.Labels = map[string]string{"cost-center": "42"}
+			mutated.OwnerReferences = nil
+			Expect(Mutate(&req, Response{RoleBindings: []rbacv1.RoleBinding{mutated}})).To(Succeed())
+
+			Expect(req.RoleBindings).To(HaveLen(1))
+			rb := req.RoleBindings[0]
+			Expect(rb.Namespace).To(Equal("team-b"))
+			// the binding stays attached to the rule.
+			Expect(rb.Labels).To(Equal(map[string]string{"cost-center": "42", constants.RBACRuleLabel: "oncall"}))
+			Expect(rb.OwnerReferences).To(Equal(owner))
+			// the kinds the hook didn't return are left as they are.
+			Expect(req.ClusterRoleBindings).To(Equal(request().ClusterRoleBindings))
+		})
+
+		It("should drop every binding of a kind when the hook returns an empty list", func() {
+			req := request()
+			Expect(Mutate(&req, Response{ClusterRoleBindings: []rbacv1.ClusterRoleBinding{}})).To(Succeed())
+			Expect(req.ClusterRoleBindings).To(BeEmpty())
+			Expect(req.RoleBindings).To(HaveLen(2))
+		})
+
+		It("should reject bindings that weren't rendered", func() {
+			req := request()
+			extra := rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "backdoor"}}
+			Expect(Mutate(&req, Response{ClusterRoleBindings: []rbacv1.ClusterRoleBinding{extra}})).
+				To(MatchError(ContainSubstring("backdoor")))
+		})
+	})
+
+	Context("HTTPHook", func() {
+		var (
+			server   *httptest.Server
+			received []Request
+			status   int
+		)
+
+		BeforeEach(func() {
+			received, status = nil, http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got := Request{}
+				Expect(json.NewDecoder(r.Body).Decode(&got)).To(Succeed())
+				received = append(received, got)
+				w.WriteHeader(status)
+				Expect(json.NewEncoder(w).Encode(Response{RoleBindings: got.RoleBindings[:1]})).To(Succeed())
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should post the request and return the response", func() {
+			resp, err := (&HTTPHook{URL: server.URL}).Call(ctx, request())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(received).To(HaveLen(1))
+			Expect(received[0].Stage).To(Equal(PreApply))
+			Expect(received[0].Rule).To(Equal("oncall"))
+			Expect(resp.RoleBindings).To(HaveLen(1))
+			Expect(resp.ClusterRoleBindings).To(BeNil())
+		})
+
+		It("should fail on a non 2xx status", func() {
+			status = http.StatusBadGateway
+			_, err := (&HTTPHook{URL: server.URL}).Call(ctx, request())
+			Expect(err).To(MatchError(ContainSubstring("502")))
+		})
+	})
+
+	Context("ExecHook", func() {
+		It("should pass the request on the standard input and read the response", func() {
+			h := &ExecHook{Command: []string{"sh", "-c", `grep -q '"stage":"PostApply"' && echo '{"clusterRoleBindings": []}'`}}
+			req := request()
+			req.Stage = PostApply
+			resp, err := h.Call(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.ClusterRoleBindings).To(BeEmpty())
+			Expect(resp.ClusterRoleBindings).NotTo(BeNil())
+			Expect(resp.RoleBindings).To(BeNil())
+		})
+
+		It("should not mutate anything when the command prints nothing", func() {
+			resp, err := (&ExecHook{Command: []string{"sh", "-c", "cat >/dev/null"}}).Call(ctx, request())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(Response{}))
+		})
+
+		It("should fail when the command fails", func() {
+			_, err := (&ExecHook{Command: []string{"sh", "-c", "echo 'ticket system unreachable' >&2; exit 1"}}).Call(ctx, request())
+			Expect(err).To(MatchError(ContainSubstring("ticket system unreachable")))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package approvals records the users who approved rules requiring approvals.
+// Users approve a rule by setting the approve annotation on it , the webhook
+// replaces it with their identity as captured at admission , so approvals
+// can't be forged.
+package approvals
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Approvers returns the distinct users who approved obj , in approval order.
+func Approvers(obj client.Object) ([]string, error) {
+	v, found := obj.GetAnnotations()[constants.ApprovedByAnnotation]
+	if !found {
+		return nil, nil
+	}
+	var approvers []string
+	if err := json.Unmarshal([]byte(v), &approvers); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", constants.ApprovedByAnnotation, err)
+	}
+	return approvers, nil
+}
+
+// Approve records the user as an approver of obj , approving twice counts
+// once.
+func Approve(obj client.Object, username string) error {
+	approvers, err := Approvers(obj)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(approvers, username) {
+		return nil
+	}
+	b, err := json.Marshal(append(approvers, username))
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.ApprovedByAnnotation] = string(b)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// Reset removes the approvals of obj.
+func Reset(obj client.Object) {
+	annotations := obj.GetAnnotations()
+	delete(annotations, constants.ApprovedByAnnotation)
+	obj.SetAnnotations(annotations)
+}
+
+// Missing returns how many more approvals obj needs to have the required
+// ones.
+func Missing(obj client.Object, required int32) (int, error) {
+	approvers, err := Approvers(obj)
+	if err != nil {
+		return int(required), err
+	}
+	return max(int(required)-len(approvers), 0), nil
+}
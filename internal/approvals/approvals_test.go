@@ -0,0 +1,61 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approvals
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Approvals", func() {
+	var rule *rbaccontrollerv1.RBACRule
+
+	BeforeEach(func() {
+		rule = &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "rule"}}
+	})
+
+	It("records distinct approvers", func() {
+		Expect(Approve(rule, "alice")).To(Succeed())
+		Expect(Approve(rule, "bob")).To(Succeed())
+		Expect(Approve(rule, "alice")).To(Succeed())
+
+		Expect(Approvers(rule)).To(Equal([]string{"alice", "bob"}))
+		Expect(rule.Annotations).To(HaveKeyWithValue(constants.ApprovedByAnnotation, `["alice","bob"]`))
+	})
+
+	It("counts the missing approvals", func() {
+		Expect(Missing(rule, 2)).To(Equal(2))
+		Expect(Approve(rule, "alice")).To(Succeed())
+		Expect(Missing(rule, 2)).To(Equal(1))
+		Expect(Missing(rule, 0)).To(Equal(0))
+
+		Reset(rule)
+		Expect(Approvers(rule)).To(BeEmpty())
+	})
+
+	It("fails on an invalid annotation", func() {
+		rule.Annotations = map[string]string{constants.ApprovedByAnnotation: "alice"}
+
+		_, err := Approvers(rule)
+		Expect(err).To(MatchError(ContainSubstring("invalid rbac-controller.io/approved-by annotation")))
+		Expect(Approve(rule, "bob")).NotTo(Succeed())
+	})
+})
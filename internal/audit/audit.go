@@ -0,0 +1,190 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit keeps a persistent trail of the RBAC mutations performed by
+// the controller.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+)
+
+// Action is the mutation performed on an object.
+type Action string
+
+const (
+	ActionCreate Action = "Create"
+	ActionUpdate Action = "Update"
+	ActionPatch  Action = "Patch"
+	ActionDelete Action = "Delete"
+)
+
+// Mutation describes a single change the controller made to an object.
+type Mutation struct {
+	Time      time.Time `json:"time"`
+	Action    Action    `json:"action"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	// Name of the RBACRule the object belongs to.
+	Rule     string           `json:"rule,omitempty"`
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+	RoleRef  *rbacv1.RoleRef  `json:"roleRef,omitempty"`
+	// The rules of ClusterRoles.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// Whether the object belongs to a break-glass rule , and why it was
+	// requested.
+	BreakGlass bool   `json:"breakGlass,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	// The user who created the rule , as recorded by the webhook.
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// Sink stores the audit trail.
+type Sink interface {
+	Record(ctx context.Context, e Mutation) error
+}
+
+// FileSink appends mutations to a file as JSON lines.
+type FileSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileSink opens the file at path for appending , "-" writes to stdout.
+func NewFileSink(path string) (*FileSink, error) {
+	if path == "-" {
+		return &FileSink{w: os.Stdout}, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{w: f}, nil
+}
+
+func (s *FileSink) Record(_ context.Context, e Mutation) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// recordedKinds are the kinds of the objects recorded by the Client.
+var recordedKinds = []string{"RoleBinding", "ClusterRoleBinding", "ClusterRole", "ServiceAccount", "Namespace"}
+
+// Client records the RoleBindings , ClusterRoleBindings , ClusterRoles ,
+// ServiceAccounts and Namespaces mutated through it. Only successful mutations are recorded , and
+// failing to record them doesn't fail the mutation.
+type Client struct {
+	client.Client
+	Sink Sink
+	Log  logr.Logger
+}
+
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, ActionCreate, obj)
+	return nil
+}
+
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, ActionUpdate, obj)
+	return nil
+}
+
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, ActionPatch, obj)
+	return nil
+}
+
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	c.record(ctx, ActionDelete, obj)
+	return nil
+}
+
+func (c *Client) record(ctx context.Context, action Action, obj client.Object) {
+	e := Mutation{
+		Time:      time.Now().UTC(),
+		Action:    action,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Rule:      obj.GetLabels()[constants.RBACRuleLabel],
+	}
+	e.Reason, e.BreakGlass = obj.GetAnnotations()[constants.BreakGlassReasonAnnotation]
+	if u, found, err := impersonation.Creator(obj); err == nil && found {
+		e.CreatedBy = u.Username
+	}
+	switch o := obj.(type) {
+	case *rbacv1.RoleBinding:
+		e.Kind = "RoleBinding"
+		e.Subjects = o.Subjects
+		e.RoleRef = &o.RoleRef
+	case *rbacv1.ClusterRoleBinding:
+		e.Kind = "ClusterRoleBinding"
+		e.Subjects = o.Subjects
+		e.RoleRef = &o.RoleRef
+	case *rbacv1.ClusterRole:
+		e.Kind = "ClusterRole"
+		e.Rules = o.Rules
+	case *corev1.ServiceAccount:
+		e.Kind = "ServiceAccount"
+	case *corev1.Namespace:
+		e.Kind = "Namespace"
+	case *metav1.PartialObjectMetadata:
+		// objects handled through their metadata , e.g deleted ones , are
+		// recorded without their subjects and role.
+		e.Kind = o.GetObjectKind().GroupVersionKind().Kind
+		if !slices.Contains(recordedKinds, e.Kind) {
+			return
+		}
+	default:
+		return
+	}
+	if err := c.Sink.Record(ctx, e); err != nil {
+		c.Log.Error(err, "Failed to record audit entry", "kind", e.Kind, "name", e.Name, "namespace", e.Namespace)
+	}
+}
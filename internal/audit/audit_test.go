@@ -0,0 +1,190 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Client", func() {
+	ctx := context.Background()
+
+	var (
+		path string
+		c    *Client
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "audit.log")
+		sink, err := NewFileSink(path)
+		Expect(err).NotTo(HaveOccurred())
+		c = &Client{
+			Client: fake.NewClientBuilder().Build(),
+			Sink:   sink,
+			Log:    logr.Discard(),
+		}
+	})
+
+	mutations := func() []Mutation {
+		f, err := os.Open(path)
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close() //nolint:errcheck
+
+		var out []Mutation
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			e := Mutation{}
+			Expect(json.Unmarshal(scanner.Bytes(), &e)).To(Succeed())
+			out = append(out, e)
+		}
+		return out
+	}
+
+	It("should record binding mutations along with their subjects and role", func() {
+		rb := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev",
+				Namespace: "team-a",
+				Labels:    map[string]string{constants.RBACRuleLabel: "developers"},
+			},
+			Subjects: []rbacv1.Subject{{Kind: "User", Name: "alice"}},
+			RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+		}
+		Expect(c.Create(ctx, rb)).To(Succeed())
+		Expect(c.Delete(ctx, rb)).To(Succeed())
+
+		e := mutations()
+		Expect(e).To(HaveLen(2))
+		Expect(e[0].Action).To(Equal(ActionCreate))
+		Expect(e[0].Kind).To(Equal("RoleBinding"))
+		Expect(e[0].Rule).To(Equal("developers"))
+		Expect(e[0].Subjects).To(Equal(rb.Subjects))
+		Expect(e[0].RoleRef).To(Equal(&rb.RoleRef))
+		Expect(e[1].Action).To(Equal(ActionDelete))
+	})
+
+	It("should flag the objects of break-glass rules", func() {
+		crb := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "incident",
+				Labels:      map[string]string{constants.RBACRuleLabel: "incident-42"},
+				Annotations: map[string]string{constants.BreakGlassReasonAnnotation: "INC-42 database outage"},
+			},
+			Subjects: []rbacv1.Subject{{Kind: "User", Name: "alice"}},
+			RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		}
+		Expect(c.Create(ctx, crb)).To(Succeed())
+
+		e := mutations()
+		Expect(e).To(HaveLen(1))
+		Expect(e[0].BreakGlass).To(BeTrue())
+		Expect(e[0].Reason).To(Equal("INC-42 database outage"))
+	})
+
+	It("should record the creator of the rule", func() {
+		rb := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "debug",
+				Namespace:   "team-a",
+				Labels:      map[string]string{constants.RBACRuleLabel: "debug"},
+				Annotations: map[string]string{constants.CreatedByAnnotation: `{"username":"alice","groups":["sre"]}`},
+			},
+			RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+		}
+		Expect(c.Create(ctx, rb)).To(Succeed())
+
+		e := mutations()
+		Expect(e).To(HaveLen(1))
+		Expect(e[0].CreatedBy).To(Equal("alice"))
+	})
+
+	It("should record ClusterRole mutations along with their rules", func() {
+		cr := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "rbac-controller-debug-logs",
+				Labels: map[string]string{constants.RBACRuleLabel: "debug"},
+			},
+			Rules: []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods/log"}, Verbs: []string{"get"}}},
+		}
+		Expect(c.Create(ctx, cr)).To(Succeed())
+
+		meta := &metav1.PartialObjectMetadata{ObjectMeta: cr.ObjectMeta}
+		meta.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"))
+		Expect(c.Delete(ctx, meta)).To(Succeed())
+
+		e := mutations()
+		Expect(e).To(HaveLen(2))
+		Expect(e[0].Kind).To(Equal("ClusterRole"))
+		Expect(e[0].Rules).To(Equal(cr.Rules))
+		Expect(e[1].Action).To(Equal(ActionDelete))
+		Expect(e[1].Kind).To(Equal("ClusterRole"))
+		Expect(e[1].Rule).To(Equal("debug"))
+	})
+
+	It("should record the objects deleted through their metadata", func() {
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name:      "ci",
+			Namespace: "team-a",
+			Labels:    map[string]string{constants.RBACRuleLabel: "ci"},
+		}}
+		Expect(c.Create(ctx, sa)).To(Succeed())
+
+		meta := &metav1.PartialObjectMetadata{}
+		meta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ServiceAccount"))
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(sa), meta)).To(Succeed())
+		Expect(c.Delete(ctx, meta)).To(Succeed())
+
+		secret := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "team-a"}}
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		Expect(c.Client.Create(ctx, &corev1.Secret{ObjectMeta: secret.ObjectMeta})).To(Succeed())
+		Expect(c.Delete(ctx, secret)).To(Succeed())
+
+		e := mutations()
+		Expect(e).To(HaveLen(2))
+		Expect(e[1].Action).To(Equal(ActionDelete))
+		Expect(e[1].Kind).To(Equal("ServiceAccount"))
+		Expect(e[1].Name).To(Equal("ci"))
+		Expect(e[1].Rule).To(Equal("ci"))
+	})
+
+	It("should not record other objects", func() {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "team-a"}}
+		Expect(c.Create(ctx, secret)).To(Succeed())
+		Expect(mutations()).To(BeEmpty())
+	})
+
+	It("should not record failed mutations", func() {
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "ci", Namespace: "team-a"}}
+		Expect(c.Delete(ctx, sa)).NotTo(Succeed())
+		Expect(mutations()).To(BeEmpty())
+	})
+})
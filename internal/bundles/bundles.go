@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundles reads the catalog of role bundles , friendly names such as
+// readonly or deployer standing for a set of ClusterRoles that bindings can
+// reference.
+package bundles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Catalog maps bundle names to ClusterRoles.
+type Catalog map[string][]string
+
+// Resolve returns the ClusterRoles of the bundle.
+func (c Catalog) Resolve(name string) ([]string, error) {
+	roles, ok := c[name]
+	if !ok {
+		return nil, fmt.Errorf("role bundle %s is not defined", name)
+	}
+	return roles, nil
+}
+
+// ConfigMapCatalog reads the catalog from a ConfigMap , each key is a bundle
+// and its value lists the ClusterRoles separated by commas or newlines:
+//
+//	readonly: view
+//	deployer: edit,rbac-controller-deployer
+//
+// The ConfigMap is read on each load , so bundles can be changed without
+// restarting the controller. A missing ConfigMap is an empty catalog.
+type ConfigMapCatalog struct {
+	Reader    client.Reader
+	ConfigMap types.NamespacedName
+}
+
+// Load reads the catalog.
+func (c *ConfigMapCatalog) Load(ctx context.Context) (Catalog, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Reader.Get(ctx, c.ConfigMap, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return Catalog{}, nil
+		}
+		return nil, err
+	}
+
+	catalog := make(Catalog, len(cm.Data))
+	for name, value := range cm.Data {
+		var roles []string
+		for _, r := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+			if r = strings.TrimSpace(r); r != "" {
+				roles = append(roles, r)
+			}
+		}
+		catalog[name] = roles
+	}
+	return catalog, nil
+}
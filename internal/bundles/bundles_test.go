@@ -0,0 +1,76 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundles
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ConfigMapCatalog", func() {
+	ctx := context.Background()
+	name := types.NamespacedName{Namespace: "rbac-controller-system", Name: "role-bundles"}
+
+	It("reads the bundles of the ConfigMap", func() {
+		c := &ConfigMapCatalog{
+			Reader: fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name},
+				Data: map[string]string{
+					"readonly": "view",
+					"deployer": "edit, deployer\n",
+					"debugger": "view\npod-exec\n",
+				},
+			}).Build(),
+			ConfigMap: name,
+		}
+
+		catalog, err := c.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(catalog).To(Equal(Catalog{
+			"readonly": {"view"},
+			"deployer": {"edit", "deployer"},
+			"debugger": {"view", "pod-exec"},
+		}))
+	})
+
+	It("is empty when the ConfigMap is missing", func() {
+		c := &ConfigMapCatalog{Reader: fake.NewClientBuilder().Build(), ConfigMap: name}
+
+		catalog, err := c.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(catalog).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Catalog", func() {
+	It("resolves the ClusterRoles of a bundle", func() {
+		roles, err := Catalog{"readonly": {"view"}}.Resolve("readonly")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roles).To(Equal([]string{"view"}))
+	})
+
+	It("fails on an unknown bundle", func() {
+		_, err := Catalog{}.Resolve("deployer")
+		Expect(err).To(MatchError("role bundle deployer is not defined"))
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs manages the certificates served by the controller.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;update
+
+// CABundleInjector keeps the caBundle of the webhook configurations in sync
+// with the CA found at CAPath , e.g the ca.crt of a cert-manager Secret. It
+// injects the CA when started and every time Trigger is called.
+type CABundleInjector struct {
+	Client client.Client
+	Log    logr.Logger
+	CAPath string
+
+	MutatingWebhookConfiguration   string
+	ValidatingWebhookConfiguration string
+
+	trigger chan struct{}
+}
+
+// NewCABundleInjector returns an injector for the given webhook
+// configurations.
+func NewCABundleInjector(c client.Client, log logr.Logger, caPath, mutating, validating string) *CABundleInjector {
+	return &CABundleInjector{
+		Client:                         c,
+		Log:                            log,
+		CAPath:                         caPath,
+		MutatingWebhookConfiguration:   mutating,
+		ValidatingWebhookConfiguration: validating,
+		trigger:                        make(chan struct{}, 1),
+	}
+}
+
+// Trigger schedules an injection , it never blocks.
+func (i *CABundleInjector) Trigger() {
+	select {
+	case i.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Start implements manager.Runnable.
+func (i *CABundleInjector) Start(ctx context.Context) error {
+	i.Trigger()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-i.trigger:
+			if err := i.Inject(ctx); err != nil {
+				i.Log.Error(err, "Failed to inject the CA bundle in the webhook configurations")
+			}
+		}
+	}
+}
+
+// Inject sets the CA as the caBundle of every webhook of the configurations.
+// Missing configurations are ignored.
+func (i *CABundleInjector) Inject(ctx context.Context) error {
+	ca, err := os.ReadFile(i.CAPath)
+	if err != nil {
+		return err
+	}
+
+	mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := i.inject(ctx, i.MutatingWebhookConfiguration, mwc, func() bool {
+		changed := false
+		for j := range mwc.Webhooks {
+			changed = setCABundle(&mwc.Webhooks[j].ClientConfig, ca) || changed
+		}
+		return changed
+	}); err != nil {
+		return err
+	}
+
+	vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	return i.inject(ctx, i.ValidatingWebhookConfiguration, vwc, func() bool {
+		changed := false
+		for j := range vwc.Webhooks {
+			changed = setCABundle(&vwc.Webhooks[j].ClientConfig, ca) || changed
+		}
+		return changed
+	})
+}
+
+// inject gets the named configuration into obj , sets the CA through set and
+// updates the configuration when set reports a change.
+func (i *CABundleInjector) inject(ctx context.Context, name string, obj client.Object, set func() bool) error {
+	if name == "" {
+		return nil
+	}
+	if err := i.Client.Get(ctx, types.NamespacedName{Name: name}, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !set() {
+		return nil
+	}
+	if err := i.Client.Update(ctx, obj); err != nil {
+		return err
+	}
+	i.Log.Info("Injected the CA bundle", "webhookConfiguration", name)
+	return nil
+}
+
+func setCABundle(cc *admissionregistrationv1.WebhookClientConfig, ca []byte) bool {
+	if bytes.Equal(cc.CABundle, ca) {
+		return false
+	}
+	cc.CABundle = ca
+	return true
+}
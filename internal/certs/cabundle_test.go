@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CABundleInjector", func() {
+	ctx := context.Background()
+	ca := []byte("-----BEGIN CERTIFICATE-----\n...\n-----END CERTIFICATE-----\n")
+
+	var (
+		c        client.Client
+		caPath   string
+		injector *CABundleInjector
+	)
+
+	BeforeEach(func() {
+		caPath = filepath.Join(GinkgoT().TempDir(), "ca.crt")
+		Expect(os.WriteFile(caPath, ca, 0o600)).To(Succeed())
+
+		c = fake.NewClientBuilder().WithObjects(
+			&admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+				Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "mrbacrule-v1alpha1.kb.io"}},
+			},
+			&admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "validating"},
+				Webhooks: []admissionregistrationv1.ValidatingWebhook{
+					{Name: "vrbacrule-v1alpha1.kb.io"},
+					{Name: "vother.kb.io", ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte("old")}},
+				},
+			},
+		).Build()
+		injector = NewCABundleInjector(c, logr.Discard(), caPath, "mutating", "validating")
+	})
+
+	It("should inject the CA in every webhook", func() {
+		Expect(injector.Inject(ctx)).To(Succeed())
+
+		mwc := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "mutating"}, mwc)).To(Succeed())
+		Expect(mwc.Webhooks[0].ClientConfig.CABundle).To(Equal(ca))
+
+		vwc := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "validating"}, vwc)).To(Succeed())
+		Expect(vwc.Webhooks[0].ClientConfig.CABundle).To(Equal(ca))
+		Expect(vwc.Webhooks[1].ClientConfig.CABundle).To(Equal(ca))
+	})
+
+	It("should ignore missing configurations", func() {
+		injector.MutatingWebhookConfiguration = "missing"
+		Expect(injector.Inject(ctx)).To(Succeed())
+	})
+
+	It("should fail when the CA can't be read", func() {
+		injector.CAPath = filepath.Join(filepath.Dir(caPath), "missing.crt")
+		Expect(injector.Inject(ctx)).NotTo(Succeed())
+	})
+})
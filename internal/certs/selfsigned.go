@@ -0,0 +1,259 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CAKey is the key of the Secret holding the CA bundle.
+const CAKey = "ca.crt"
+
+// SelfSigner generates a self-signed CA and a serving certificate for the
+// webhook , and rotates them once two thirds of their validity went by. They
+// are stored in a Secret shared by all the replicas , and written to CertDir
+// where the certificate watcher picks them up. The Secret's ca.crt holds the
+// previous CA along with the current one , so webhook calls keep working while
+// the new CA is being injected.
+type SelfSigner struct {
+	Client client.Client
+	Log    logr.Logger
+	Secret types.NamespacedName
+	// Names the serving certificate is valid for , e.g the webhook Service
+	// names.
+	DNSNames []string
+	Validity time.Duration
+
+	CertDir  string
+	CertName string
+	KeyName  string
+	CAName   string
+
+	// How often the certificate is checked for rotation.
+	CheckInterval time.Duration
+}
+
+// Start implements manager.Runnable.
+func (s *SelfSigner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.Sync(ctx); err != nil {
+				s.Log.Error(err, "Failed to sync the webhook certificate")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable , every replica
+// needs the certificate files.
+func (s *SelfSigner) NeedLeaderElection() bool {
+	return false
+}
+
+// Sync makes sure the Secret holds a valid certificate , rotating it when
+// needed , and writes it to CertDir.
+func (s *SelfSigner) Sync(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, s.Secret, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		data, err := generate(s.DNSNames, s.Validity, nil)
+		if err != nil {
+			return err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: s.Secret.Name, Namespace: s.Secret.Namespace},
+			Type:       corev1.SecretTypeTLS,
+			Data:       data,
+		}
+		if err := s.Client.Create(ctx, secret); err != nil {
+			// another replica created it first.
+			if !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+			if err := s.Client.Get(ctx, s.Secret, secret); err != nil {
+				return err
+			}
+		} else {
+			s.Log.Info("Generated the webhook certificate", "secret", s.Secret)
+		}
+	} else if s.needsRotation(secret.Data[corev1.TLSCertKey]) {
+		data, err := generate(s.DNSNames, s.Validity, secret.Data[CAKey])
+		if err != nil {
+			return err
+		}
+		secret.Data = data
+		if err := s.Client.Update(ctx, secret); err != nil {
+			// another replica rotated it first.
+			if !apierrors.IsConflict(err) {
+				return err
+			}
+			if err := s.Client.Get(ctx, s.Secret, secret); err != nil {
+				return err
+			}
+		} else {
+			s.Log.Info("Rotated the webhook certificate", "secret", s.Secret)
+		}
+	}
+
+	for name, key := range map[string]string{
+		s.CertName: corev1.TLSCertKey,
+		s.KeyName:  corev1.TLSPrivateKeyKey,
+		s.CAName:   CAKey,
+	} {
+		if err := writeFile(filepath.Join(s.CertDir, name), secret.Data[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// needsRotation reports whether the certificate is invalid , doesn't cover the
+// DNS names anymore or went through two thirds of its validity.
+func (s *SelfSigner) needsRotation(certPEM []byte) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	if !slices.Equal(cert.DNSNames, s.DNSNames) {
+		return true
+	}
+	return time.Until(cert.NotAfter) < cert.NotAfter.Sub(cert.NotBefore)/3
+}
+
+// generate generates a CA and a serving certificate signed by it. The first
+// certificate of the previous CA bundle is kept in the new bundle while it is
+// still valid.
+func generate(dnsNames []string, validity time.Duration, previousCA []byte) (map[string][]byte, error) {
+	now := time.Now()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: "rbac-controller-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	if block, _ := pem.Decode(previousCA); block != nil {
+		if previous, err := x509.ParseCertificate(block.Bytes); err == nil && previous.NotAfter.After(now) {
+			bundle = append(bundle, pem.EncodeToMemory(block)...)
+		}
+	}
+
+	return map[string][]byte{
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		CAKey:                   bundle,
+	}, nil
+}
+
+func serialNumber() *big.Int {
+	n, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	return n
+}
+
+// writeFile atomically replaces the file when its content changed , so the
+// certificate watcher never reads a partially written file.
+func writeFile(path string, data []byte) error {
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("SelfSigner", func() {
+	ctx := context.Background()
+	secretName := types.NamespacedName{Namespace: "rbac-controller-system", Name: "webhook-server-cert"}
+
+	var (
+		c      client.Client
+		signer *SelfSigner
+	)
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().Build()
+		signer = &SelfSigner{
+			Client:   c,
+			Log:      logr.Discard(),
+			Secret:   secretName,
+			DNSNames: []string{"webhook-service.rbac-controller-system.svc"},
+			Validity: 24 * time.Hour,
+			CertDir:  GinkgoT().TempDir(),
+			CertName: "tls.crt",
+			KeyName:  "tls.key",
+			CAName:   "ca.crt",
+		}
+	})
+
+	read := func(name string) []byte {
+		b, err := os.ReadFile(filepath.Join(signer.CertDir, name))
+		Expect(err).NotTo(HaveOccurred())
+		return b
+	}
+
+	It("should generate a certificate trusted by the CA bundle", func() {
+		Expect(signer.Sync(ctx)).To(Succeed())
+
+		pair, err := tls.X509KeyPair(read("tls.crt"), read("tls.key"))
+		Expect(err).NotTo(HaveOccurred())
+		cert, err := x509.ParseCertificate(pair.Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(read("ca.crt"))).To(BeTrue())
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: signer.DNSNames[0], Roots: roots})
+		Expect(err).NotTo(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, secretName, secret)).To(Succeed())
+		Expect(secret.Data[corev1.TLSCertKey]).To(Equal(read("tls.crt")))
+	})
+
+	It("should keep a valid certificate", func() {
+		Expect(signer.Sync(ctx)).To(Succeed())
+		cert := read("tls.crt")
+
+		Expect(signer.Sync(ctx)).To(Succeed())
+		Expect(read("tls.crt")).To(Equal(cert))
+	})
+
+	It("should rotate the certificate when the DNS names change , keeping the previous CA", func() {
+		Expect(signer.Sync(ctx)).To(Succeed())
+		cert, previousCA := read("tls.crt"), read("ca.crt")
+
+		signer.DNSNames = []string{"other-service.rbac-controller-system.svc"}
+		Expect(signer.Sync(ctx)).To(Succeed())
+		Expect(read("tls.crt")).NotTo(Equal(cert))
+		Expect(string(read("ca.crt"))).To(HaveSuffix(string(previousCA)))
+	})
+})
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cleanup removes everything the controller created , to
+// decommission it without leaving orphaned bindings or rules stuck on their
+// finalizer.
+package cleanup
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/controller"
+)
+
+// Cleaner deletes the resources carrying the rule label , then removes the
+// finalizer of the rules and deletes them.
+type Cleaner struct {
+	Client client.Client
+	Log    logr.Logger
+	// Also delete the namespaces created for ServiceAccount subjects , they
+	// are only unlabeled otherwise.
+	DeleteNamespaces bool
+	// Only report what would be removed , without changing anything.
+	DryRun bool
+}
+
+// Clean removes the rules named in rules , or matching selector when no rule
+// is named , along with their resources. When neither is set , every rule is
+// removed along with all the resources carrying the rule label , including
+// those of rules that don't exist anymore.
+func (c *Cleaner) Clean(ctx context.Context, rules []string, selector labels.Selector) error {
+	all := len(rules) == 0 && (selector == nil || selector.Empty())
+	if len(rules) == 0 {
+		list := &rbaccontrollerv1.RBACRuleList{}
+		if err := c.Client.List(ctx, list, client.MatchingLabelsSelector{Selector: orEverything(selector)}); err != nil {
+			return err
+		}
+		for _, r := range list.Items {
+			rules = append(rules, r.Name)
+		}
+		if len(rules) == 0 && !all {
+			c.Log.Info("No rule matches the selector")
+			return nil
+		}
+	}
+
+	op, values := selection.In, rules
+	if all {
+		op, values = selection.Exists, nil
+	}
+	req, err := labels.NewRequirement(constants.RBACRuleLabel, op, values)
+	if err != nil {
+		return err
+	}
+	opts := client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*req)}
+
+	if err := c.cleanResources(ctx, opts); err != nil {
+		return err
+	}
+	for _, name := range rules {
+		if err := c.cleanRule(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanResources deletes the bindings , ServiceAccounts and token Secrets
+// matching opts , and deletes or unlabels the namespaces.
+func (c *Cleaner) cleanResources(ctx context.Context, opts client.ListOption) error {
+	var objs []client.Object
+	rbs := &rbacv1.RoleBindingList{}
+	if err := c.Client.List(ctx, rbs, opts); err != nil {
+		return err
+	}
+	for i := range rbs.Items {
+		objs = append(objs, &rbs.Items[i])
+	}
+	crbs := &rbacv1.ClusterRoleBindingList{}
+	if err := c.Client.List(ctx, crbs, opts); err != nil {
+		return err
+	}
+	for i := range crbs.Items {
+		objs = append(objs, &crbs.Items[i])
+	}
+	sas := &corev1.ServiceAccountList{}
+	if err := c.Client.List(ctx, sas, opts); err != nil {
+		return err
+	}
+	for i := range sas.Items {
+		objs = append(objs, &sas.Items[i])
+	}
+	secrets := &corev1.SecretList{}
+	if err := c.Client.List(ctx, secrets, opts); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		objs = append(objs, &secrets.Items[i])
+	}
+	for _, obj := range objs {
+		if err := c.delete(ctx, obj); err != nil {
+			return err
+		}
+	}
+
+	nss := &corev1.NamespaceList{}
+	if err := c.Client.List(ctx, nss, opts); err != nil {
+		return err
+	}
+	for i := range nss.Items {
+		ns := &nss.Items[i]
+		if c.DeleteNamespaces {
+			if err := c.delete(ctx, ns); err != nil {
+				return err
+			}
+			continue
+		}
+		log := c.Log.WithValues("kind", "Namespace", "name", ns.Name)
+		if c.DryRun {
+			log.Info("Would unlabel")
+			continue
+		}
+		base := ns.DeepCopy()
+		delete(ns.Labels, constants.RBACRuleLabel)
+		if err := c.Client.Patch(ctx, ns, client.MergeFrom(base)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("Unlabeled")
+	}
+	return nil
+}
+
+// cleanRule removes the finalizer of the rule and deletes it.
+func (c *Cleaner) cleanRule(ctx context.Context, name string) error {
+	rule := &rbaccontrollerv1.RBACRule{}
+	if err := c.Client.Get(ctx, types.NamespacedName{Name: name}, rule); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			c.Log.Info("Rule not found", "rule", name)
+			return nil
+		}
+		return err
+	}
+	if !c.DryRun && controllerutil.ContainsFinalizer(rule, controller.RBACRuleFinalizer) {
+		base := rule.DeepCopy()
+		controllerutil.RemoveFinalizer(rule, controller.RBACRuleFinalizer)
+		if err := c.Client.Patch(ctx, rule, client.MergeFrom(base)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return c.delete(ctx, rule)
+}
+
+func (c *Cleaner) delete(ctx context.Context, obj client.Object) error {
+	log := c.Log.WithValues("kind", kindOf(obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+	if c.DryRun {
+		log.Info("Would delete")
+		return nil
+	}
+	if err := c.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	log.Info("Deleted")
+	return nil
+}
+
+func orEverything(selector labels.Selector) labels.Selector {
+	if selector == nil {
+		return labels.Everything()
+	}
+	return selector
+}
+
+func kindOf(obj client.Object) string {
+	switch obj.(type) {
+	case *rbacv1.RoleBinding:
+		return "RoleBinding"
+	case *rbacv1.ClusterRoleBinding:
+		return "ClusterRoleBinding"
+	case *corev1.ServiceAccount:
+		return "ServiceAccount"
+	case *corev1.Secret:
+		return "Secret"
+	case *corev1.Namespace:
+		return "Namespace"
+	default:
+		return "RBACRule"
+	}
+}
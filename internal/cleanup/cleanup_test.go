@@ -0,0 +1,135 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/controller"
+)
+
+func ruleLabels(rule string) map[string]string {
+	return map[string]string{constants.RBACRuleLabel: rule}
+}
+
+func exists(c client.Client, obj client.Object) bool {
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj)
+	if apierrors.IsNotFound(err) {
+		return false
+	}
+	Expect(err).NotTo(HaveOccurred())
+	return true
+}
+
+var _ = Describe("Cleaner", func() {
+	ctx := context.Background()
+	var c client.Client
+	var cleaner *Cleaner
+
+	rule := func(name string, l map[string]string) *rbaccontrollerv1.RBACRule {
+		return &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Labels:     l,
+			Finalizers: []string{controller.RBACRuleFinalizer},
+		}}
+	}
+	crb := func(name, rule string) *rbacv1.ClusterRoleBinding {
+		return &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: ruleLabels(rule)},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			rule("oncall", map[string]string{"team": "sre"}),
+			rule("audit", nil),
+			crb("oncall-view", "oncall"),
+			crb("audit-view", "audit"),
+			crb("gone-view", "gone"),
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "oncall-edit", Namespace: "ops", Labels: ruleLabels("oncall")},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+			},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "pager", Namespace: "ops", Labels: ruleLabels("oncall")}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pager-token", Namespace: "ops", Labels: ruleLabels("oncall")}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ops", Labels: ruleLabels("oncall")}},
+		).Build()
+		cleaner = &Cleaner{Client: c, Log: logr.Discard()}
+	})
+
+	It("removes the selected rules and their resources", func() {
+		Expect(cleaner.Clean(ctx, nil, labels.SelectorFromSet(map[string]string{"team": "sre"}))).To(Succeed())
+
+		Expect(exists(c, &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "oncall"}})).To(BeFalse())
+		Expect(exists(c, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "oncall-view"}})).To(BeFalse())
+		Expect(exists(c, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "oncall-edit", Namespace: "ops"}})).To(BeFalse())
+		Expect(exists(c, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "pager", Namespace: "ops"}})).To(BeFalse())
+		Expect(exists(c, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pager-token", Namespace: "ops"}})).To(BeFalse())
+
+		// the namespace is kept , without the rule label.
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ops"}}
+		Expect(exists(c, ns)).To(BeTrue())
+		Expect(ns.Labels).NotTo(HaveKey(constants.RBACRuleLabel))
+
+		Expect(exists(c, &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "audit"}})).To(BeTrue())
+		Expect(exists(c, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "audit-view"}})).To(BeTrue())
+		Expect(exists(c, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "gone-view"}})).To(BeTrue())
+	})
+
+	It("removes every rule and labeled resource when nothing is selected", func() {
+		cleaner.DeleteNamespaces = true
+		Expect(cleaner.Clean(ctx, nil, nil)).To(Succeed())
+
+		rules := &rbaccontrollerv1.RBACRuleList{}
+		Expect(c.List(ctx, rules)).To(Succeed())
+		Expect(rules.Items).To(BeEmpty())
+		crbs := &rbacv1.ClusterRoleBindingList{}
+		Expect(c.List(ctx, crbs)).To(Succeed())
+		Expect(crbs.Items).To(BeEmpty())
+		Expect(exists(c, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ops"}})).To(BeFalse())
+	})
+
+	It("changes nothing in dry run mode", func() {
+		cleaner.DryRun = true
+		Expect(cleaner.Clean(ctx, []string{"oncall"}, nil)).To(Succeed())
+
+		r := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "oncall"}}
+		Expect(exists(c, r)).To(BeTrue())
+		Expect(r.Finalizers).To(ContainElement(controller.RBACRuleFinalizer))
+		Expect(exists(c, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "oncall-view"}})).To(BeTrue())
+	})
+})
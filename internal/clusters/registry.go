@@ -0,0 +1,126 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusters keeps the registry of the member clusters on which rules
+// can create bindings.
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// KubeconfigKey is the key of the registry Secrets holding the kubeconfig of
+// the member cluster.
+const KubeconfigKey = "kubeconfig"
+
+// Cluster is a member cluster.
+type Cluster struct {
+	Name   string
+	Client client.Client
+}
+
+// Registry lists the member clusters from the Secrets of Namespace labeled
+// with the cluster label , whose value is the cluster name. Clients are
+// cached until their Secret changes.
+type Registry struct {
+	// Reader reads the Secrets uncached , e.g the manager's API reader so
+	// the Secrets of the cluster aren't all cached.
+	Reader    client.Reader
+	Scheme    *runtime.Scheme
+	Namespace string
+
+	mu      sync.Mutex
+	clients map[string]cachedClient
+}
+
+type cachedClient struct {
+	resourceVersion string
+	client          client.Client
+}
+
+// Clusters returns the clusters whose Secret matches the selector.
+func (r *Registry) Clusters(ctx context.Context, selector *metav1.LabelSelector) ([]Cluster, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	return r.list(ctx, sel)
+}
+
+// Get returns the named cluster , false when it isn't registered anymore.
+func (r *Registry) Get(ctx context.Context, name string) (Cluster, bool, error) {
+	clusters, err := r.list(ctx, labels.SelectorFromSet(labels.Set{constants.ClusterLabel: name}))
+	if err != nil || len(clusters) == 0 {
+		return Cluster{}, false, err
+	}
+	return clusters[0], true, nil
+}
+
+func (r *Registry) list(ctx context.Context, sel labels.Selector) ([]Cluster, error) {
+	registered, err := labels.NewRequirement(constants.ClusterLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	secrets := &corev1.SecretList{}
+	if err := r.Reader.List(ctx, secrets, client.InNamespace(r.Namespace), client.MatchingLabelsSelector{Selector: sel.Add(*registered)}); err != nil {
+		return nil, err
+	}
+
+	clusters := make([]Cluster, 0, len(secrets.Items))
+	for _, s := range secrets.Items {
+		c, err := r.client(&s)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", s.Labels[constants.ClusterLabel], err)
+		}
+		clusters = append(clusters, Cluster{Name: s.Labels[constants.ClusterLabel], Client: c})
+	}
+	return clusters, nil
+}
+
+// client returns the client of the cluster registered by the Secret.
+func (r *Registry) client(s *corev1.Secret) (client.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if c, ok := r.clients[s.Name]; ok && c.resourceVersion == s.ResourceVersion {
+		return c.client, nil
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(s.Data[KubeconfigKey])
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: r.Scheme})
+	if err != nil {
+		return nil, err
+	}
+	if r.clients == nil {
+		r.clients = map[string]cachedClient{}
+	}
+	r.clients[s.Name] = cachedClient{resourceVersion: s.ResourceVersion, client: c}
+	return c, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: member
+  cluster:
+    server: https://member.example.com:6443
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: member
+  context:
+    cluster: member
+    user: admin
+current-context: member
+`
+
+func clusterSecret(name, cluster string, extra map[string]string) *corev1.Secret {
+	l := map[string]string{constants.ClusterLabel: cluster}
+	for k, v := range extra {
+		l[k] = v
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "rbac-controller-system", Labels: l},
+		Data:       map[string][]byte{KubeconfigKey: []byte(kubeconfig)},
+	}
+}
+
+var _ = Describe("Registry", func() {
+	ctx := context.Background()
+
+	newRegistry := func(objs ...*corev1.Secret) *Registry {
+		b := fake.NewClientBuilder()
+		for _, o := range objs {
+			b = b.WithObjects(o)
+		}
+		return &Registry{Reader: b.Build(), Scheme: scheme.Scheme, Namespace: "rbac-controller-system"}
+	}
+
+	It("lists the clusters matching the selector", func() {
+		r := newRegistry(
+			clusterSecret("eu", "eu-west", map[string]string{"env": "prod"}),
+			clusterSecret("us", "us-east", map[string]string{"env": "dev"}),
+		)
+
+		clusters, err := r.Clusters(ctx, &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(HaveLen(1))
+		Expect(clusters[0].Name).To(Equal("eu-west"))
+		Expect(clusters[0].Client).NotTo(BeNil())
+	})
+
+	It("ignores Secrets without the cluster label or in other namespaces", func() {
+		unlabeled := clusterSecret("unlabeled", "", nil)
+		unlabeled.Labels = nil
+		other := clusterSecret("other", "other", nil)
+		other.Namespace = "default"
+		r := newRegistry(unlabeled, other)
+
+		clusters, err := r.Clusters(ctx, &metav1.LabelSelector{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(BeEmpty())
+	})
+
+	It("gets a cluster by name", func() {
+		r := newRegistry(clusterSecret("eu", "eu-west", nil))
+
+		c, found, err := r.Get(ctx, "eu-west")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(c.Name).To(Equal("eu-west"))
+
+		_, found, err = r.Get(ctx, "us-east")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("reuses the client until the Secret changes", func() {
+		s := clusterSecret("eu", "eu-west", nil)
+		r := newRegistry(s)
+
+		first, _, err := r.Get(ctx, "eu-west")
+		Expect(err).NotTo(HaveOccurred())
+		second, _, err := r.Get(ctx, "eu-west")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.Client).To(BeIdenticalTo(first.Client))
+
+		c := r.Reader.(client.Client)
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(s), s)).To(Succeed())
+		s.Data[KubeconfigKey] = []byte(kubeconfig + "\n")
+		Expect(c.Update(ctx, s)).To(Succeed())
+		third, _, err := r.Get(ctx, "eu-west")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(third.Client).NotTo(BeIdenticalTo(first.Client))
+	})
+
+	It("fails on an invalid kubeconfig", func() {
+		s := clusterSecret("eu", "eu-west", nil)
+		s.Data[KubeconfigKey] = []byte("not a kubeconfig")
+		r := newRegistry(s)
+
+		_, _, err := r.Get(ctx, "eu-west")
+		Expect(err).To(MatchError(ContainSubstring("cluster eu-west")))
+	})
+})
@@ -0,0 +1,514 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/GGh41th/rbac-controller/internal/risk"
+)
+
+// DefaultNamespace is the namespace given to the ServiceAccount subjects and
+// the Roles of rules that don't select any , unless the config sets another
+// one.
+const DefaultNamespace = "default"
+
+// Config holds the controller wide policy , shared by the reconciler and the
+// webhooks. It can be replaced while the controller runs with Set. Its fields
+// are only set to build a config , once shared they must not be read nor
+// modified directly , which would race with Set , but through its methods.
+// Namespaces is the exception , it never changes once shared.
+type Config struct {
+	mu sync.RWMutex
+	// Called after the config was replaced.
+	listeners []func()
+
+	// Namespaces in which the controller never creates bindings or
+	// ServiceAccounts.
+	ProtectedNamespaces []string
+
+	// The only namespaces in which the controller manages bindings and
+	// ServiceAccounts , so it doesn't need cluster wide permissions on them.
+	// ClusterRoles can't be granted cluster wide then. Every namespace is
+	// managed when empty.
+	Namespaces []string
+
+	// How long before their EndTime rules are reported as Expiring.
+	ExpiringWindow time.Duration
+
+	// Lifetime given to rules without an EndTime , counted from their
+	// StartTime. Rules without an EndTime never expire when it is 0.
+	DefaultTTL time.Duration
+
+	// The longest lifetime , from StartTime to EndTime , a rule can have. It
+	// isn't enforced when 0.
+	MaxTTL time.Duration
+	// Maximum lifetimes overriding MaxTTL for the rules binding the given
+	// Role , ClusterRole or bundle.
+	MaxTTLOverrides map[string]time.Duration
+
+	// The longest lifetime of break-glass rules , whatever roles they bind.
+	// It is also the lifetime given to break-glass rules without an EndTime.
+	// It isn't enforced when 0.
+	BreakGlassMaxTTL time.Duration
+
+	// Whether rules must have a justification.
+	RequireJustification bool
+	// The pattern the ticket URL of rules must match , rules are required to
+	// have one when it is set.
+	TicketURLPattern *regexp.Regexp
+
+	// ClusterRoles rules can only bind when they are explicitly allowed to ,
+	// entries ending with * match the ClusterRoles starting with what
+	// precedes it.
+	BlockedClusterRoles []string
+	// The only ClusterRoles rules can bind , entries ending with * match the
+	// ClusterRoles starting with what precedes it. Any ClusterRole can be
+	// bound when empty.
+	AllowedClusterRoles []string
+
+	// Limits on the size of rules , they aren't enforced when 0.
+	MaxBindingsPerRule int
+	// Subjects are counted across the bindings of a rule.
+	MaxSubjectsPerRule int
+	// The namespaces a rule creates bindings or ServiceAccounts in , once its
+	// selectors are resolved.
+	MaxNamespacesPerRule int
+	// The number of rules labeled for the same team , it isn't enforced when
+	// 0.
+	MaxRulesPerTeam int
+
+	// Whether requesters can only grant what the RBACRequesterPolicies
+	// applying to them allow.
+	EnforceRequesterPolicies bool
+
+	// The namespace given to the ServiceAccount subjects and the Roles of
+	// rules that don't select any , DefaultNamespace when empty.
+	DefaultNamespace string
+
+	// The Secret holding the webhooks notifications are posted to ,
+	// notifications are disabled when it is empty.
+	NotifierSecret types.NamespacedName
+
+	// Prefixes prepended to the names of User and Group subjects , by kind ,
+	// e.g to match the prefixes the API server gives to the identities of an
+	// OIDC provider.
+	SubjectPrefixes map[string]string
+
+	// The template the names of User subjects are rendered with , before
+	// being prefixed. Names are kept when it is nil.
+	UsernameTemplate *template.Template
+
+	// The risk level from which rules require approvals , rules aren't gated
+	// on their risk when empty.
+	RiskApprovalLevel risk.Level
+	// The approvals rules from RiskApprovalLevel require on top of their own
+	// approvalsRequired.
+	RiskApprovals int
+}
+
+// Clone returns a copy of the config , without its listeners.
+func (c *Config) Clone() *Config {
+	out := &Config{}
+	if c == nil {
+		return out
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out.Namespaces = c.Namespaces
+	out.copyFrom(c)
+	return out
+}
+
+// Set replaces the policy with the one of next , then notifies the listeners.
+// The namespaces the controller manages can't change while it runs , they are
+// kept.
+func (c *Config) Set(next *Config) {
+	next = next.Clone()
+	c.mu.Lock()
+	c.copyFrom(next)
+	listeners := slices.Clone(c.listeners)
+	c.mu.Unlock()
+	for _, f := range listeners {
+		f()
+	}
+}
+
+// OnChange registers f to be called each time the config is replaced. It must
+// not block.
+func (c *Config) OnChange(f func()) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, f)
+}
+
+// copyFrom copies the policy of src but the managed namespaces , the caller
+// holds the locks.
+func (c *Config) copyFrom(src *Config) {
+	c.ProtectedNamespaces = src.ProtectedNamespaces
+	c.ExpiringWindow = src.ExpiringWindow
+	c.DefaultTTL = src.DefaultTTL
+	c.MaxTTL = src.MaxTTL
+	c.MaxTTLOverrides = src.MaxTTLOverrides
+	c.BreakGlassMaxTTL = src.BreakGlassMaxTTL
+	c.RequireJustification = src.RequireJustification
+	c.TicketURLPattern = src.TicketURLPattern
+	c.BlockedClusterRoles = src.BlockedClusterRoles
+	c.AllowedClusterRoles = src.AllowedClusterRoles
+	c.MaxBindingsPerRule = src.MaxBindingsPerRule
+	c.MaxSubjectsPerRule = src.MaxSubjectsPerRule
+	c.MaxNamespacesPerRule = src.MaxNamespacesPerRule
+	c.MaxRulesPerTeam = src.MaxRulesPerTeam
+	c.EnforceRequesterPolicies = src.EnforceRequesterPolicies
+	c.DefaultNamespace = src.DefaultNamespace
+	c.NotifierSecret = src.NotifierSecret
+	c.SubjectPrefixes = src.SubjectPrefixes
+	c.UsernameTemplate = src.UsernameTemplate
+	c.RiskApprovalLevel = src.RiskApprovalLevel
+	c.RiskApprovals = src.RiskApprovals
+}
+
+// IsProtectedNamespace reports whether ns is one of the protected namespaces.
+func (c *Config) IsProtectedNamespace(ns string) bool {
+	return slices.Contains(c.GetProtectedNamespaces(), ns)
+}
+
+// IsNamespaceRestricted reports whether the controller only manages some
+// namespaces.
+func (c *Config) IsNamespaceRestricted() bool {
+	return c != nil && len(c.Namespaces) > 0
+}
+
+// IsManagedNamespace reports whether the controller manages bindings and
+// ServiceAccounts in ns.
+func (c *Config) IsManagedNamespace(ns string) bool {
+	return !c.IsNamespaceRestricted() || slices.Contains(c.Namespaces, ns)
+}
+
+// GetExpiringWindow returns the expiring window , 0 when no config is set.
+func (c *Config) GetExpiringWindow() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ExpiringWindow
+}
+
+// GetDefaultTTL returns the default TTL , 0 when no config is set.
+func (c *Config) GetDefaultTTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DefaultTTL
+}
+
+// GetBreakGlassMaxTTL returns the maximum lifetime of break-glass rules , 0
+// when no config is set.
+func (c *Config) GetBreakGlassMaxTTL() time.Duration {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.BreakGlassMaxTTL
+}
+
+// ValidateJustification checks the justification and the ticket URL of a rule
+// against the policy , it accepts everything when no config is set.
+func (c *Config) ValidateJustification(justification, ticketURL string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RequireJustification && strings.TrimSpace(justification) == "" {
+		return errors.New("a justification is required")
+	}
+	if c.TicketURLPattern != nil {
+		if ticketURL == "" {
+			return errors.New("a ticket URL is required")
+		}
+		if !c.TicketURLPattern.MatchString(ticketURL) {
+			return fmt.Errorf("ticket URL %s doesn't match %s", ticketURL, c.TicketURLPattern)
+		}
+	}
+	return nil
+}
+
+// CheckRuleSize returns an error when a rule of the given size exceeds the
+// limits , nil when no config is set.
+func (c *Config) CheckRuleSize(bindings, subjects, namespaces int) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	switch {
+	case c.MaxBindingsPerRule > 0 && bindings > c.MaxBindingsPerRule:
+		return fmt.Errorf("rules can't have more than %d bindings , this one has %d", c.MaxBindingsPerRule, bindings)
+	case c.MaxSubjectsPerRule > 0 && subjects > c.MaxSubjectsPerRule:
+		return fmt.Errorf("rules can't have more than %d subjects , this one has %d", c.MaxSubjectsPerRule, subjects)
+	case c.MaxNamespacesPerRule > 0 && namespaces > c.MaxNamespacesPerRule:
+		return fmt.Errorf("rules can't target more than %d namespaces , this one targets %d", c.MaxNamespacesPerRule, namespaces)
+	}
+	return nil
+}
+
+// GetMaxNamespacesPerRule returns the maximum number of namespaces a rule
+// targets , 0 when no config is set.
+func (c *Config) GetMaxNamespacesPerRule() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxNamespacesPerRule
+}
+
+// GetMaxRulesPerTeam returns the maximum number of rules per team , 0 when no
+// config is set.
+func (c *Config) GetMaxRulesPerTeam() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxRulesPerTeam
+}
+
+// IsBlockedClusterRole reports whether the ClusterRole is blocked.
+func (c *Config) IsBlockedClusterRole(name string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return matchesRole(c.BlockedClusterRoles, name)
+}
+
+// IsAllowedClusterRole reports whether rules can bind the ClusterRole.
+func (c *Config) IsAllowedClusterRole(name string) bool {
+	allowed := c.GetAllowedClusterRoles()
+	return len(allowed) == 0 || matchesRole(allowed, name)
+}
+
+// GetAllowedClusterRoles returns the only ClusterRoles rules can bind , none
+// when any ClusterRole can be bound.
+func (c *Config) GetAllowedClusterRoles() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.AllowedClusterRoles
+}
+
+// matchesRole reports whether the role is one of the patterns , a trailing *
+// matching any suffix.
+func matchesRole(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if prefix, found := strings.CutSuffix(p, "*"); found && strings.HasPrefix(name, prefix) || p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxTTLFor returns the longest lifetime of a rule binding the given roles ,
+// the shortest of their limits. It returns false when none of them is limited.
+func (c *Config) MaxTTLFor(roles []string) (time.Duration, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var limit time.Duration
+	limited := false
+	for _, r := range roles {
+		ttl, ok := c.MaxTTLOverrides[r]
+		if !ok {
+			ttl = c.MaxTTL
+		}
+		if ttl > 0 && (!limited || ttl < limit) {
+			limit, limited = ttl, true
+		}
+	}
+	return limit, limited
+}
+
+// GetProtectedNamespaces returns the protected namespaces , none when no
+// config is set.
+func (c *Config) GetProtectedNamespaces() []string {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ProtectedNamespaces
+}
+
+// RequiresJustification reports whether rules must have a justification.
+func (c *Config) RequiresJustification() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RequireJustification
+}
+
+// GetTicketURLPattern returns the pattern ticket URLs must match , nil when
+// rules don't need one.
+func (c *Config) GetTicketURLPattern() *regexp.Regexp {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.TicketURLPattern
+}
+
+// EnforcesRequesterPolicies reports whether requesters can only grant what
+// their RBACRequesterPolicies allow.
+func (c *Config) EnforcesRequesterPolicies() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.EnforceRequesterPolicies
+}
+
+// GetDefaultNamespace returns the namespace given to the ServiceAccount
+// subjects and the Roles of rules that don't select any.
+func (c *Config) GetDefaultNamespace() string {
+	if c == nil {
+		return DefaultNamespace
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.DefaultNamespace == "" {
+		return DefaultNamespace
+	}
+	return c.DefaultNamespace
+}
+
+// GetNotifierSecret returns the Secret holding the notification webhooks ,
+// an empty name when notifications are disabled.
+func (c *Config) GetNotifierSecret() types.NamespacedName {
+	if c == nil {
+		return types.NamespacedName{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.NotifierSecret
+}
+
+// GetSubjectPrefixes returns the prefixes of the names of User and Group
+// subjects , by kind.
+func (c *Config) GetSubjectPrefixes() map[string]string {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SubjectPrefixes
+}
+
+// GetUsernameTemplate returns the template the names of User subjects are
+// rendered with , nil when they are kept.
+func (c *Config) GetUsernameTemplate() *template.Template {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.UsernameTemplate
+}
+
+// usernameFuncs are the functions username templates can use , e.g to keep
+// the names that are already qualified.
+var usernameFuncs = template.FuncMap{
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"lower":     strings.ToLower,
+}
+
+// ParseUsernameTemplate parses a username template , e.g
+// {{ .name }}@corp.example.com. The name of the subject is given as .name ,
+// the template is rejected when it can't render a name.
+func ParseUsernameTemplate(text string) (*template.Template, error) {
+	t, err := template.New("username").Funcs(usernameFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid username template: %w", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, map[string]string{"name": "alice"}); err != nil {
+		return nil, fmt.Errorf("invalid username template: %w", err)
+	}
+	if strings.TrimSpace(b.String()) == "" {
+		return nil, errors.New("invalid username template: it renders an empty name")
+	}
+	return t, nil
+}
+
+// CheckSubjectPrefixes returns an error when prefixes are given to other
+// kinds than User and Group.
+func CheckSubjectPrefixes(prefixes map[string]string) error {
+	for kind := range prefixes {
+		if kind != "User" && kind != "Group" {
+			return fmt.Errorf("subject prefixes can only be given to Users and Groups , not %s", kind)
+		}
+	}
+	return nil
+}
+
+// CompileTicketURLPattern compiles a ticket URL pattern , the whole URL has
+// to match it.
+func CompileTicketURLPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// RiskApprovalsFor returns the approvals rules of the risk level require on
+// top of their own , 0 when they aren't gated on their risk.
+func (c *Config) RiskApprovalsFor(level risk.Level) int32 {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.RiskApprovalLevel == "" || level == "" || !level.AtLeast(c.RiskApprovalLevel) {
+		return 0
+	}
+	return int32(c.RiskApprovals)
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+)
+
+var _ = Describe("Config", func() {
+	Context("MaxTTLFor", func() {
+		c := &Config{
+			MaxTTL: 24 * time.Hour,
+			MaxTTLOverrides: map[string]time.Duration{
+				"cluster-admin": time.Hour,
+				"view":          0,
+			},
+		}
+
+		It("applies the maximum TTL", func() {
+			ttl, limited := c.MaxTTLFor([]string{"edit"})
+			Expect(limited).To(BeTrue())
+			Expect(ttl).To(Equal(24 * time.Hour))
+		})
+
+		It("applies the shortest limit of the roles", func() {
+			ttl, limited := c.MaxTTLFor([]string{"edit", "cluster-admin"})
+			Expect(limited).To(BeTrue())
+			Expect(ttl).To(Equal(time.Hour))
+		})
+
+		It("lifts the limit of exempted roles", func() {
+			_, limited := c.MaxTTLFor([]string{"view"})
+			Expect(limited).To(BeFalse())
+		})
+
+		It("only limits the overridden roles without a maximum TTL", func() {
+			c := &Config{MaxTTLOverrides: map[string]time.Duration{"cluster-admin": time.Hour}}
+			_, limited := c.MaxTTLFor([]string{"edit"})
+			Expect(limited).To(BeFalse())
+			ttl, limited := c.MaxTTLFor([]string{"edit", "cluster-admin"})
+			Expect(limited).To(BeTrue())
+			Expect(ttl).To(Equal(time.Hour))
+		})
+
+		It("doesn't limit anything without a config", func() {
+			var c *Config
+			_, limited := c.MaxTTLFor([]string{"cluster-admin"})
+			Expect(limited).To(BeFalse())
+		})
+	})
+
+	Context("IsManagedNamespace", func() {
+		It("manages every namespace when unrestricted", func() {
+			c := &Config{}
+			Expect(c.IsNamespaceRestricted()).To(BeFalse())
+			Expect(c.IsManagedNamespace("team-a")).To(BeTrue())
+
+			var unset *Config
+			Expect(unset.IsManagedNamespace("team-a")).To(BeTrue())
+		})
+
+		It("only manages the given namespaces when restricted", func() {
+			c := &Config{Namespaces: []string{"team-a", "team-b"}}
+			Expect(c.IsNamespaceRestricted()).To(BeTrue())
+			Expect(c.IsManagedNamespace("team-b")).To(BeTrue())
+			Expect(c.IsManagedNamespace("team-c")).To(BeFalse())
+		})
+	})
+
+	Context("ValidateJustification", func() {
+		c := &Config{
+			RequireJustification: true,
+			TicketURLPattern:     regexp.MustCompile(`^(?:https://jira\.example\.com/browse/OPS-[0-9]+)$`),
+		}
+
+		It("accepts a justification and a matching ticket URL", func() {
+			Expect(c.ValidateJustification("debugging INC-42", "https://jira.example.com/browse/OPS-42")).To(Succeed())
+		})
+
+		It("requires a justification", func() {
+			Expect(c.ValidateJustification("  ", "https://jira.example.com/browse/OPS-42")).To(MatchError("a justification is required"))
+		})
+
+		It("requires a ticket URL matching the pattern", func() {
+			Expect(c.ValidateJustification("debugging", "")).To(MatchError("a ticket URL is required"))
+			Expect(c.ValidateJustification("debugging", "https://example.com/OPS-42")).To(MatchError(ContainSubstring("doesn't match")))
+		})
+
+		It("accepts everything without a config", func() {
+			var c *Config
+			Expect(c.ValidateJustification("", "")).To(Succeed())
+		})
+	})
+
+	Context("IsBlockedClusterRole", func() {
+		c := &Config{BlockedClusterRoles: []string{"cluster-admin", "system:*"}}
+
+		It("blocks the listed ClusterRoles and the ones matching a prefix", func() {
+			Expect(c.IsBlockedClusterRole("cluster-admin")).To(BeTrue())
+			Expect(c.IsBlockedClusterRole("system:node")).To(BeTrue())
+			Expect(c.IsBlockedClusterRole("edit")).To(BeFalse())
+			Expect(c.IsBlockedClusterRole("cluster-admin-lite")).To(BeFalse())
+		})
+
+		It("doesn't block anything without a config", func() {
+			var c *Config
+			Expect(c.IsBlockedClusterRole("cluster-admin")).To(BeFalse())
+		})
+	})
+
+	Context("IsAllowedClusterRole", func() {
+		It("only allows the listed ClusterRoles and the ones matching a prefix", func() {
+			c := &Config{AllowedClusterRoles: []string{"view", "team:*"}}
+			Expect(c.IsAllowedClusterRole("view")).To(BeTrue())
+			Expect(c.IsAllowedClusterRole("team:deployer")).To(BeTrue())
+			Expect(c.IsAllowedClusterRole("edit")).To(BeFalse())
+		})
+
+		It("allows every ClusterRole without an allowlist", func() {
+			Expect((&Config{}).IsAllowedClusterRole("edit")).To(BeTrue())
+			var c *Config
+			Expect(c.IsAllowedClusterRole("edit")).To(BeTrue())
+		})
+	})
+
+	Context("CheckRuleSize", func() {
+		c := &Config{MaxBindingsPerRule: 5, MaxSubjectsPerRule: 10, MaxNamespacesPerRule: 20}
+
+		It("accepts rules within the limits", func() {
+			Expect(c.CheckRuleSize(5, 10, 20)).To(Succeed())
+		})
+
+		It("rejects rules exceeding a limit", func() {
+			Expect(c.CheckRuleSize(6, 1, 1)).To(MatchError(ContainSubstring("more than 5 bindings")))
+			Expect(c.CheckRuleSize(1, 11, 1)).To(MatchError(ContainSubstring("more than 10 subjects")))
+			Expect(c.CheckRuleSize(1, 1, 21)).To(MatchError(ContainSubstring("more than 20 namespaces")))
+		})
+
+		It("doesn't limit anything without a config", func() {
+			var c *Config
+			Expect(c.CheckRuleSize(1000, 1000, 1000)).To(Succeed())
+			Expect(c.GetMaxNamespacesPerRule()).To(BeZero())
+		})
+
+		It("returns the namespaces limit", func() {
+			Expect(c.GetMaxNamespacesPerRule()).To(Equal(20))
+		})
+	})
+
+	Context("WithSpec", func() {
+		defaults := &Config{
+			ProtectedNamespaces:  []string{"kube-system"},
+			Namespaces:           []string{"team-a"},
+			MaxTTL:               24 * time.Hour,
+			RequireJustification: true,
+		}
+
+		It("overrides the fields set in the spec", func() {
+			c, err := defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{
+				ProtectedNamespaces: []string{"kube-system", "vault"},
+				MaxTTLOverrides:     map[string]metav1.Duration{"cluster-admin": {Duration: time.Hour}},
+				TicketURLPattern:    ptr.To(`https://jira\.example\.com/browse/.+`),
+				MaxRulesPerTeam:     ptr.To[int32](10),
+				DefaultNamespace:    "apps",
+				NotifierSecret:      &rbaccontrollerv1.SecretReference{Namespace: "ops", Name: "notifier"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.GetProtectedNamespaces()).To(ConsistOf("kube-system", "vault"))
+			ttl, _ := c.MaxTTLFor([]string{"cluster-admin"})
+			Expect(ttl).To(Equal(time.Hour))
+			Expect(c.ValidateJustification("on call", "https://jira.example.com/browse/OPS-1")).To(Succeed())
+			Expect(c.GetMaxRulesPerTeam()).To(Equal(10))
+			Expect(c.GetDefaultNamespace()).To(Equal("apps"))
+			Expect(c.GetNotifierSecret()).To(Equal(types.NamespacedName{Namespace: "ops", Name: "notifier"}))
+		})
+
+		It("keeps the fields left unset", func() {
+			c, err := defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.GetProtectedNamespaces()).To(ConsistOf("kube-system"))
+			Expect(c.Namespaces).To(ConsistOf("team-a"))
+			Expect(c.RequiresJustification()).To(BeTrue())
+			Expect(c.GetDefaultNamespace()).To(Equal(DefaultNamespace))
+		})
+
+		It("can turn settings off", func() {
+			c, err := defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{
+				RequireJustification: ptr.To(false),
+				MaxTTL:               &metav1.Duration{},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.RequiresJustification()).To(BeFalse())
+			_, limited := c.MaxTTLFor([]string{"edit"})
+			Expect(limited).To(BeFalse())
+		})
+
+		It("rejects prefixes of other subjects than Users and Groups", func() {
+			_, err := defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{
+				SubjectPrefixes: map[string]string{"ServiceAccount": "oidc:"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("only be given to Users and Groups")))
+		})
+
+		It("gates rules on their risk", func() {
+			c, err := defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{
+				RiskApprovalLevel: "Medium",
+				RiskApprovals:     ptr.To[int32](2),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.RiskApprovalsFor(risk.High)).To(BeEquivalentTo(2))
+			Expect(c.RiskApprovalsFor(risk.Medium)).To(BeEquivalentTo(2))
+			Expect(c.RiskApprovalsFor(risk.Low)).To(BeZero())
+			Expect(c.RiskApprovalsFor("")).To(BeZero())
+			Expect(defaults.RiskApprovalsFor(risk.High)).To(BeZero())
+
+			_, err = defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{RiskApprovalLevel: "Critical"})
+			Expect(err).To(MatchError(ContainSubstring("invalid risk level")))
+		})
+
+		It("rejects invalid ticket URL patterns", func() {
+			_, err := defaults.WithSpec(&rbaccontrollerv1.RBACControllerConfigSpec{TicketURLPattern: ptr.To("(")})
+			Expect(err).To(MatchError(ContainSubstring("invalid ticket URL pattern")))
+		})
+	})
+
+	Context("Set", func() {
+		It("replaces the policy but the managed namespaces and notifies the listeners", func() {
+			c := &Config{Namespaces: []string{"team-a"}, MaxRulesPerTeam: 5}
+			notified := 0
+			c.OnChange(func() { notified++ })
+
+			c.Set(&Config{Namespaces: []string{"team-b"}, MaxRulesPerTeam: 10, EnforceRequesterPolicies: true})
+			Expect(notified).To(Equal(1))
+			Expect(c.GetMaxRulesPerTeam()).To(Equal(10))
+			Expect(c.EnforcesRequesterPolicies()).To(BeTrue())
+			Expect(c.IsManagedNamespace("team-a")).To(BeTrue())
+			Expect(c.IsManagedNamespace("team-b")).To(BeFalse())
+		})
+	})
+
+	Context("ParseUsernameTemplate", func() {
+		render := func(text, name string) string {
+			t, err := ParseUsernameTemplate(text)
+			Expect(err).NotTo(HaveOccurred())
+			var b strings.Builder
+			Expect(t.Execute(&b, map[string]string{"name": name})).To(Succeed())
+			return b.String()
+		}
+
+		It("renders the name of the subject", func() {
+			Expect(render(`{{ .name }}@corp.example.com`, "alice")).To(Equal("alice@corp.example.com"))
+		})
+
+		It("can keep the names that are already qualified", func() {
+			text := `{{ if contains .name "@" }}{{ .name }}{{ else }}{{ lower .name }}@corp.example.com{{ end }}`
+			Expect(render(text, "Alice")).To(Equal("alice@corp.example.com"))
+			Expect(render(text, "bob@partner.example.com")).To(Equal("bob@partner.example.com"))
+		})
+
+		It("rejects templates that can't render a name", func() {
+			_, err := ParseUsernameTemplate(`{{ .name`)
+			Expect(err).To(MatchError(ContainSubstring("invalid username template")))
+			_, err = ParseUsernameTemplate(`{{ .username }}`)
+			Expect(err).To(HaveOccurred())
+			_, err = ParseUsernameTemplate(` `)
+			Expect(err).To(MatchError(ContainSubstring("empty name")))
+		})
+	})
+})
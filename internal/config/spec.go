@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+)
+
+// WithSpec returns a copy of the config with the fields set in spec
+// overriding its own. It returns an error when spec is invalid , e.g its
+// ticket URL pattern doesn't compile.
+func (c *Config) WithSpec(spec *rbaccontrollerv1.RBACControllerConfigSpec) (*Config, error) {
+	out := c.Clone()
+	if spec.ProtectedNamespaces != nil {
+		out.ProtectedNamespaces = spec.ProtectedNamespaces
+	}
+	if spec.ExpiringWindow != nil {
+		out.ExpiringWindow = spec.ExpiringWindow.Duration
+	}
+	if spec.DefaultTTL != nil {
+		out.DefaultTTL = spec.DefaultTTL.Duration
+	}
+	if spec.MaxTTL != nil {
+		out.MaxTTL = spec.MaxTTL.Duration
+	}
+	if spec.MaxTTLOverrides != nil {
+		out.MaxTTLOverrides = make(map[string]time.Duration, len(spec.MaxTTLOverrides))
+		for role, ttl := range spec.MaxTTLOverrides {
+			out.MaxTTLOverrides[role] = ttl.Duration
+		}
+	}
+	if spec.BreakGlassMaxTTL != nil {
+		out.BreakGlassMaxTTL = spec.BreakGlassMaxTTL.Duration
+	}
+	if spec.RequireJustification != nil {
+		out.RequireJustification = *spec.RequireJustification
+	}
+	if spec.TicketURLPattern != nil {
+		out.TicketURLPattern = nil
+		if *spec.TicketURLPattern != "" {
+			pattern, err := CompileTicketURLPattern(*spec.TicketURLPattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ticket URL pattern: %w", err)
+			}
+			out.TicketURLPattern = pattern
+		}
+	}
+	if spec.BlockedClusterRoles != nil {
+		out.BlockedClusterRoles = spec.BlockedClusterRoles
+	}
+	if spec.AllowedClusterRoles != nil {
+		out.AllowedClusterRoles = spec.AllowedClusterRoles
+	}
+	if spec.MaxBindingsPerRule != nil {
+		out.MaxBindingsPerRule = int(*spec.MaxBindingsPerRule)
+	}
+	if spec.MaxSubjectsPerRule != nil {
+		out.MaxSubjectsPerRule = int(*spec.MaxSubjectsPerRule)
+	}
+	if spec.MaxNamespacesPerRule != nil {
+		out.MaxNamespacesPerRule = int(*spec.MaxNamespacesPerRule)
+	}
+	if spec.MaxRulesPerTeam != nil {
+		out.MaxRulesPerTeam = int(*spec.MaxRulesPerTeam)
+	}
+	if spec.EnforceRequesterPolicies != nil {
+		out.EnforceRequesterPolicies = *spec.EnforceRequesterPolicies
+	}
+	if spec.DefaultNamespace != "" {
+		out.DefaultNamespace = spec.DefaultNamespace
+	}
+	if s := spec.NotifierSecret; s != nil {
+		out.NotifierSecret = types.NamespacedName{Namespace: s.Namespace, Name: s.Name}
+	}
+	if spec.SubjectPrefixes != nil {
+		if err := CheckSubjectPrefixes(spec.SubjectPrefixes); err != nil {
+			return nil, err
+		}
+		out.SubjectPrefixes = spec.SubjectPrefixes
+	}
+	if spec.UsernameTemplate != nil {
+		out.UsernameTemplate = nil
+		if *spec.UsernameTemplate != "" {
+			t, err := ParseUsernameTemplate(*spec.UsernameTemplate)
+			if err != nil {
+				return nil, err
+			}
+			out.UsernameTemplate = t
+		}
+	}
+	if spec.RiskApprovalLevel != "" {
+		level, err := risk.ParseLevel(spec.RiskApprovalLevel)
+		if err != nil {
+			return nil, err
+		}
+		out.RiskApprovalLevel = level
+	}
+	if spec.RiskApprovals != nil {
+		out.RiskApprovals = int(*spec.RiskApprovals)
+	}
+	return out, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=rbac-controller.ggh41th.io,resources=rbaccontrollerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac-controller.ggh41th.io,resources=rbaccontrollerconfigs/status,verbs=get;update;patch
+
+// WatcherName is the name of the controller applying the RBACControllerConfig.
+const WatcherName = "controllerconfig"
+
+// Watcher applies the RBACControllerConfig to a Config , over the policy the
+// Config had when the watcher was set up (e.g from flags). The policy is
+// restored once the RBACControllerConfig is deleted , and kept when it is
+// invalid.
+type Watcher struct {
+	client.Client
+	Log    logr.Logger
+	Config *Config
+
+	defaults *Config
+}
+
+// SetupWithManager sets up the watcher with the Manager. It runs on every
+// replica , the webhook reading the config too.
+func (w *Watcher) SetupWithManager(mgr ctrl.Manager) error {
+	w.defaults = w.Config.Clone()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rbaccontrollerv1.RBACControllerConfig{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetName() == rbaccontrollerv1.ControllerConfigName
+			}),
+			predicate.GenerationChangedPredicate{})).
+		Named(WatcherName).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(w)
+}
+
+func (w *Watcher) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cfg := &rbaccontrollerv1.RBACControllerConfig{}
+	if err := w.Get(ctx, req.NamespacedName, cfg); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+		w.Log.Info("The controller config was deleted , restoring the defaults")
+		w.Config.Set(w.defaults)
+		return ctrl.Result{}, nil
+	}
+
+	condition := metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionApplied,
+		Status:             metav1.ConditionTrue,
+		Reason:             rbaccontrollerv1.ReasonApplied,
+		Message:            "The controller runs with the config",
+		ObservedGeneration: cfg.Generation,
+	}
+	next, err := w.defaults.WithSpec(&cfg.Spec)
+	if err != nil {
+		// the last valid config is kept.
+		w.Log.Error(err, "Invalid controller config , keeping the current one")
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = rbaccontrollerv1.ReasonInvalidConfig
+		condition.Message = err.Error()
+	} else {
+		w.Log.Info("Applied the controller config", "generation", cfg.Generation)
+		w.Config.Set(next)
+	}
+
+	// every replica applies the config , the first one reports it.
+	changed := meta.SetStatusCondition(&cfg.Status.Conditions, condition)
+	if !changed && cfg.Status.ObservedGeneration == cfg.Generation {
+		return ctrl.Result{}, nil
+	}
+	cfg.Status.ObservedGeneration = cfg.Generation
+	if err := w.Status().Update(ctx, cfg); err != nil && !apierrors.IsConflict(err) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
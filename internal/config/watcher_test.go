@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		ctx = context.Background()
+		c   client.Client
+		w   *Watcher
+		key = types.NamespacedName{Name: rbaccontrollerv1.ControllerConfigName}
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&rbaccontrollerv1.RBACControllerConfig{}).
+			Build()
+		cfg := &Config{MaxTTL: 24 * time.Hour}
+		w = &Watcher{Client: c, Log: logr.Discard(), Config: cfg, defaults: cfg.Clone()}
+	})
+
+	reconcile := func() {
+		_, err := w.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	applied := func() *metav1.Condition {
+		cfg := &rbaccontrollerv1.RBACControllerConfig{}
+		Expect(c.Get(ctx, key, cfg)).To(Succeed())
+		return meta.FindStatusCondition(cfg.Status.Conditions, rbaccontrollerv1.ConditionApplied)
+	}
+
+	It("applies the config and restores the defaults once it is deleted", func() {
+		cfg := &rbaccontrollerv1.RBACControllerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: rbaccontrollerv1.ControllerConfigName},
+			Spec:       rbaccontrollerv1.RBACControllerConfigSpec{MaxTTL: &metav1.Duration{Duration: time.Hour}},
+		}
+		Expect(c.Create(ctx, cfg)).To(Succeed())
+		reconcile()
+		ttl, _ := w.Config.MaxTTLFor([]string{"edit"})
+		Expect(ttl).To(Equal(time.Hour))
+		Expect(applied().Status).To(Equal(metav1.ConditionTrue))
+
+		Expect(c.Delete(ctx, cfg)).To(Succeed())
+		reconcile()
+		ttl, _ = w.Config.MaxTTLFor([]string{"edit"})
+		Expect(ttl).To(Equal(24 * time.Hour))
+	})
+
+	It("keeps the current config when the new one is invalid", func() {
+		cfg := &rbaccontrollerv1.RBACControllerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: rbaccontrollerv1.ControllerConfigName},
+			Spec: rbaccontrollerv1.RBACControllerConfigSpec{
+				MaxTTL:           &metav1.Duration{Duration: time.Hour},
+				TicketURLPattern: ptr.To("("),
+			},
+		}
+		Expect(c.Create(ctx, cfg)).To(Succeed())
+		reconcile()
+		ttl, _ := w.Config.MaxTTLFor([]string{"edit"})
+		Expect(ttl).To(Equal(24 * time.Hour))
+		condition := applied()
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(rbaccontrollerv1.ReasonInvalidConfig))
+	})
+})
@@ -0,0 +1,22 @@
+package constants
+
+const (
+	TokenExpirationAnnotation   = "rbac-controller.io/token-expiration"
+	OwnerContactAnnotation      = "rbac-controller.io/owner-contact"
+	DocsURLAnnotation           = "rbac-controller.io/docs-url"
+	CreatedByAnnotation         = "rbac-controller.io/created-by"
+	BreakGlassReasonAnnotation  = "rbac-controller.io/break-glass-reason"
+	JustificationAnnotation     = "rbac-controller.io/justification"
+	TicketURLAnnotation         = "rbac-controller.io/ticket-url"
+	ExpiresAtAnnotation         = "rbac-controller.io/expires-at"
+	AllowBlockedRolesAnnotation = "rbac-controller.io/allow-blocked-roles"
+	// ApproveAnnotation is set by users approving a rule , the webhook
+	// records them in ApprovedByAnnotation instead.
+	ApproveAnnotation    = "rbac-controller.io/approve"
+	ApprovedByAnnotation = "rbac-controller.io/approved-by"
+	// The risk of a rule as assessed by the webhook , any value set by users
+	// is overwritten.
+	RiskScoreAnnotation   = "rbac-controller.io/risk-score"
+	RiskLevelAnnotation   = "rbac-controller.io/risk-level"
+	RiskFactorsAnnotation = "rbac-controller.io/risk-factors"
+)
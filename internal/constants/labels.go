@@ -2,4 +2,12 @@ package constants
 
 const (
 	RBACRuleLabel = "rbac-controller.io/RBACRule"
+	ClusterLabel  = "rbac-controller.io/cluster"
+	// TeamLabel is set on rules to the team owning them.
+	TeamLabel = "rbac-controller.io/team"
+	// ShardLabel is set on rules that have to be reconciled by the same
+	// replica , to the same value. Rules are sharded by name otherwise.
+	ShardLabel = "rbac-controller.io/shard"
+	// ShardMemberLabel is set on the Leases of the replicas sharing the rules.
+	ShardMemberLabel = "rbac-controller.io/shard-member"
 )
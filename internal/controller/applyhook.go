@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/applyhook"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// applyRequest returns the request sent to the apply hook for the given
+// bindings of the binding.
+func applyRequest(stage applyhook.Stage, RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding, p *parser.Parser, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) applyhook.Request {
+	req := applyhook.Request{
+		Stage:               stage,
+		Rule:                RBACRule.Name,
+		Binding:             b.Name,
+		Justification:       RBACRule.Spec.Justification,
+		TicketURL:           RBACRule.Spec.TicketURL,
+		ServiceAccounts:     createdServiceAccounts(b, p),
+		RoleBindings:        rbs,
+		ClusterRoleBindings: crbs,
+	}
+	if creator, found, _ := impersonation.Creator(RBACRule); found {
+		req.Creator = creator.Username
+	}
+	return req
+}
+
+// preApply lets the apply hook mutate the bindings rendered for the binding
+// before they are applied.
+func (r *RBACRuleReconciler) preApply(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding, p *parser.Parser) error {
+	req := applyRequest(applyhook.PreApply, RBACRule, b, p, p.RoleBindings, p.ClusterRoleBindings)
+	resp, err := r.ApplyHook.Call(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := applyhook.Mutate(&req, resp); err != nil {
+		return err
+	}
+	p.RoleBindings, p.ClusterRoleBindings = req.RoleBindings, req.ClusterRoleBindings
+	return nil
+}
+
+// postApply tells the apply hook the bindings were applied for the binding.
+func (r *RBACRuleReconciler) postApply(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding, p *parser.Parser, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) error {
+	_, err := r.ApplyHook.Call(ctx, applyRequest(applyhook.PostApply, RBACRule, b, p, rbs, crbs))
+	return err
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+// setBindingStatus records the status of a binding with its Ready condition ,
+// the message of a False condition being the binding's last error. The rule's
+// binding counts are recomputed.
+func (r *RBACRuleReconciler) setBindingStatus(RBACRule *rbaccontrollerv1.RBACRule, bs rbaccontrollerv1.BindingStatus, status metav1.ConditionStatus, reason, message string) {
+	i := slices.IndexFunc(RBACRule.Status.Bindings, func(s rbaccontrollerv1.BindingStatus) bool { return s.Name == bs.Name })
+	if i >= 0 {
+		// the condition keeps its transition time while its status doesn't
+		// change.
+		bs.Conditions = slices.Clone(RBACRule.Status.Bindings[i].Conditions)
+		// the last use is recorded from the audit events.
+		bs.LastUsed = RBACRule.Status.Bindings[i].LastUsed
+		bs.AppliedAt = RBACRule.Status.Bindings[i].AppliedAt
+	}
+	switch {
+	case reason == rbaccontrollerv1.ReasonUnused:
+		// the binding is applied anew once granted again.
+		bs.AppliedAt = nil
+	case status == metav1.ConditionTrue && bs.AppliedAt == nil:
+		now := metav1.NewTime(r.now())
+		bs.AppliedAt = &now
+	}
+	meta.SetStatusCondition(&bs.Conditions, metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: RBACRule.Generation,
+	})
+	bs.LastError = ""
+	if status == metav1.ConditionFalse {
+		bs.LastError = message
+	}
+	if i >= 0 {
+		RBACRule.Status.Bindings[i] = bs
+	} else {
+		RBACRule.Status.Bindings = append(RBACRule.Status.Bindings, bs)
+	}
+	countBindings(RBACRule)
+}
+
+// pruneBindingStatuses drops the status of the bindings removed from the
+// rule.
+func pruneBindingStatuses(RBACRule *rbaccontrollerv1.RBACRule) {
+	RBACRule.Status.Bindings = slices.DeleteFunc(RBACRule.Status.Bindings, func(s rbaccontrollerv1.BindingStatus) bool {
+		return !slices.ContainsFunc(RBACRule.Spec.Bindings, func(b rbaccontrollerv1.Binding) bool { return b.Name == s.Name })
+	})
+	countBindings(RBACRule)
+}
+
+func countBindings(RBACRule *rbaccontrollerv1.RBACRule) {
+	var rbs, crbs int32
+	for _, s := range RBACRule.Status.Bindings {
+		rbs += int32(len(s.RoleBindings))
+		crbs += int32(len(s.ClusterRoleBindings))
+	}
+	RBACRule.Status.RoleBindingCount = rbs
+	RBACRule.Status.ClusterRoleBindingCount = crbs
+}
+
+// unusedWindow returns how long the binding may stay unused before it is
+// revoked , 0 when it never is.
+func unusedWindow(RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding) time.Duration {
+	if b.RevokeIfUnusedFor != nil {
+		return b.RevokeIfUnusedFor.Duration
+	}
+	if RBACRule.Spec.RevokeIfUnusedFor != nil {
+		return RBACRule.Spec.RevokeIfUnusedFor.Duration
+	}
+	return 0
+}
+
+// lastActivity returns when the binding was last used , or applied when it
+// wasn't used since , nil when it wasn't applied yet.
+func lastActivity(bs *rbaccontrollerv1.BindingStatus) *metav1.Time {
+	if bs.LastUsed != nil && (bs.AppliedAt == nil || bs.AppliedAt.Before(bs.LastUsed)) {
+		return bs.LastUsed
+	}
+	return bs.AppliedAt
+}
+
+// revokedUnused reports whether the binding was revoked for being unused ,
+// and the rule wasn't updated since.
+func revokedUnused(RBACRule *rbaccontrollerv1.RBACRule, bs *rbaccontrollerv1.BindingStatus) bool {
+	c := meta.FindStatusCondition(bs.Conditions, rbaccontrollerv1.ConditionReady)
+	return c != nil && c.Reason == rbaccontrollerv1.ReasonUnused && c.ObservedGeneration == RBACRule.Generation
+}
+
+func findBindingStatus(statuses []rbaccontrollerv1.BindingStatus, name string) *rbaccontrollerv1.BindingStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Reasons of the events emitted on RBACRules.
+const (
+	ReasonBindingCreated     = "BindingCreated"
+	ReasonExpired            = "Expired"
+	ReasonRevoked            = "Revoked"
+	ReasonNamespaceRetained  = "NamespaceRetained"
+	ReasonProtectedNamespace = "ProtectedNamespace"
+	ReasonUnmanagedNamespace = "UnmanagedNamespace"
+	ReasonInvalidBinding     = "InvalidBinding"
+	ReasonNotAdopted         = "NotAdopted"
+	ReasonRoleNotFound       = "RoleNotFound"
+	ReasonBindingRecreated   = "BindingRecreated"
+	ReasonGrantNotEffective  = "GrantNotEffective"
+	ReasonBreakGlass         = "BreakGlass"
+	ReasonBlocked            = "Blocked"
+	ReasonQuotaExceeded      = "QuotaExceeded"
+	ReasonOutsideTeam        = "OutsideTeam"
+	ReasonNamespaceDeleted   = "NamespaceDeleted"
+	ReasonNamespaceRecreated = "NamespaceRecreated"
+	ReasonReactivated        = "Reactivated"
+	ReasonExpiring           = "Expiring"
+	ReasonApproved           = "Approved"
+	ReasonRolesBroadened     = "RolesBroadened"
+)
+
+// event records an event on the rule. The rule's owner contact and docs URL
+// are appended to the message and set as annotations on the event , so
+// whoever is affected by a grant knows whom to reach.
+func (r *RBACRuleReconciler) event(RBACRule *rbaccontrollerv1.RBACRule, eventtype, reason, messageFmt string, args ...any) {
+	msg := fmt.Sprintf(messageFmt, args...)
+	annotations := map[string]string{}
+	details := []string{}
+	if c := RBACRule.Spec.OwnerContact; c != "" {
+		annotations[constants.OwnerContactAnnotation] = c
+		details = append(details, "owner: "+c)
+	}
+	if u := RBACRule.Spec.DocsURL; u != "" {
+		annotations[constants.DocsURLAnnotation] = u
+		details = append(details, "docs: "+u)
+	}
+	if len(details) > 0 {
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(details, ", "))
+	}
+	r.Recorder.AnnotatedEventf(RBACRule, annotations, eventtype, reason, "%s", msg)
+}
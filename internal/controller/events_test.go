@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// annotatedEvent is an event recorded by annotationRecorder.
+type annotatedEvent struct {
+	annotations map[string]string
+	message     string
+}
+
+// annotationRecorder records the annotations of the events along with their
+// message , which the fake recorder drops.
+type annotationRecorder struct {
+	record.FakeRecorder
+	events []annotatedEvent
+}
+
+func (a *annotationRecorder) AnnotatedEventf(_ runtime.Object, annotations map[string]string, _, _, messageFmt string, args ...any) {
+	a.events = append(a.events, annotatedEvent{annotations: annotations, message: fmt.Sprintf(messageFmt, args...)})
+}
+
+var _ = Describe("event", func() {
+	var (
+		recorder *annotationRecorder
+		r        *RBACRuleReconciler
+	)
+
+	BeforeEach(func() {
+		recorder = &annotationRecorder{}
+		r = &RBACRuleReconciler{Recorder: recorder}
+	})
+
+	It("surfaces the owner contact and the docs URL of the rule", func() {
+		rule := newRule()
+		rule.Spec.OwnerContact = "#team-a"
+		rule.Spec.DocsURL = "https://wiki.example.com/team-a"
+		r.event(rule, corev1.EventTypeNormal, ReasonBindingCreated, "Created RoleBinding %s", "rb")
+
+		Expect(recorder.events).To(ConsistOf(annotatedEvent{
+			annotations: map[string]string{
+				constants.OwnerContactAnnotation: "#team-a",
+				constants.DocsURLAnnotation:      "https://wiki.example.com/team-a",
+			},
+			message: "Created RoleBinding rb (owner: #team-a, docs: https://wiki.example.com/team-a)",
+		}))
+	})
+
+	It("leaves the message of rules without contact alone", func() {
+		r.event(newRule(), corev1.EventTypeNormal, ReasonBindingCreated, "Created RoleBinding %s", "rb")
+
+		Expect(recorder.events).To(ConsistOf(annotatedEvent{annotations: map[string]string{}, message: "Created RoleBinding rb"}))
+	})
+})
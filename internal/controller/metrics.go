@@ -0,0 +1,184 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+)
+
+// Results of a reconciliation , as reported by the metrics.
+const (
+	resultSuccess = "success"
+	resultRequeue = "requeue"
+	resultError   = "error"
+)
+
+var (
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "rbac_controller_reconcile_duration_seconds",
+		Help:    "Duration of the reconciliations of each rule , by result.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"rule", "result"})
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rbac_controller_reconcile_total",
+		Help: "Number of reconciliations of each rule , by result and , for errors , by category.",
+	}, []string{"rule", "result", "category"})
+	expiredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "rbac_controller_expired_rules_total",
+		Help: "Number of rules that expired.",
+	})
+	ruleRisk = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rbac_controller_rule_risk_score",
+		Help: "Risk score of each rule , as assessed at admission , by risk level.",
+	}, []string{"rule", "level"})
+	// the times of the rules , exported relative to the time of the scrape.
+	lifetimes = &lifetimeCollector{rules: map[string]lifetime{}, now: time.Now}
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDuration, reconcileTotal, expiredTotal, ruleRisk, lifetimes)
+}
+
+var (
+	untilExpiryDesc = prometheus.NewDesc("rbac_controller_rule_seconds_until_expiry",
+		"Seconds until each rule with an end time expires , negative once it expired until its bindings are revoked.",
+		[]string{"rule", "break_glass"}, nil)
+	untilActivationDesc = prometheus.NewDesc("rbac_controller_rule_seconds_until_activation",
+		"Seconds until each rule with a start time in the future is activated.",
+		[]string{"rule"}, nil)
+)
+
+// lifetime holds the times of a rule.
+type lifetime struct {
+	start, end time.Time
+	breakGlass bool
+}
+
+// lifetimeCollector exports how long until each rule expires and is activated ,
+// computed when the metrics are scraped so they don't go stale between
+// reconciliations.
+type lifetimeCollector struct {
+	mu    sync.Mutex
+	rules map[string]lifetime
+	now   func() time.Time
+}
+
+func (c *lifetimeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- untilExpiryDesc
+	ch <- untilActivationDesc
+}
+
+func (c *lifetimeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for rule, l := range c.rules {
+		if !l.end.IsZero() {
+			ch <- prometheus.MustNewConstMetric(untilExpiryDesc, prometheus.GaugeValue,
+				l.end.Sub(now).Seconds(), rule, strconv.FormatBool(l.breakGlass))
+		}
+		if l.start.After(now) {
+			ch <- prometheus.MustNewConstMetric(untilActivationDesc, prometheus.GaugeValue, l.start.Sub(now).Seconds(), rule)
+		}
+	}
+}
+
+// observeLifetime records the times of the rule , rules without any aren't
+// exported.
+func observeLifetime(rule string, start, end time.Time, breakGlass bool) {
+	lifetimes.mu.Lock()
+	defer lifetimes.mu.Unlock()
+	if start.IsZero() && end.IsZero() {
+		delete(lifetimes.rules, rule)
+		return
+	}
+	lifetimes.rules[rule] = lifetime{start: start, end: end, breakGlass: breakGlass}
+}
+
+// forgetLifetime stops exporting the times of the rule , e.g once its bindings
+// were revoked.
+func forgetLifetime(rule string) {
+	lifetimes.mu.Lock()
+	defer lifetimes.mu.Unlock()
+	delete(lifetimes.rules, rule)
+}
+
+// observeRisk records the risk score of the rule , rules which weren't scored
+// aren't exported.
+func observeRisk(rule *rbaccontrollerv1.RBACRule) {
+	forgetRisk(rule.Name)
+	if score, level, found := risk.FromAnnotations(rule); found {
+		ruleRisk.WithLabelValues(rule.Name, string(level)).Set(float64(score))
+	}
+}
+
+// forgetRisk stops exporting the risk score of the rule.
+func forgetRisk(rule string) {
+	ruleRisk.DeletePartialMatch(prometheus.Labels{"rule": rule})
+}
+
+// observeReconcile records the duration and the outcome of a reconciliation
+// of the rule.
+func observeReconcile(rule string, duration time.Duration, result ctrl.Result, err error) {
+	res, category := resultSuccess, ""
+	switch {
+	case err != nil:
+		res, category = resultError, errorCategory(err)
+	case result.RequeueAfter > 0:
+		res = resultRequeue
+	}
+	reconcileDuration.WithLabelValues(rule, res).Observe(duration.Seconds())
+	reconcileTotal.WithLabelValues(rule, res, category).Inc()
+}
+
+// forgetRule drops the metrics of a deleted rule.
+func forgetRule(rule string) {
+	reconcileDuration.DeletePartialMatch(prometheus.Labels{"rule": rule})
+	reconcileTotal.DeletePartialMatch(prometheus.Labels{"rule": rule})
+	forgetRisk(rule)
+	forgetLifetime(rule)
+}
+
+func errorCategory(err error) string {
+	switch {
+	case apierrors.IsConflict(err):
+		return "conflict"
+	case apierrors.IsNotFound(err):
+		return "not_found"
+	case apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err):
+		return "forbidden"
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err):
+		return "invalid"
+	case apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "other"
+	}
+}
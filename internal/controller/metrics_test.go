@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// lifetimeGauge returns the value the lifetime collector exports for the rule.
+func lifetimeGauge(name, rule string) float64 {
+	registry := prometheus.NewPedanticRegistry()
+	Expect(registry.Register(lifetimes)).To(Succeed())
+	families, err := registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "rule" && l.GetValue() == rule {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	Fail("no " + name + " metric for rule " + rule)
+	return 0
+}
+
+var _ = Describe("lifetimeCollector", func() {
+	It("exports how long until the reconciled rules are activated and expire", func() {
+		previous := lifetimes.now
+		lifetimes.now = func() time.Time { return fakeNow }
+		DeferCleanup(func() { lifetimes.now = previous })
+		DeferCleanup(forgetRule, "timed")
+
+		rule := newRule()
+		rule.Name = "timed"
+		rule.Spec.StartTime = metav1.NewTime(fakeNow.Add(30 * time.Minute))
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(2 * time.Hour))
+		r := newFakeReconciler(rule)
+		Expect(r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "timed"}})).Error().NotTo(HaveOccurred())
+
+		Expect(lifetimeGauge("rbac_controller_rule_seconds_until_activation", "timed")).To(Equal(1800.0))
+		Expect(lifetimeGauge("rbac_controller_rule_seconds_until_expiry", "timed")).To(Equal(7200.0))
+	})
+})
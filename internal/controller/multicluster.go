@@ -0,0 +1,181 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Reasons of the events emitted on RBACRules for member clusters.
+const (
+	ReasonClusterSyncFailed = "ClusterSyncFailed"
+)
+
+// how long to wait before syncing again the clusters that failed.
+const clusterRetryPeriod = time.Minute
+
+// propagate creates the bindings generated for the rule on the selected member
+// clusters , and revokes them from the clusters that aren't selected anymore.
+// The state of each cluster is recorded in the status , it returns whether a
+// cluster couldn't be synced.
+func (r *RBACRuleReconciler) propagate(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) (bool, error) {
+	if r.Clusters == nil || (RBACRule.Spec.ClusterSelector == nil && len(RBACRule.Status.Clusters) == 0) {
+		return false, nil
+	}
+
+	var statuses []rbaccontrollerv1.ClusterStatus
+	if RBACRule.Spec.ClusterSelector != nil {
+		clusters, err := r.Clusters.Clusters(ctx, RBACRule.Spec.ClusterSelector)
+		if err != nil {
+			return false, err
+		}
+		for _, c := range clusters {
+			status := rbaccontrollerv1.ClusterStatus{Name: c.Name}
+			if err := applyBindings(ctx, c.Client, rbs, crbs); err != nil {
+				status.Error = err.Error()
+				r.event(RBACRule, corev1.EventTypeWarning, ReasonClusterSyncFailed, "Failed to sync bindings to cluster %s: %s", c.Name, err)
+			} else {
+				status.RoleBindingCount = int32(len(rbs))
+				status.ClusterRoleBindingCount = int32(len(crbs))
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	// clusters that were deselected get their bindings revoked.
+	for _, old := range RBACRule.Status.Clusters {
+		if slices.ContainsFunc(statuses, func(s rbaccontrollerv1.ClusterStatus) bool { return s.Name == old.Name }) {
+			continue
+		}
+		if err := r.revokeFromCluster(ctx, RBACRule, old.Name); err != nil {
+			return false, err
+		}
+	}
+
+	failed := false
+	now := metav1.NewTime(r.now())
+	for i := range statuses {
+		failed = failed || statuses[i].Error != ""
+		old := findClusterStatus(RBACRule.Status.Clusters, statuses[i].Name)
+		if old != nil && old.Error == statuses[i].Error && old.RoleBindingCount == statuses[i].RoleBindingCount &&
+			old.ClusterRoleBindingCount == statuses[i].ClusterRoleBindingCount {
+			statuses[i].LastTransitionTime = old.LastTransitionTime
+		} else {
+			statuses[i].LastTransitionTime = now
+		}
+	}
+	slices.SortFunc(statuses, func(a, b rbaccontrollerv1.ClusterStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	RBACRule.Status.Clusters = statuses
+	return failed, nil
+}
+
+// revokeFromCluster deletes the bindings of the rule from the member cluster ,
+// clusters removed from the registry are skipped.
+func (r *RBACRuleReconciler) revokeFromCluster(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, name string) error {
+	c, found, err := r.Clusters.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !found {
+		log.FromContext(ctx).Info("Cluster is not registered anymore , skipping revocation", "cluster", name)
+		return nil
+	}
+	ls := labels.SelectorFromSet(map[string]string{constants.RBACRuleLabel: RBACRule.Name})
+	if err := c.Client.DeleteAllOf(ctx, &rbacv1.ClusterRoleBinding{}, client.MatchingLabelsSelector{Selector: ls}); err != nil {
+		return err
+	}
+	rbs := &rbacv1.RoleBindingList{}
+	if err := c.Client.List(ctx, rbs, client.MatchingLabelsSelector{Selector: ls}); err != nil {
+		return err
+	}
+	for _, rb := range rbs.Items {
+		if err := c.Client.Delete(ctx, &rb); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyBindings creates or updates the bindings on a member cluster. Owner
+// references point to the rule on this cluster , so they are dropped.
+func applyBindings(ctx context.Context, c client.Client, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) error {
+	for _, crb := range crbs {
+		crb.OwnerReferences = nil
+		crb.ResourceVersion = ""
+		if err := applyBinding(ctx, c, &crb, &rbacv1.ClusterRoleBinding{}); err != nil {
+			return err
+		}
+	}
+	for _, rb := range rbs {
+		rb.OwnerReferences = nil
+		rb.ResourceVersion = ""
+		if err := applyBinding(ctx, c, &rb, &rbacv1.RoleBinding{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyBinding creates or updates the binding on a member cluster , the
+// existing one being read into existing. Bindings referring to another role
+// are deleted and created again , as their roleRef is immutable.
+func applyBinding(ctx context.Context, c client.Client, obj, existing client.Object) error {
+	err := c.Create(ctx, obj)
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+	if _, _, ok := roleRefChanged(obj, existing); ok {
+		if err := c.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	if upToDate(obj, existing) {
+		return nil
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func findClusterStatus(statuses []rbaccontrollerv1.ClusterStatus, name string) *rbaccontrollerv1.ClusterStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// boundNamespaces returns the namespaces the binding granted access in , as
+// recorded by its status , including the ones that were deleted since.
+func boundNamespaces(bs *rbaccontrollerv1.BindingStatus) []string {
+	namespaces := slices.Clone(bs.DeletedNamespaces)
+	for _, ref := range slices.Concat(bs.RoleBindings, bs.ServiceAccounts) {
+		if ns, _, found := strings.Cut(ref, "/"); found {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+// namespaceDeleted reports whether the namespace doesn't exist or is being
+// deleted.
+func (r *RBACRuleReconciler) namespaceDeleted(ctx context.Context, ns string) (bool, error) {
+	meta := &metav1.PartialObjectMetadata{}
+	meta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	if err := r.Get(ctx, client.ObjectKey{Name: ns}, meta); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return meta.DeletionTimestamp != nil, nil
+}
+
+// deletedNamespaces returns the namespaces the binding granted access in that
+// were deleted , sorted. Besides the ones its resources were skipped in ,
+// namespaces matched by selectors aren't rendered once deleted , they are
+// looked up among the previously bound ones. Events are emitted when a
+// namespace is deleted and when the binding is applied again in a recreated
+// one.
+func (r *RBACRuleReconciler) deletedNamespaces(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding, prev *rbaccontrollerv1.BindingStatus, p *parser.Parser, skipped map[string]bool) ([]string, error) {
+	rendered := map[string]bool{}
+	for _, rb := range p.RoleBindings {
+		rendered[rb.Namespace] = true
+	}
+	for _, sa := range p.ServiceAccounts {
+		rendered[sa.Namespace] = true
+	}
+
+	deleted := []string{}
+	for _, ns := range boundNamespaces(prev) {
+		gone := skipped[ns]
+		if !gone && !rendered[ns] {
+			var err error
+			if gone, err = r.namespaceDeleted(ctx, ns); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case gone:
+			deleted = append(deleted, ns)
+			if !slices.Contains(prev.DeletedNamespaces, ns) {
+				r.event(RBACRule, corev1.EventTypeWarning, ReasonNamespaceDeleted,
+					"Namespace %s was deleted , binding %s is applied in it again once it is recreated", ns, b.Name)
+			}
+		case rendered[ns] && slices.Contains(prev.DeletedNamespaces, ns):
+			r.event(RBACRule, corev1.EventTypeNormal, ReasonNamespaceRecreated,
+				"Namespace %s was recreated , binding %s was applied in it again", ns, b.Name)
+		}
+	}
+	return deleted, nil
+}
+
+// rulesBoundInNamespace returns a request for every rule whose bindings
+// granted access in the namespace , or list it explicitly.
+func (r *RBACRuleReconciler) rulesBoundInNamespace(ctx context.Context, ns client.Object) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		r.Log.Error(err, "Failed to list the rules bound in namespaces")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rule := range rules.Items {
+		if boundInNamespace(&rule, ns.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+		}
+	}
+	return requests
+}
+
+func boundInNamespace(RBACRule *rbaccontrollerv1.RBACRule, ns string) bool {
+	for i := range RBACRule.Status.Bindings {
+		if slices.Contains(boundNamespaces(&RBACRule.Status.Bindings[i]), ns) {
+			return true
+		}
+	}
+	for _, b := range RBACRule.Spec.Bindings {
+		for _, s := range b.Subjects {
+			if slices.Contains(s.Namespaces, ns) {
+				return true
+			}
+		}
+		for _, rb := range b.RoleBindings {
+			if slices.Contains(rb.Namespaces, ns) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+	"github.com/GGh41th/rbac-controller/internal/notifier"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// notifyPhase sends a notification when the rule moved from the old phase to
+// its current one. Failing to notify never fails the reconciliation.
+func (r *RBACRuleReconciler) notifyPhase(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, old rbaccontrollerv1.RBACRulePhase) {
+	if r.Notifier == nil {
+		return
+	}
+
+	e := notifier.Event{
+		Rule:         RBACRule.Name,
+		Grants:       grants(RBACRule),
+		OwnerContact: RBACRule.Spec.OwnerContact,
+		DocsURL:      RBACRule.Spec.DocsURL,
+	}
+	switch RBACRule.Status.Phase {
+	case rbaccontrollerv1.RBACRulePhaseActive:
+		// a rule leaving the Expiring phase (e.g its EndTime was pushed back)
+		// was active already.
+		if old == rbaccontrollerv1.RBACRulePhaseExpiring {
+			return
+		}
+		e.Type = notifier.Activated
+		if end := RBACRule.Spec.EndTime.Time; end != (time.Time{}) {
+			e.Message = "access granted until " + end.UTC().Format(time.RFC3339)
+		} else {
+			e.Message = "access granted"
+		}
+		if RBACRule.Spec.BreakGlass {
+			e.Type = notifier.BreakGlass
+			e.Message = "break-glass " + e.Message + " , reason: " + RBACRule.Spec.Reason
+			if creator, ok, _ := impersonation.Creator(RBACRule); ok {
+				e.Message += " , requested by " + creator.Username
+			}
+		}
+	case rbaccontrollerv1.RBACRulePhaseExpiring:
+		e.Type = notifier.Expiring
+		e.Message = "access will be revoked at " + RBACRule.Spec.EndTime.UTC().Format(time.RFC3339)
+	case rbaccontrollerv1.RBACRulePhaseExpired:
+		e.Type = notifier.Expired
+		e.Message = "access revoked"
+	case rbaccontrollerv1.RBACRulePhaseFailed:
+		e.Type = notifier.Failed
+		if c := meta.FindStatusCondition(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionDegraded); c != nil {
+			e.Message = c.Message
+		}
+	default:
+		return
+	}
+
+	if err := r.Notifier.Notify(ctx, e); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to send notification", "rule", RBACRule.Name, "type", e.Type)
+	}
+}
+
+// grants describes what the rule grants and to whom , one line per binding.
+func grants(RBACRule *rbaccontrollerv1.RBACRule) []string {
+	var lines []string
+	for _, b := range RBACRule.Spec.Bindings {
+		subjects := make([]string, 0, len(b.Subjects))
+		for _, s := range b.Subjects {
+			subject := string(s.Kind) + " " + strings.Join(parser.SubjectNames(&s), ", ")
+			if s.ServiceAccountSelector != nil {
+				subject = "ServiceAccounts selected by " + metav1.FormatLabelSelector(s.ServiceAccountSelector)
+			}
+			if s.Kind == rbaccontrollerv1.AllServiceAccounts {
+				subject = "all ServiceAccounts"
+			}
+			if s.Kind == rbaccontrollerv1.ServiceAccount || s.Kind == rbaccontrollerv1.AllServiceAccounts {
+				subject += " in " + namespacesOf(s.Namespaces, &s.NameSpaceSelector, s.NamespaceMatchExpression) +
+					excluding(s.ExcludeNamespaces, &s.ExcludeNamespaceSelector)
+			}
+			subjects = append(subjects, subject)
+		}
+
+		roles := make([]string, 0, len(b.ClusterRoleBindings)+len(b.RoleBindings))
+		for _, crb := range b.ClusterRoleBindings {
+			role := "ClusterRole " + crb.ClusterRole
+			if crb.Bundle != "" {
+				role = "bundle " + crb.Bundle
+			}
+			roles = append(roles, role+" cluster wide")
+		}
+		for _, rb := range b.RoleBindings {
+			var refs []string
+			if rb.Role != "" {
+				refs = append(refs, "Role "+rb.Role)
+			}
+			if rb.ClusterRole != "" {
+				refs = append(refs, "ClusterRole "+rb.ClusterRole)
+			}
+			if rb.Bundle != "" {
+				refs = append(refs, "bundle "+rb.Bundle)
+			}
+			roles = append(roles, strings.Join(refs, ", ")+" in "+namespacesOf(rb.Namespaces, &rb.NameSpaceSelector, rb.NamespaceMatchExpression)+
+				excluding(rb.ExcludeNamespaces, &rb.ExcludeNamespaceSelector))
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", b.Name, strings.Join(subjects, ", "), strings.Join(roles, ", ")))
+	}
+	return lines
+}
+
+// namespacesOf describes the namespaces a subject or role binding targets.
+func namespacesOf(namespaces []string, selector *metav1.LabelSelector, expression string) string {
+	var targets []string
+	if len(namespaces) > 0 {
+		targets = append(targets, strings.Join(namespaces, ", "))
+	}
+	if len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0 {
+		targets = append(targets, "namespaces matching "+metav1.FormatLabelSelector(selector))
+	}
+	if expression != "" {
+		targets = append(targets, "namespaces matching "+expression)
+	}
+	// only ServiceAccount selectors may target no namespace in particular.
+	if len(targets) == 0 {
+		return "all namespaces"
+	}
+	return strings.Join(targets, " and ")
+}
+
+// excluding describes the namespaces excluded from a subject or role binding.
+func excluding(namespaces []string, selector *metav1.LabelSelector) string {
+	var excluded []string
+	if len(namespaces) > 0 {
+		excluded = append(excluded, strings.Join(namespaces, ", "))
+	}
+	if len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0 {
+		excluded = append(excluded, "namespaces matching "+metav1.FormatLabelSelector(selector))
+	}
+	if len(excluded) == 0 {
+		return ""
+	}
+	return " except " + strings.Join(excluded, " and ")
+}
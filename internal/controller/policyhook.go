@@ -0,0 +1,77 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+	"github.com/GGh41th/rbac-controller/internal/policyhook"
+)
+
+// reviewBinding asks the policy hook whether the resources rendered for the
+// binding may be applied.
+func (r *RBACRuleReconciler) reviewBinding(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding, p *parser.Parser) (policyhook.Response, error) {
+	req := policyhook.Request{
+		Rule:                RBACRule.Name,
+		Binding:             b.Name,
+		Justification:       RBACRule.Spec.Justification,
+		TicketURL:           RBACRule.Spec.TicketURL,
+		RoleBindings:        p.RoleBindings,
+		ClusterRoleBindings: p.ClusterRoleBindings,
+	}
+	if creator, found, _ := impersonation.Creator(RBACRule); found {
+		req.Creator = creator.Username
+	}
+	req.ServiceAccounts = createdServiceAccounts(b, p)
+	return r.PolicyHook.Review(ctx, req)
+}
+
+// createdServiceAccounts returns the ServiceAccounts the binding creates , as
+// namespace/name.
+func createdServiceAccounts(b *rbaccontrollerv1.Binding, p *parser.Parser) []string {
+	var sas []string
+	for _, sa := range p.ServiceAccounts {
+		if shouldCreateSA(b, sa.Subject) {
+			sas = append(sas, sa.Namespace+"/"+sa.Name)
+		}
+	}
+	return sas
+}
+
+// setBlocked sets the Blocked condition of the rule from the bindings the
+// policy hook didn't allow.
+func (r *RBACRuleReconciler) setBlocked(RBACRule *rbaccontrollerv1.RBACRule, blocked []string) {
+	c := metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionBlocked,
+		Status:             metav1.ConditionFalse,
+		Reason:             rbaccontrollerv1.ReasonAllowed,
+		Message:            "The policy hook allowed every binding",
+		ObservedGeneration: RBACRule.Generation,
+	}
+	if len(blocked) > 0 {
+		c.Status, c.Reason = metav1.ConditionTrue, rbaccontrollerv1.ReasonBlocked
+		c.Message = "The policy hook blocked bindings " + strings.Join(blocked, ", ")
+	}
+	meta.SetStatusCondition(&RBACRule.Status.Conditions, c)
+}
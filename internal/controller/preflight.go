@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// permissionsRetryPeriod is how long after a binding was denied it is applied
+// again , permissions aren't watched.
+const permissionsRetryPeriod = time.Minute
+
+// bindDeniedError is returned when the identity writing a binding isn't
+// allowed to bind its role.
+type bindDeniedError struct {
+	ref       rbacv1.RoleRef
+	namespace string
+	reason    string
+}
+
+func (e *bindDeniedError) Error() string {
+	msg := fmt.Sprintf("not allowed to bind %s %s", e.ref.Kind, e.ref.Name)
+	if e.namespace != "" {
+		msg += " in namespace " + e.namespace
+	}
+	if e.reason != "" {
+		msg += ": " + e.reason
+	}
+	return msg
+}
+
+// preflightWriter reviews , through a SelfSubjectAccessReview , that the
+// controller can bind the role of the bindings it creates or updates , so a
+// denial is reported as such instead of a generic write error.
+type preflightWriter struct {
+	client.Writer
+}
+
+func (w preflightWriter) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := w.checkBind(ctx, obj); err != nil {
+		return err
+	}
+	return w.Writer.Create(ctx, obj, opts...)
+}
+
+func (w preflightWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := w.checkBind(ctx, obj); err != nil {
+		return err
+	}
+	return w.Writer.Update(ctx, obj, opts...)
+}
+
+// checkBind returns a bindDeniedError when obj is a binding whose role can't
+// be bound.
+func (w preflightWriter) checkBind(ctx context.Context, obj client.Object) error {
+	var ref rbacv1.RoleRef
+	switch b := obj.(type) {
+	case *rbacv1.RoleBinding:
+		ref = b.RoleRef
+	case *rbacv1.ClusterRoleBinding:
+		ref = b.RoleRef
+	default:
+		return nil
+	}
+	resource := "clusterroles"
+	if ref.Kind == "Role" {
+		resource = "roles"
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Verb:      "bind",
+				Group:     rbacv1.GroupName,
+				Resource:  resource,
+				Name:      ref.Name,
+				Namespace: obj.GetNamespace(),
+			},
+		},
+	}
+	if err := w.Writer.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to review the bind permission on %s %s: %w", ref.Kind, ref.Name, err)
+	}
+	if !review.Status.Allowed {
+		return &bindDeniedError{ref: ref, namespace: obj.GetNamespace(), reason: review.Status.Reason}
+	}
+	return nil
+}
+
+// insufficientPermissions returns the message describing err when it is a
+// denial of the controller's permissions. The denials of the creators
+// impersonated are reported as any other failure , the API server rejecting
+// the bindings granting more than they hold.
+func (r *RBACRuleReconciler) insufficientPermissions(err error) (string, bool) {
+	var denied *bindDeniedError
+	switch {
+	case errors.As(err, &denied):
+		return "The controller can't write the bindings , it is " + denied.Error(), true
+	case apierrors.IsForbidden(err) && r.Impersonation == nil:
+		return "The controller can't write the bindings: " + err.Error(), true
+	}
+	return "", false
+}
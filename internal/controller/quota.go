@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// checkQuota returns why the rule exceeds the limits , an empty string when
+// it doesn't. Unlike the webhook , the namespaces matched by selectors are
+// counted , they are resolved through the namespace cache of the
+// reconciliation. Bindings that can't be parsed are reported when applied.
+// Rules of a team beyond its limit , in creation order , exceed it. Expired
+// rules that are retained don't count.
+func (r *RBACRuleReconciler) checkQuota(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, catalog bundles.Catalog, namespaces parser.NamespaceCache) (string, error) {
+	subjects := 0
+	targeted := map[string]bool{}
+	for _, b := range RBACRule.Spec.Bindings {
+		subjects += parser.SubjectCount(b.Subjects)
+		if r.Config.GetMaxNamespacesPerRule() == 0 {
+			continue
+		}
+		p := &parser.Parser{Client: r.Client, Bundles: catalog, Templates: templateClusterRoles(RBACRule), Namespaces: namespaces}
+		if err := p.Parse(ctx, &b, nil, nil, RBACRule); err != nil {
+			continue
+		}
+		for _, rb := range p.RoleBindings {
+			targeted[rb.Namespace] = true
+		}
+		for _, sa := range p.ServiceAccounts {
+			targeted[sa.Namespace] = true
+		}
+	}
+	if err := r.Config.CheckRuleSize(len(RBACRule.Spec.Bindings), subjects, len(targeted)); err != nil {
+		return err.Error(), nil
+	}
+
+	limit := r.Config.GetMaxRulesPerTeam()
+	team, found := RBACRule.Labels[constants.TeamLabel]
+	if limit == 0 || !found {
+		return "", nil
+	}
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules, client.MatchingLabels{constants.TeamLabel: team}); err != nil {
+		return "", err
+	}
+	// expired rules that are retained don't grant anything anymore.
+	rules.Items = slices.DeleteFunc(rules.Items, func(rule rbaccontrollerv1.RBACRule) bool {
+		return meta.IsStatusConditionTrue(rule.Status.Conditions, rbaccontrollerv1.ConditionExpired)
+	})
+	slices.SortFunc(rules.Items, func(a, b rbaccontrollerv1.RBACRule) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	rank := slices.IndexFunc(rules.Items, func(rule rbaccontrollerv1.RBACRule) bool { return rule.Name == RBACRule.Name })
+	if rank >= limit {
+		return fmt.Sprintf("team %s can't have more than %d rules , this one is rule number %d", team, limit, rank+1), nil
+	}
+	return "", nil
+}
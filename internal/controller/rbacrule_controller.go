@@ -17,28 +17,64 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/applyhook"
+	"github.com/GGh41th/rbac-controller/internal/approvals"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/clusters"
+	"github.com/GGh41th/rbac-controller/internal/config"
 	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/exporter"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+	"github.com/GGh41th/rbac-controller/internal/notifier"
 	"github.com/GGh41th/rbac-controller/internal/parser"
+	"github.com/GGh41th/rbac-controller/internal/policyhook"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+	"github.com/GGh41th/rbac-controller/internal/roletemplates"
+	"github.com/GGh41th/rbac-controller/internal/scheduler"
+	"github.com/GGh41th/rbac-controller/internal/sharding"
+	"github.com/GGh41th/rbac-controller/internal/teams"
+	"github.com/GGh41th/rbac-controller/internal/tracing"
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -49,8 +85,54 @@ const (
 // RBACRuleReconciler reconciles a RBACRule object
 type RBACRuleReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Recorder  record.EventRecorder
+	APIReader client.Reader
+	Config    *config.Config
+	Notifier  notifier.Notifier
+	Exporter  exporter.Exporter
+	Clusters  *clusters.Registry
+	Bundles   *bundles.ConfigMapCatalog
+	// bindings are written on behalf of the rule's creator when set.
+	Impersonation *impersonation.Clients
+	// the number of accesses granted by each binding verified through a
+	// SubjectAccessReview , verification is disabled when 0.
+	GrantSamples int
+	// whether the use of bindings is recorded , unused bindings are only
+	// revoked when it is.
+	UsageRecorded bool
+	// reviews the bindings before they are applied when set.
+	PolicyHook policyhook.Hook
+	// called before the bindings are applied , possibly mutating them , and
+	// after when set.
+	ApplyHook applyhook.Hook
+	// the source of the current time , the real clock when nil.
+	Clock clock.PassiveClock
+	// the rules of a team only grant access within the team when set.
+	Teams *teams.ConfigMapCatalog
+	// the role templates bindings can reference , none when nil.
+	RoleTemplates *roletemplates.Source
+	// the rules are partitioned across the replicas when set , each replica
+	// only reconciles the rules it owns.
+	Shards *sharding.Membership
+	// rules are enqueued when they're activated or expire through the
+	// scheduler when set , instead of only being requeued.
+	Scheduler *scheduler.Scheduler
+	// how often the roles bound by active rules are revalidated against
+	// their recorded risk , and their existence checked again. Roles aren't
+	// revalidated when 0.
+	RoleRevalidationInterval time.Duration
+	// whether broadened roles are notified , besides the event and the
+	// condition.
+	NotifyRolesBroadened bool
+	// how long in-flight reconciles keep running once the manager stops ,
+	// they aren't interrupted when negative.
+	GracefulShutdownTimeout time.Duration
+
+	// the objects the controller wrote , so they aren't read in full again
+	// while they don't change.
+	written sync.Map
 }
 
 // +kubebuilder:rbac:groups=rbac-controller.ggh41th.io,resources=rbacrules,verbs=get;list;watch;create;update;patch;delete
@@ -58,281 +140,1462 @@ type RBACRuleReconciler struct {
 // +kubebuilder:rbac:groups=rbac-controller.ggh41th.io,resources=rbacrules/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=bind
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind
+// +kubebuilder:rbac:groups="",resources=serviceaccounts/token,verbs=create
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods;persistentvolumeclaims,verbs=list
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=users;groups;serviceaccounts,verbs=impersonate
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=uids,verbs=impersonate
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;bind
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete;deletecollection;bind;escalate
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update;patch;delete
 
 func (r *RBACRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// every log line of the reconciliation carries the rule and the
+	// reconcile ID.
+	logger := r.Log.WithValues("rbacrule", req.Name, "reconcileID", controller.ReconcileIDFromContext(ctx))
+	if req.Namespace != "" {
+		logger = logger.WithValues("namespace", req.Namespace)
+	}
+	ctx = log.IntoContext(ctx, logger)
+	// the context of reconciles is canceled when the manager stops , in-flight
+	// reconciles are only interrupted once the graceful shutdown timeout went
+	// by so a rule isn't left half revoked.
+	ctx, cancel := detach(ctx, r.GracefulShutdownTimeout)
+	defer cancel()
+
+	ctx, span := tracing.Tracer().Start(ctx, "Reconcile", trace.WithAttributes(attribute.String("rbacrule", req.Name)))
+	defer span.End()
+
+	result, err := r.reconcileRule(ctx, req)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+// detach returns a context which is only canceled timeout after parent is ,
+// or never when timeout is negative.
+func detach(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	if timeout < 0 {
+		return ctx, cancel
+	}
+	stop := context.AfterFunc(parent, func() {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-ctx.Done():
+		}
+	})
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+func (r *RBACRuleReconciler) reconcileRule(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	RBACRule := &rbaccontrollerv1.RBACRule{}
-	err := r.Get(ctx, req.NamespacedName, RBACRule)
+	err = r.Get(ctx, req.NamespacedName, RBACRule)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			r.Log.Info("Rule might been deleted")
+			log.FromContext(ctx).Info("Rule might been deleted")
+			forgetRule(req.Name)
+			if r.Scheduler != nil {
+				r.Scheduler.Forget(req.NamespacedName)
+			}
 			return ctrl.Result{}, nil
 		}
 		// error trying to get the rule , requeue the request
 		return ctrl.Result{}, err
 	}
+	// the rules owned by another replica are reconciled , and their metrics
+	// exported , by it.
+	if r.Shards != nil && !r.Shards.Owns(sharding.Key(RBACRule)) {
+		forgetRule(RBACRule.Name)
+		return ctrl.Result{}, nil
+	}
+	began := r.now()
+	defer func() {
+		observeReconcile(RBACRule.Name, r.now().Sub(began), result, err)
+	}()
 
+	// finalizers are patched , so they don't conflict with the writes of the
+	// webhook or of other controllers.
 	if RBACRule.GetDeletionTimestamp() == nil && !controllerutil.ContainsFinalizer(RBACRule, RBACRuleFinalizer) {
+		base := RBACRule.DeepCopy()
 		controllerutil.AddFinalizer(RBACRule, RBACRuleFinalizer)
-		if err := r.Update(ctx, RBACRule); err != nil {
-			r.Log.Error(err, "failed to add finalizer")
+		if err := r.Patch(ctx, RBACRule, client.MergeFrom(base)); err != nil {
+			log.FromContext(ctx).Error(err, "failed to add finalizer")
 			return ctrl.Result{}, err
 		}
 	}
 
 	// Handle deletion: If Rule is marked for deletion , delete all assoicated ressources
 	if RBACRule.GetDeletionTimestamp() != nil {
+		forgetLifetime(RBACRule.Name)
+		forgetRisk(RBACRule.Name)
+		if r.Scheduler != nil {
+			r.Scheduler.Forget(req.NamespacedName)
+		}
 		return ctrl.Result{}, r.reconcileDelete(ctx, RBACRule)
 	}
+	observeLifetime(RBACRule.Name, RBACRule.Spec.StartTime.Time, RBACRule.Spec.EndTime.Time, RBACRule.Spec.BreakGlass)
+	observeRisk(RBACRule)
+
+	// the status is only updated in memory while reconciling , it is written
+	// once when the reconciliation is over.
+	observed := RBACRule.Status.DeepCopy()
+	// whether every binding of the rule was applied.
+	applied := false
+	defer func() {
+		if statusErr := r.writeStatus(ctx, RBACRule, observed, applied); statusErr != nil {
+			log.FromContext(ctx).Error(statusErr, "Failed to update RBACRule status")
+			if err == nil {
+				result, err = ctrl.Result{}, statusErr
+			}
+		}
+	}()
+
+	// expired rules that are retained don't grant anything anymore.
+	if end := RBACRule.Spec.EndTime.Time; RBACRule.Spec.ExpiredRulePolicy == rbaccontrollerv1.ExpiredRulePolicyRetain &&
+		end != (time.Time{}) && !end.After(r.now()) {
+		return ctrl.Result{}, r.expire(ctx, RBACRule)
+	}
+	// retained rules whose times were edited are applied again.
+	if meta.IsStatusConditionTrue(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionExpired) {
+		r.reactivate(RBACRule)
+	}
 
 	//if the user provided a start time we stop processing and requeue
 	//when the start time comes.
 	start := RBACRule.Spec.StartTime.Time
-	if start != (time.Time{}) && start.After(time.Now()) {
-		period := time.Until(start)
-		r.Log.Info("Rule shouldn't be active yet , waiting for start time", "Wait Period", period)
-		return ctrl.Result{RequeueAfter: period}, nil
+	if start != (time.Time{}) && start.After(r.now()) {
+		period := start.Sub(r.now())
+		log.FromContext(ctx).Info("Rule shouldn't be active yet , waiting for start time", "Wait Period", period)
+		r.setPhase(RBACRule)
+		return r.requeueAt(RBACRule, start, 0), nil
+	}
+
+	// rules requiring approvals are only applied once approved , what an
+	// approved version of the rule granted stays in place meanwhile.
+	if missing := r.checkApprovals(RBACRule); missing > 0 {
+		log.FromContext(ctx).Info("Rule is awaiting approval", "missing approvals", missing)
+		r.setPhase(RBACRule)
+		end := RBACRule.Spec.EndTime.Time
+		if end == (time.Time{}) || end.After(r.now()) {
+			return r.requeueAt(RBACRule, end, 0), nil
+		}
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonExpired, "Rule expired at %s before it was approved", end.UTC().Format(time.RFC3339))
+		expiredTotal.Inc()
+		return ctrl.Result{}, client.IgnoreNotFound(r.Delete(ctx, RBACRule))
 	}
 
+	// the shortest time after which the rule should be reconciled again , e.g
+	// to renew a generated token.
+	var requeueAfter time.Duration
+	// the bindings generated for the rule , as they are exported.
+	var generatedRBs []rbacv1.RoleBinding
+	var generatedCRBs []rbacv1.ClusterRoleBinding
+	// the referenced roles that don't exist , the rule is degraded until they
+	// are created.
+	var missingRoles []string
+	// the bindings that can't be parsed , stale bindings are only pruned once
+	// every binding of the rule could be parsed so a broken binding doesn't
+	// revoke what it granted.
+	var invalid []string
+	// the bindings the policy hook didn't allow.
+	var blocked []string
+	// the ClusterRoles generated from role templates , by template.
+	var templates map[string]string
 	if RBACRule.Spec.Bindings != nil {
 		RBAClabels := map[string]string{constants.RBACRuleLabel: RBACRule.Name}
 		ownerRef := []metav1.OwnerReference{
 			*metav1.NewControllerRef(RBACRule, rbaccontrollerv1.GroupVersion.WithKind("RBACRule")),
 		}
 
+		// bindings referencing a bundle fail to parse when no catalog is
+		// configured.
+		catalog := bundles.Catalog{}
+		if r.Bundles != nil {
+			catalog, err = r.Bundles.Load(ctx)
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Failed to load the role bundles")
+				return ctrl.Result{}, err
+			}
+		}
+
+		// the rules of a team only grant access within the team.
+		scope, err := r.loadTeamScope(ctx, RBACRule)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to load the teams")
+			return ctrl.Result{}, err
+		}
+
+		// bindings selecting the same namespaces share their resolution.
+		namespaces := parser.NamespaceCache{}
+
+		// rules exceeding the limits aren't applied any further , what they
+		// granted already is kept.
+		exceeded, err := r.checkQuota(ctx, RBACRule, catalog, namespaces)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to check the quota of the rule")
+			return ctrl.Result{}, err
+		}
+		if exceeded != "" {
+			if c := meta.FindStatusCondition(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionDegraded); c == nil || c.Reason != rbaccontrollerv1.ReasonQuotaExceeded {
+				r.event(RBACRule, corev1.EventTypeWarning, ReasonQuotaExceeded, "Rule exceeds the quota: %s", exceeded)
+			}
+			r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonQuotaExceeded, "Rule exceeds the quota: "+exceeded)
+			return ctrl.Result{}, nil
+		}
+
+		// the ClusterRoles of role templates are generated before the
+		// bindings referencing them.
+		templates, err = r.applyRoleTemplates(ctx, RBACRule, RBAClabels, ownerRef)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to generate the ClusterRoles of role templates")
+			return ctrl.Result{}, err
+		}
+
+		// in impersonation mode , bindings are written on behalf of the rule's
+		// creator , so the API server prevents them from granting more than
+		// the creator holds. Otherwise the controller is checked to be allowed
+		// to bind the roles before writing the bindings.
+		var writer client.Writer = preflightWriter{r.Client}
+		if r.Impersonation != nil {
+			creator, found, err := impersonation.Creator(RBACRule)
+			if err == nil && !found {
+				err = errors.New("its creator wasn't recorded at admission")
+			}
+			if err != nil {
+				r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonCreatorUnknown, "The rule's creator can't be impersonated: "+err.Error())
+				return ctrl.Result{}, nil
+			}
+			if writer, err = r.Impersonation.For(creator); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to impersonate the rule's creator", "user", creator.Username)
+				return ctrl.Result{}, err
+			}
+		}
+
 		//we loop over the bindings , parse each individual binding and create
 		//the parsed ressources
 		for _, b := range RBACRule.Spec.Bindings {
+			ctx := log.IntoContext(ctx, log.FromContext(ctx).WithValues("binding", b.Name))
+			// the resources of the binding that were applied by a previous
+			// reconciliation , to only report the new ones.
+			var prev rbaccontrollerv1.BindingStatus
+			if s := findBindingStatus(RBACRule.Status.Bindings, b.Name); s != nil {
+				prev = *s
+			}
+			bs := rbaccontrollerv1.BindingStatus{Name: b.Name}
+			var missing []string
+			// the namespaces the binding granted access in that were deleted ,
+			// its resources are created in them again once they are recreated.
+			bound := boundNamespaces(&prev)
+			deleted := map[string]bool{}
+
+			// bindings left unused are revoked , their resources are pruned
+			// until the rule is updated.
+			if window := unusedWindow(RBACRule, &b); r.UsageRecorded && window > 0 {
+				if revokedUnused(RBACRule, &prev) {
+					c := meta.FindStatusCondition(prev.Conditions, rbaccontrollerv1.ConditionReady)
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonUnused, c.Message)
+					continue
+				}
+				if last := lastActivity(&prev); last != nil {
+					idle := r.now().Sub(last.Time)
+					if idle >= window {
+						since := last.UTC().Format(time.RFC3339)
+						r.event(RBACRule, corev1.EventTypeNormal, ReasonRevoked, "Binding %s was revoked , it wasn't used since %s", b.Name, since)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonUnused,
+							fmt.Sprintf("Revoked , unused since %s for more than %s", since, window))
+						continue
+					}
+					if left := window - idle; requeueAfter == 0 || left < requeueAfter {
+						requeueAfter = left
+					}
+				}
+			}
+			objLabels, objAnnotations := generatedMetadata(RBACRule, &b, RBAClabels)
+
 			p := &parser.Parser{
-				Client: r.Client,
+				Client:           r.Client,
+				Bundles:          catalog,
+				Templates:        templates,
+				Namespaces:       namespaces,
+				Annotations:      objAnnotations,
+				Prefixes:         r.Config.GetSubjectPrefixes(),
+				UsernameTemplate: r.Config.GetUsernameTemplate(),
+			}
+			parseErr := p.Parse(ctx, &b, objLabels, ownerRef, RBACRule)
+			if parser.KindOf(parseErr) == parser.ListFailed {
+				log.FromContext(ctx).Error(parseErr, "Failed to list the namespaces of the binding")
+				r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonNamespaceListFailed, parseErr.Error())
+				return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
 			}
-			if err := p.Parse(ctx, &b, RBAClabels, ownerRef, RBACRule.Name); err != nil {
-				r.Log.Error(err, "failed to parse RBACBinding")
+			if parseErr != nil {
+				log.FromContext(ctx).Error(parseErr, "failed to parse RBACBinding")
+				r.event(RBACRule, corev1.EventTypeWarning, ReasonInvalidBinding, "Binding %s could not be parsed: %s", b.Name, parseErr)
+				invalid = append(invalid, b.Name)
+			}
+
+			if scope != nil && parseErr == nil {
+				removed, err := r.scopeToTeam(ctx, scope, p)
+				if err != nil {
+					log.FromContext(ctx).Error(err, "Failed to scope the binding to its team")
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+					return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+				}
+				if len(removed) > 0 {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonOutsideTeam,
+						"Binding %s: %s not created , outside of team %s", b.Name, strings.Join(removed, ", "), scope.team)
+				}
+			}
+
+			// the policy hook reviews what the binding grants before any of
+			// it is applied , the resources of blocked bindings are pruned.
+			if r.PolicyHook != nil && parseErr == nil {
+				decision, err := r.reviewBinding(ctx, RBACRule, &b, p)
+				if err != nil {
+					log.FromContext(ctx).Error(err, "Failed to review the binding through the policy hook")
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, "The policy hook failed: "+err.Error())
+					return ctrl.Result{}, err
+				}
+				if !decision.Allowed {
+					if c := meta.FindStatusCondition(prev.Conditions, rbaccontrollerv1.ConditionReady); c == nil || c.Reason != rbaccontrollerv1.ReasonBlocked {
+						r.event(RBACRule, corev1.EventTypeWarning, ReasonBlocked, "Binding %s was blocked by the policy hook: %s", b.Name, decision.Reason)
+					}
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonBlocked, "Blocked by the policy hook: "+decision.Reason)
+					blocked = append(blocked, b.Name)
+					continue
+				}
+			}
+			// the apply hook may mutate what the binding grants before it is
+			// applied.
+			if r.ApplyHook != nil && parseErr == nil {
+				if err := r.preApply(ctx, RBACRule, &b, p); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to call the pre-apply hook")
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, "The pre-apply hook failed: "+err.Error())
+					return ctrl.Result{}, err
+				}
 			}
 
 			//if we have SA subjects , we need to handle them.
-			for _, s := range p.Subjects {
-				if s.Kind == string(rbaccontrollerv1.ServiceAccount) {
+			for _, s := range p.ServiceAccounts {
+				// the ServiceAccounts selected by label or pattern , or enumerated ,
+				// were listed , they exist and are never created.
+				if s.Listed {
+					bs.ServiceAccounts = append(bs.ServiceAccounts, s.Namespace+"/"+s.Name)
+					continue
+				}
+				if r.Config.IsProtectedNamespace(s.Namespace) {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonProtectedNamespace,
+						"ServiceAccount %s was not created , namespace %s is protected", s.Name, s.Namespace)
+					continue
+				}
+				if !r.Config.IsManagedNamespace(s.Namespace) {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonUnmanagedNamespace,
+						"ServiceAccount %s was not created , namespace %s isn't managed by the controller", s.Name, s.Namespace)
+					continue
+				}
 
-					// if createSA is set to false , fail and don't requeue until the resource
-					// is updated.
-					if err := r.checkNamespace(ctx, s.Namespace, ownerRef); err != nil {
-						r.Log.Error(err, "Failed to create namespace", "namespace", s.Namespace)
+				if slices.Contains(bound, s.Namespace) {
+					gone, err := r.namespaceDeleted(ctx, s.Namespace)
+					if err != nil {
+						log.FromContext(ctx).Error(err, "Failed to get namespace", "namespace", s.Namespace)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
 						return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
 					}
-					err = r.createSA(ctx, s.Name, s.Namespace, RBAClabels, ownerRef)
+					if gone {
+						deleted[s.Namespace] = true
+						continue
+					}
+				}
+
+				if !shouldCreateSA(&b, s.Subject) {
+					// the SA has to exist already , fail and don't requeue until the
+					// resource is updated.
+					found, err := r.serviceAccountExists(ctx, s)
 					if err != nil {
-						r.Log.Error(err, "Failed to create SA", "name", s.Name, "namespace", s.Namespace)
+						log.FromContext(ctx).Error(err, "Failed to get SA", "name", s.Name, "namespace", s.Namespace)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
 						return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
 					}
+					if !found {
+						msg := fmt.Sprintf("ServiceAccount %s/%s doesn't exist and createSA is disabled", s.Namespace, s.Name)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonServiceAccountNotFound, msg)
+						r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonServiceAccountNotFound, msg)
+						return ctrl.Result{}, nil
+					}
+				} else {
+					found, err := r.checkNamespace(ctx, s.Namespace, &RBACRule.Spec, RBAClabels)
+					if err != nil {
+						log.FromContext(ctx).Error(err, "Failed to create namespace", "namespace", s.Namespace)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+						return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+					}
+					if !found {
+						if RBACRule.Spec.NamespacePolicy == rbaccontrollerv1.NamespacePolicySkip {
+							log.FromContext(ctx).Info("Skipping SA , its namespace doesn't exist", "name", s.Name, "namespace", s.Namespace)
+							continue
+						}
+						// the namespace is required , fail and don't requeue until the
+						// resource is updated.
+						msg := fmt.Sprintf("Namespace %s of ServiceAccount %s doesn't exist", s.Namespace, s.Name)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonNamespaceNotFound, msg)
+						r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonNamespaceNotFound, msg)
+						return ctrl.Result{}, nil
+					}
+					if err := r.createSA(ctx, RBACRule, s, objLabels, objAnnotations, ownerRef); err != nil {
+						if !errors.Is(err, errNotAdopted) {
+							log.FromContext(ctx).Error(err, "Failed to create SA", "name", s.Name, "namespace", s.Namespace)
+							r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+							return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+						}
+						// the existing SA is bound as it is.
+						r.event(RBACRule, corev1.EventTypeNormal, ReasonNotAdopted,
+							"ServiceAccount %s/%s already exists , it is bound but not managed by the rule", s.Namespace, s.Name)
+					}
 				}
+
+				if s.Subject.GenerateToken {
+					renewal, err := r.reconcileToken(ctx, RBACRule, s, RBAClabels, ownerRef)
+					if errors.Is(err, errNotAdopted) {
+						// the ServiceAccount is still bound , without a token.
+						r.event(RBACRule, corev1.EventTypeWarning, ReasonNotAdopted,
+							"Secret %s/%s already exists and isn't managed by the rule , no token was generated for ServiceAccount %s",
+							s.Namespace, TokenSecretName(s.Name), s.Name)
+					} else if err != nil {
+						log.FromContext(ctx).Error(err, "Failed to generate SA token", "name", s.Name, "namespace", s.Namespace)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+						return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+					}
+					if renewal > 0 && (requeueAfter == 0 || renewal < requeueAfter) {
+						requeueAfter = renewal
+					}
+				}
+				bs.ServiceAccounts = append(bs.ServiceAccounts, s.Namespace+"/"+s.Name)
 			}
 
+			// the bindings generated for this binding are verified once applied.
+			firstRB, firstCRB := len(generatedRBs), len(generatedCRBs)
+
 			//we create the cluster role bindings if we have any.
 			for _, crb := range p.ClusterRoleBindings {
-				if err := r.createCRB(ctx, &crb); err != nil {
-					r.Log.Error(err, "Failed to create CRB", "name", crb.Name)
-					return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+				// rules admitted before the controller was restricted to some
+				// namespaces can't grant ClusterRoles cluster wide anymore.
+				if r.Config.IsNamespaceRestricted() {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonUnmanagedNamespace,
+						"ClusterRoleBinding %s was not created , the controller only manages some namespaces", crb.Name)
+					continue
 				}
-				if slices.Index(RBACRule.Status.ClusterRoleBindings, crb.Name) == -1 {
-					RBACRule.Status.ClusterRoleBindings = append(RBACRule.Status.ClusterRoleBindings, crb.Name)
-					if err := r.Status().Update(ctx, RBACRule); err != nil {
-						r.Log.Error(err, "Failed to update RBACRule status", "CRB", crb.Name)
-						return ctrl.Result{}, err
+				if err := r.createCRB(ctx, writer, RBACRule, &crb); err != nil {
+					if errors.Is(err, errNotAdopted) {
+						r.event(RBACRule, corev1.EventTypeWarning, ReasonNotAdopted,
+							"ClusterRoleBinding %s already exists and isn't managed by the rule", crb.Name)
+						continue
+					}
+					if msg, denied := r.insufficientPermissions(err); denied {
+						log.FromContext(ctx).Info("Not allowed to create CRB", "name", crb.Name, "reason", msg)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonInsufficientControllerPermissions, msg)
+						r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonInsufficientControllerPermissions, msg)
+						return reconcile.Result{RequeueAfter: permissionsRetryPeriod}, nil
 					}
+					log.FromContext(ctx).Error(err, "Failed to create CRB", "name", crb.Name)
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+					return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+				}
+				generatedCRBs = append(generatedCRBs, crb)
+				if missing, err = r.checkRole(ctx, crb.RoleRef, "", missing); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to get ClusterRole", "name", crb.RoleRef.Name)
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+					return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+				}
+				bs.ClusterRoleBindings = append(bs.ClusterRoleBindings, crb.Name)
+				if !slices.Contains(prev.ClusterRoleBindings, crb.Name) {
+					r.event(RBACRule, corev1.EventTypeNormal, ReasonBindingCreated,
+						"ClusterRole %s granted cluster wide through %s", crb.RoleRef.Name, crb.Name)
 				}
-
 			}
 
 			//we create the role bindings if we have any.
 			for _, rb := range p.RoleBindings {
-				if err := r.createCR(ctx, &rb); err != nil {
-					r.Log.Error(err, "Failed to create RB", "name", rb.Name)
+				if r.Config.IsProtectedNamespace(rb.Namespace) {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonProtectedNamespace,
+						"RoleBinding %s was not created , namespace %s is protected", rb.Name, rb.Namespace)
+					continue
+				}
+				if !r.Config.IsManagedNamespace(rb.Namespace) {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonUnmanagedNamespace,
+						"RoleBinding %s was not created , namespace %s isn't managed by the controller", rb.Name, rb.Namespace)
+					continue
+				}
+				if slices.Contains(bound, rb.Namespace) {
+					gone, err := r.namespaceDeleted(ctx, rb.Namespace)
+					if err != nil {
+						log.FromContext(ctx).Error(err, "Failed to get namespace", "namespace", rb.Namespace)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+						return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+					}
+					if gone {
+						deleted[rb.Namespace] = true
+						continue
+					}
+				}
+				if err := r.createCR(ctx, writer, RBACRule, &rb); err != nil {
+					if errors.Is(err, errNotAdopted) {
+						r.event(RBACRule, corev1.EventTypeWarning, ReasonNotAdopted,
+							"RoleBinding %s/%s already exists and isn't managed by the rule", rb.Namespace, rb.Name)
+						continue
+					}
+					if msg, denied := r.insufficientPermissions(err); denied {
+						log.FromContext(ctx).Info("Not allowed to create RB", "name", rb.Name, "reason", msg)
+						r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonInsufficientControllerPermissions, msg)
+						r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonInsufficientControllerPermissions, msg)
+						return reconcile.Result{RequeueAfter: permissionsRetryPeriod}, nil
+					}
+					log.FromContext(ctx).Error(err, "Failed to create RB", "name", rb.Name)
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
 					return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, err
 				}
-				if slices.Index(RBACRule.Status.RoleBindings, rb.Namespace+"/"+rb.Name) == -1 {
-					RBACRule.Status.RoleBindings = append(RBACRule.Status.RoleBindings, rb.Namespace+"/"+rb.Name)
-					if err := r.Status().Update(ctx, RBACRule); err != nil {
-						r.Log.Error(err, "Failed to update RBACRule status", "CR", rb.Name)
-						return ctrl.Result{}, err
+				generatedRBs = append(generatedRBs, rb)
+				if missing, err = r.checkRole(ctx, rb.RoleRef, rb.Namespace, missing); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to get role", "kind", rb.RoleRef.Kind, "name", rb.RoleRef.Name)
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+					return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+				}
+				bs.RoleBindings = append(bs.RoleBindings, rb.Namespace+"/"+rb.Name)
+				if !slices.Contains(prev.RoleBindings, rb.Namespace+"/"+rb.Name) {
+					r.event(RBACRule, corev1.EventTypeNormal, ReasonBindingCreated,
+						"%s %s granted in namespace %s through %s", rb.RoleRef.Kind, rb.RoleRef.Name, rb.Namespace, rb.Name)
+				}
+			}
+
+			if r.ApplyHook != nil && parseErr == nil {
+				if err := r.postApply(ctx, RBACRule, &b, p, generatedRBs[firstRB:], generatedCRBs[firstCRB:]); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to call the post-apply hook")
+					r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, "The post-apply hook failed: "+err.Error())
+					return ctrl.Result{}, err
+				}
+			}
+
+			status, reason, msg := metav1.ConditionTrue, rbaccontrollerv1.ReasonApplied, "All the resources of the binding were applied"
+			deletedNs, err := r.deletedNamespaces(ctx, RBACRule, &b, &prev, p, deleted)
+			if err != nil {
+				log.FromContext(ctx).Error(err, "Failed to get the deleted namespaces of the binding")
+				r.setBindingStatus(RBACRule, bs, metav1.ConditionFalse, rbaccontrollerv1.ReasonApplyFailed, err.Error())
+				return reconcile.Result{RequeueAfter: 500 * time.Millisecond}, nil
+			}
+			bs.DeletedNamespaces = deletedNs
+			if len(bs.DeletedNamespaces) > 0 {
+				status, reason, msg = metav1.ConditionFalse, rbaccontrollerv1.ReasonNamespaceDeleted,
+					"Waiting for deleted namespaces to be recreated: "+strings.Join(bs.DeletedNamespaces, ", ")
+			}
+			if len(missing) > 0 {
+				// the bindings are kept , they grant access again once the
+				// roles are recreated.
+				status, reason, msg = metav1.ConditionFalse, rbaccontrollerv1.ReasonRoleNotFound, "Referenced roles don't exist: "+strings.Join(missing, ", ")
+				if c := meta.FindStatusCondition(prev.Conditions, rbaccontrollerv1.ConditionReady); c == nil || c.Reason != rbaccontrollerv1.ReasonRoleNotFound {
+					r.event(RBACRule, corev1.EventTypeWarning, ReasonRoleNotFound, "Binding %s references roles that don't exist: %s", b.Name, strings.Join(missing, ", "))
+				}
+				for _, m := range missing {
+					if !slices.Contains(missingRoles, m) {
+						missingRoles = append(missingRoles, m)
+					}
+				}
+			}
+			if r.GrantSamples > 0 && status == metav1.ConditionTrue {
+				bs.Verifications = prev.Verifications
+				results, err := r.verifyGrants(ctx, generatedRBs[firstRB:], generatedCRBs[firstCRB:])
+				if err != nil {
+					log.FromContext(ctx).Error(err, "Failed to verify the grants of the binding")
+				} else {
+					bs.Verifications = results
+				}
+				denied, pending := notEffective(bs.Verifications, prev.Verifications)
+				if pending && (requeueAfter == 0 || requeueAfter > grantRetryPeriod) {
+					requeueAfter = grantRetryPeriod
+				}
+				if len(denied) > 0 {
+					status, reason, msg = metav1.ConditionFalse, rbaccontrollerv1.ReasonGrantNotEffective, "Granted accesses are denied: "+strings.Join(denied, ", ")
+					if c := meta.FindStatusCondition(prev.Conditions, rbaccontrollerv1.ConditionReady); c == nil || c.Reason != rbaccontrollerv1.ReasonGrantNotEffective {
+						r.event(RBACRule, corev1.EventTypeWarning, ReasonGrantNotEffective, "Binding %s grants accesses that are denied: %s", b.Name, strings.Join(denied, ", "))
 					}
 				}
 			}
+			if status == metav1.ConditionTrue && len(p.Unmatched) > 0 {
+				fields := make([]string, 0, len(p.Unmatched))
+				for _, u := range p.Unmatched {
+					fields = append(fields, u.Field)
+				}
+				status, reason, msg = metav1.ConditionFalse, rbaccontrollerv1.ReasonNoNamespaceMatched, "No namespace matched by "+strings.Join(fields, ", ")
+			}
+			if parseErr != nil {
+				status, reason, msg = metav1.ConditionFalse, parseErrorReason(parseErr), parseErr.Error()
+			}
+			r.setBindingStatus(RBACRule, bs, status, reason, msg)
 		}
 	}
 
+	// bindings removed from the rule don't report a status anymore.
+	pruneBindingStatuses(RBACRule)
+	if len(invalid) == 0 {
+		if err := r.pruneBindings(ctx, RBACRule, generatedRBs, generatedCRBs); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to prune stale bindings")
+			return ctrl.Result{}, err
+		}
+		if err := r.pruneRoleTemplates(ctx, RBACRule, templates); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to prune the ClusterRoles of role templates")
+			return ctrl.Result{}, err
+		}
+	}
+	if r.PolicyHook != nil {
+		r.setBlocked(RBACRule, blocked)
+	}
+	if len(invalid) > 0 {
+		r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonInvalidBinding, "Bindings that can't be parsed: "+strings.Join(invalid, ", "))
+	} else if len(blocked) > 0 {
+		r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonBlocked, "Bindings blocked by the policy hook: "+strings.Join(blocked, ", "))
+	} else if len(missingRoles) > 0 {
+		r.setCondition(RBACRule, metav1.ConditionTrue, rbaccontrollerv1.ReasonRoleNotFound, "Referenced roles don't exist: "+strings.Join(missingRoles, ", "))
+	} else {
+		r.setCondition(RBACRule, metav1.ConditionFalse, rbaccontrollerv1.ReasonReconciled, "All bindings were applied")
+		applied = true
+	}
+	if r.RoleRevalidationInterval > 0 {
+		r.revalidateRoles(ctx, RBACRule)
+		if requeueAfter == 0 || requeueAfter > r.RoleRevalidationInterval {
+			requeueAfter = r.RoleRevalidationInterval
+		}
+	}
+	r.export(ctx, RBACRule, generatedRBs, generatedCRBs)
+
+	clusterFailed, err := r.propagate(ctx, RBACRule, generatedRBs, generatedCRBs)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to propagate bindings to member clusters")
+		return ctrl.Result{}, err
+	}
+	if clusterFailed && (requeueAfter == 0 || requeueAfter > clusterRetryPeriod) {
+		requeueAfter = clusterRetryPeriod
+	}
+
 	//if the user provided an end time , we take care of it here.
 	end := RBACRule.Spec.EndTime.Time
-	if end != (time.Time{}) && end.After(time.Now()) {
-		period := time.Until(end)
-		r.Log.Info("Rule will be scheduled for deletion", "Time until deletion", period)
-		return ctrl.Result{RequeueAfter: period}, nil
-	} else if end.Before(time.Now()) {
+	r.setExpiring(RBACRule)
+	if end != (time.Time{}) && end.After(r.now()) {
+		period := end.Sub(r.now())
+		log.FromContext(ctx).Info("Rule will be scheduled for deletion", "Time until deletion", period)
+		// requeue when the rule enters its expiring window , so the phase
+		// gets updated and the revocation is announced.
+		at := end
+		if window := r.expiringWindow(RBACRule); period > window {
+			at = end.Add(-window)
+		}
+		return r.requeueAt(RBACRule, at, requeueAfter), nil
+	} else if end != (time.Time{}) && RBACRule.Spec.ExpiredRulePolicy == rbaccontrollerv1.ExpiredRulePolicyRetain {
+		return ctrl.Result{}, r.expire(ctx, RBACRule)
+	} else if end != (time.Time{}) {
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonExpired, "Rule expired at %s , revoking its bindings", end.UTC().Format(time.RFC3339))
+		expiredTotal.Inc()
+		r.setPhase(RBACRule)
 		err := r.Delete(ctx, RBACRule)
 		if err != nil {
-			r.Log.Error(err, "error deleting resource")
+			log.FromContext(ctx).Error(err, "error deleting resource")
 			return ctrl.Result{}, nil
 		}
 	}
-	return ctrl.Result{}, nil
+	return r.requeueAt(RBACRule, time.Time{}, requeueAfter), nil
+}
+
+// statusRefreshPeriod is how often the lastReconcileTime of a rule is refreshed
+// when its status doesn't change otherwise. Every write of the status triggers a
+// reconciliation , so the time isn't written on each of them.
+const statusRefreshPeriod = 5 * time.Minute
+
+// now returns the current time of the reconciler's clock.
+func (r *RBACRuleReconciler) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+	return r.Clock.Now()
+}
+
+// setCondition sets the Degraded condition of the rule and recomputes its
+// phase.
+func (r *RBACRuleReconciler) setCondition(RBACRule *rbaccontrollerv1.RBACRule, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&RBACRule.Status.Conditions, metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionDegraded,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: RBACRule.Generation,
+	})
+	r.setPhase(RBACRule)
+}
+
+// parseErrorReason returns the reason of the Ready condition of a binding that
+// couldn't be parsed.
+func parseErrorReason(err error) string {
+	switch parser.KindOf(err) {
+	case parser.InvalidSelector:
+		return rbaccontrollerv1.ReasonInvalidSelector
+	default:
+		return rbaccontrollerv1.ReasonInvalidBinding
+	}
+}
+
+// export exports the bindings generated for the rule. Failing to export never
+// fails the reconciliation.
+func (r *RBACRuleReconciler) export(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) {
+	if r.Exporter == nil {
+		return
+	}
+	if err := r.Exporter.Export(ctx, RBACRule.Name, rbs, crbs); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to export bindings", "rule", RBACRule.Name)
+	}
+}
+
+// setPhase recomputes the phase of the rule.
+func (r *RBACRuleReconciler) setPhase(RBACRule *rbaccontrollerv1.RBACRule) {
+	RBACRule.Status.Phase = r.rulePhase(RBACRule, r.now())
+}
+
+// writeStatus writes the status of the rule when it changed from the observed
+// one , or when its lastReconcileTime is older than statusRefreshPeriod. A
+// notification is sent when its phase changed. The status is merge patched from
+// the observed one , so it doesn't conflict with concurrent writes to the rule.
+func (r *RBACRuleReconciler) writeStatus(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, observed *rbaccontrollerv1.RBACRuleStatus, applied bool) error {
+	now := metav1.NewTime(r.now())
+	last := observed.LastReconcileTime
+	if equality.Semantic.DeepEqual(observed, &RBACRule.Status) && last != nil && now.Sub(last.Time) < statusRefreshPeriod {
+		return nil
+	}
+	RBACRule.Status.LastReconcileTime = &now
+	if applied {
+		RBACRule.Status.LastAppliedTime = &now
+	}
+	base := RBACRule.DeepCopy()
+	base.Status = *observed
+	if err := r.Status().Patch(ctx, RBACRule, client.MergeFrom(base)); err != nil {
+		// the rule is gone once its finalizer is removed.
+		return client.IgnoreNotFound(err)
+	}
+	if RBACRule.Status.Phase != observed.Phase {
+		if RBACRule.Spec.BreakGlass && RBACRule.Status.Phase == rbaccontrollerv1.RBACRulePhaseActive && observed.Phase != rbaccontrollerv1.RBACRulePhaseExpiring {
+			r.event(RBACRule, corev1.EventTypeWarning, ReasonBreakGlass, "Break-glass access granted until %s: %s",
+				RBACRule.Spec.EndTime.UTC().Format(time.RFC3339), RBACRule.Spec.Reason)
+			log.FromContext(ctx).Info("Break-glass access granted", "rule", RBACRule.Name, "reason", RBACRule.Spec.Reason, "endTime", RBACRule.Spec.EndTime)
+		}
+		r.notifyPhase(ctx, RBACRule, observed.Phase)
+	}
+	return nil
+}
+
+// rulePhase computes the phase of the rule at the given time from its spec ,
+// deletion timestamp and Degraded condition.
+func (r *RBACRuleReconciler) rulePhase(RBACRule *rbaccontrollerv1.RBACRule, now time.Time) rbaccontrollerv1.RBACRulePhase {
+	start, end := RBACRule.Spec.StartTime.Time, RBACRule.Spec.EndTime.Time
+	switch {
+	case RBACRule.GetDeletionTimestamp() != nil:
+		return rbaccontrollerv1.RBACRulePhaseDeleting
+	case start != (time.Time{}) && start.After(now):
+		return rbaccontrollerv1.RBACRulePhasePending
+	case end != (time.Time{}) && !end.After(now):
+		return rbaccontrollerv1.RBACRulePhaseExpired
+	case meta.IsStatusConditionFalse(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionApproved):
+		return rbaccontrollerv1.RBACRulePhasePending
+	case meta.IsStatusConditionTrue(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionDegraded):
+		return rbaccontrollerv1.RBACRulePhaseFailed
+	case end != (time.Time{}) && end.Sub(now) <= r.expiringWindow(RBACRule):
+		return rbaccontrollerv1.RBACRulePhaseExpiring
+	default:
+		return rbaccontrollerv1.RBACRulePhaseActive
+	}
+}
+
+// checkApprovals sets the Approved condition of a rule requiring approvals ,
+// and returns how many approvals it is missing. Rules whose risk is at least
+// the configured level require more approvals than their own.
+func (r *RBACRuleReconciler) checkApprovals(RBACRule *rbaccontrollerv1.RBACRule) int {
+	_, level, _ := risk.FromAnnotations(RBACRule)
+	required := RBACRule.Spec.ApprovalsRequired + r.Config.RiskApprovalsFor(level)
+	if required == 0 {
+		meta.RemoveStatusCondition(&RBACRule.Status.Conditions, rbaccontrollerv1.ConditionApproved)
+		return 0
+	}
+	approvers, err := approvals.Approvers(RBACRule)
+	missing, _ := approvals.Missing(RBACRule, required)
+	condition := metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             rbaccontrollerv1.ReasonAwaitingApproval,
+		Message:            fmt.Sprintf("%d of %d approvals , approved by %s", len(approvers), required, strings.Join(approvers, ", ")),
+		ObservedGeneration: RBACRule.Generation,
+	}
+	switch {
+	case err != nil:
+		condition.Message = err.Error()
+	case len(approvers) == 0:
+		condition.Message = fmt.Sprintf("0 of %d approvals", required)
+	case missing == 0:
+		condition.Status, condition.Reason = metav1.ConditionTrue, rbaccontrollerv1.ReasonApproved
+		condition.Message = "Approved by " + strings.Join(approvers, ", ")
+		if !meta.IsStatusConditionTrue(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionApproved) {
+			r.event(RBACRule, corev1.EventTypeNormal, ReasonApproved, "Rule was approved by %s", strings.Join(approvers, ", "))
+		}
+	}
+	meta.SetStatusCondition(&RBACRule.Status.Conditions, condition)
+	return missing
+}
+
+// expiringWindow returns how long before its EndTime the rule is expiring ,
+// its revocationGracePeriod or the controller's expiring window.
+func (r *RBACRuleReconciler) expiringWindow(RBACRule *rbaccontrollerv1.RBACRule) time.Duration {
+	if grace := RBACRule.Spec.RevocationGracePeriod; grace != nil {
+		return grace.Duration
+	}
+	return r.Config.GetExpiringWindow()
 }
 
-func (r *RBACRuleReconciler) checkNamespace(ctx context.Context, name string, ownerRef []metav1.OwnerReference) error {
+// setExpiring sets the Expiring condition of a rule whose EndTime is within
+// its expiring window , warning once that its access is about to be revoked.
+// The condition turns False when the EndTime is pushed back or removed.
+func (r *RBACRuleReconciler) setExpiring(RBACRule *rbaccontrollerv1.RBACRule) {
+	end := RBACRule.Spec.EndTime
+	left := end.Sub(r.now())
+	expiring := !end.IsZero() && left > 0 && left <= r.expiringWindow(RBACRule)
+	c := meta.FindStatusCondition(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionExpiring)
+	switch {
+	case expiring && (c == nil || c.Status != metav1.ConditionTrue):
+		revocation := end.UTC().Format(time.RFC3339)
+		r.event(RBACRule, corev1.EventTypeWarning, ReasonExpiring, "Rule will be revoked at %s , in %s", revocation, left.Round(time.Second))
+		meta.SetStatusCondition(&RBACRule.Status.Conditions, metav1.Condition{
+			Type:               rbaccontrollerv1.ConditionExpiring,
+			Status:             metav1.ConditionTrue,
+			Reason:             rbaccontrollerv1.ReasonEndTimeApproaching,
+			Message:            "Access will be revoked at " + revocation,
+			ObservedGeneration: RBACRule.Generation,
+		})
+	case !expiring && (end.IsZero() || left > 0) && c != nil && c.Status == metav1.ConditionTrue:
+		message := "The end time was removed"
+		if !end.IsZero() {
+			message = "Access will be revoked at " + end.UTC().Format(time.RFC3339)
+		}
+		meta.SetStatusCondition(&RBACRule.Status.Conditions, metav1.Condition{
+			Type:               rbaccontrollerv1.ConditionExpiring,
+			Status:             metav1.ConditionFalse,
+			Reason:             rbaccontrollerv1.ReasonEndTimeExtended,
+			Message:            message,
+			ObservedGeneration: RBACRule.Generation,
+		})
+	}
+}
+
+// checkNamespace makes sure the namespace exists. Missing namespaces are only
+// created with the Create policy , it returns false when the namespace is
+// missing and wasn't created.
+func (r *RBACRuleReconciler) checkNamespace(ctx context.Context, name string, spec *rbaccontrollerv1.RBACRuleSpec, RBACLabel map[string]string) (bool, error) {
 	nsName := types.NamespacedName{Namespace: "", Name: name}
-	ns := &corev1.Namespace{}
-	// we check if the ns exist , if not we create it
-	if err := r.Get(ctx, nsName, ns); err != nil {
+	nsMeta := &metav1.PartialObjectMetadata{}
+	nsMeta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	// we check if the ns exist , if not we create it.
+	// created namespaces are labeled instead of being owned by the rule , so
+	// the garbage collector doesn't remove them along with their workloads.
+	if err := r.Get(ctx, nsName, nsMeta); err != nil {
 		if apierrors.IsNotFound(err) {
+			policy := spec.NamespacePolicy
+			if policy == rbaccontrollerv1.NamespacePolicyRequireExisting || policy == rbaccontrollerv1.NamespacePolicySkip {
+				return false, nil
+			}
+			ns := &corev1.Namespace{}
 			ns.ObjectMeta = metav1.ObjectMeta{
-				Name:            name,
-				OwnerReferences: ownerRef,
+				Name:   name,
+				Labels: RBACLabel,
+			}
+			if t := spec.NamespaceTemplate; t != nil {
+				ns.Labels = map[string]string{}
+				maps.Copy(ns.Labels, t.Labels)
+				maps.Copy(ns.Labels, RBACLabel)
+				ns.Annotations = maps.Clone(t.Annotations)
 			}
 			if err := r.Create(ctx, ns); err != nil {
-				return err
+				return false, err
 			}
-			return nil
+			return true, nil
 		}
-		return err
+		return false, err
 	}
-	return nil
+	return true, nil
+}
+
+// shouldCreateSA reports whether a missing ServiceAccount subject should be
+// created , the binding's createSA takes precedence over the subject's one.
+// The ServiceAccounts selected by label or bound by AllServiceAccounts are
+// never created.
+func shouldCreateSA(b *rbaccontrollerv1.Binding, s *rbaccontrollerv1.Subject) bool {
+	if s.ServiceAccountSelector != nil || s.Kind == rbaccontrollerv1.AllServiceAccounts {
+		return false
+	}
+	if b.CreateSA != nil {
+		return *b.CreateSA
+	}
+	return s.CreateSA
+}
+
+// serviceAccountExists reports whether the ServiceAccount exists.
+func (r *RBACRuleReconciler) serviceAccountExists(ctx context.Context, s parser.ServiceAccount) (bool, error) {
+	sa := &metav1.PartialObjectMetadata{}
+	sa.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ServiceAccount"))
+	if err := r.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, sa); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
 }
 
-func (r *RBACRuleReconciler) createSA(ctx context.Context, name string, ns string, RBACLAbel map[string]string, ownerRef []metav1.OwnerReference) error {
+// generatedMetadata returns the labels and annotations of the objects
+// generated for a binding: the rule's common ones , overridden by the
+// binding's. The rule label is applied last , it is what the cleanup relies on.
+func generatedMetadata(RBACRule *rbaccontrollerv1.RBACRule, b *rbaccontrollerv1.Binding, RBACLabel map[string]string) (map[string]string, map[string]string) {
+	objLabels := map[string]string{}
+	maps.Copy(objLabels, RBACRule.Spec.CommonLabels)
+	maps.Copy(objLabels, b.Labels)
+	maps.Copy(objLabels, RBACLabel)
+
+	// the creator , justification , ticket and break-glass reason are stamped
+	// last , so whoever inspects the objects , or the audit trail , can trace
+	// them back to the request.
+	stamped := map[string]string{}
+	if c, found := RBACRule.Annotations[constants.CreatedByAnnotation]; found {
+		stamped[constants.CreatedByAnnotation] = c
+	}
+	if j := RBACRule.Spec.Justification; j != "" {
+		stamped[constants.JustificationAnnotation] = j
+	}
+	if t := RBACRule.Spec.TicketURL; t != "" {
+		stamped[constants.TicketURLAnnotation] = t
+	}
+	if RBACRule.Spec.BreakGlass {
+		stamped[constants.BreakGlassReasonAnnotation] = RBACRule.Spec.Reason
+	}
+
+	var objAnnotations map[string]string
+	if len(RBACRule.Spec.CommonAnnotations) > 0 || len(b.Annotations) > 0 || len(stamped) > 0 {
+		objAnnotations = map[string]string{}
+		maps.Copy(objAnnotations, RBACRule.Spec.CommonAnnotations)
+		maps.Copy(objAnnotations, b.Annotations)
+		maps.Copy(objAnnotations, stamped)
+	}
+	return objLabels, objAnnotations
+}
+
+func (r *RBACRuleReconciler) createSA(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, s parser.ServiceAccount, RBACLAbel, annotations map[string]string, ownerRef []metav1.OwnerReference) error {
 	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            name,
-			Namespace:       ns,
+			Name:            s.Name,
+			Namespace:       s.Namespace,
 			Labels:          RBACLAbel,
+			Annotations:     annotations,
 			OwnerReferences: ownerRef,
 		},
 	}
-	if err := r.Create(ctx, sa); err != nil {
-		if apierrors.IsAlreadyExists(err) {
-			if err := r.Update(ctx, sa); err != nil {
-				return err
+	if t := s.Subject.ServiceAccountTemplate; t != nil {
+		// the template overrides the rule's metadata , the rule label is
+		// applied last , it is what the cleanup relies on.
+		sa.Labels = map[string]string{}
+		maps.Copy(sa.Labels, RBACLAbel)
+		maps.Copy(sa.Labels, t.Labels)
+		sa.Labels[constants.RBACRuleLabel] = RBACRule.Name
+		if len(t.Annotations) > 0 {
+			sa.Annotations = maps.Clone(annotations)
+			if sa.Annotations == nil {
+				sa.Annotations = map[string]string{}
 			}
+			maps.Copy(sa.Annotations, t.Annotations)
+		}
+		sa.AutomountServiceAccountToken = t.AutomountServiceAccountToken
+		sa.ImagePullSecrets = t.ImagePullSecrets
+	}
+	return r.createOrAdopt(ctx, r.Client, RBACRule, sa, &corev1.ServiceAccount{})
+}
+
+func (r *RBACRuleReconciler) createCRB(ctx context.Context, w client.Writer, RBACRule *rbaccontrollerv1.RBACRule, crb *rbacv1.ClusterRoleBinding) error {
+	return r.createOrAdopt(ctx, w, RBACRule, crb, &rbacv1.ClusterRoleBinding{})
+}
+
+func (r *RBACRuleReconciler) createCR(ctx context.Context, w client.Writer, RBACRule *rbaccontrollerv1.RBACRule, cr *rbacv1.RoleBinding) error {
+	return r.createOrAdopt(ctx, w, RBACRule, cr, &rbacv1.RoleBinding{})
+}
+
+// errNotAdopted is returned when an object the controller didn't create
+// already exists and the rule's adoption policy doesn't allow taking it over.
+var errNotAdopted = errors.New("object exists and can't be adopted")
+
+// createOrAdopt creates obj , or updates the existing object , read into
+// existing , when the rule controls it or its adoption policy allows taking it
+// over. Existing objects that are up to date aren't written , writes go
+// through w. The roleRef of bindings is immutable , existing bindings referring to
+// another role are deleted and created again.
+// The cache only holds the metadata of the objects , existing objects are only
+// read in full from the API server when they changed since the controller last
+// wrote or checked them.
+func (r *RBACRuleReconciler) createOrAdopt(ctx context.Context, w client.Writer, RBACRule *rbaccontrollerv1.RBACRule, obj, existing client.Object) error {
+	key := client.ObjectKeyFromObject(obj)
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return err
+	}
+	desired, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	current := &metav1.PartialObjectMetadata{}
+	current.SetGroupVersionKind(gvk)
+	err = r.Get(ctx, key, current)
+	if apierrors.IsNotFound(err) {
+		err = w.Create(ctx, obj)
+		if err == nil {
+			r.remember(gvk, obj, desired)
+		}
+		if err == nil || !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		// the cache didn't see the object yet.
+		err = r.APIReader.Get(ctx, key, existing)
+	} else if err == nil {
+		if !mayAdopt(RBACRule, current) {
+			return errNotAdopted
+		}
+		if r.unchanged(gvk, current, desired) {
 			return nil
 		}
+		err = r.APIReader.Get(ctx, key, existing)
+	}
+	if err != nil {
+		return err
+	}
+	if !mayAdopt(RBACRule, existing) {
+		return errNotAdopted
+	}
+	if ref, old, ok := roleRefChanged(obj, existing); ok {
+		// the precondition makes sure a binding recreated meanwhile isn't
+		// deleted.
+		uid := existing.GetUID()
+		if err := w.Delete(ctx, existing, client.Preconditions{UID: &uid}); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		if err := w.Create(ctx, obj); err != nil {
+			return err
+		}
+		r.remember(gvk, obj, desired)
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonBindingRecreated,
+			"Binding %s was recreated , its role changed from %s %s to %s %s",
+			client.ObjectKeyFromObject(obj), old.Kind, old.Name, ref.Kind, ref.Name)
+		return nil
+	}
+	if upToDate(obj, existing) {
+		r.remember(gvk, existing, desired)
+		return nil
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	if err := w.Update(ctx, obj); err != nil {
 		return err
 	}
+	r.remember(gvk, obj, desired)
 	return nil
 }
 
-func (r *RBACRuleReconciler) createCRB(ctx context.Context, crb *rbacv1.ClusterRoleBinding) error {
-	// TODO: I really hate how this looks , change it.
-	if err := r.Create(ctx, crb); err != nil {
-		if apierrors.IsAlreadyExists(err) {
-			if err = r.Update(ctx, crb); err != nil {
-				return err
-			}
-			return nil
+// writtenObject is the version of an object the controller wrote , or found
+// up to date , along with the object it wanted.
+type writtenObject struct {
+	resourceVersion string
+	desired         []byte
+}
+
+// remember records that obj matches the desired object at its current
+// version.
+func (r *RBACRuleReconciler) remember(gvk schema.GroupVersionKind, obj client.Object, desired []byte) {
+	r.written.Store(writtenKey(gvk, client.ObjectKeyFromObject(obj)), writtenObject{obj.GetResourceVersion(), desired})
+}
+
+// forget drops what was recorded about the object once it's deleted.
+func (r *RBACRuleReconciler) forget(obj *metav1.PartialObjectMetadata) {
+	r.written.Delete(writtenKey(obj.GroupVersionKind(), client.ObjectKeyFromObject(obj)))
+}
+
+// unchanged reports whether the object , whose metadata is given , is still
+// at the version the controller wrote or found up to date for the same
+// desired object.
+func (r *RBACRuleReconciler) unchanged(gvk schema.GroupVersionKind, current *metav1.PartialObjectMetadata, desired []byte) bool {
+	v, found := r.written.Load(writtenKey(gvk, client.ObjectKeyFromObject(current)))
+	if !found {
+		return false
+	}
+	written := v.(writtenObject)
+	return written.resourceVersion == current.ResourceVersion && bytes.Equal(written.desired, desired)
+}
+
+func writtenKey(gvk schema.GroupVersionKind, key client.ObjectKey) string {
+	return gvk.GroupKind().String() + "/" + key.String()
+}
+
+// upToDate reports whether the existing object already matches the desired
+// one , on the fields the controller sets , so no-op writes are skipped.
+func upToDate(obj, existing client.Object) bool {
+	if !equality.Semantic.DeepEqual(obj.GetLabels(), existing.GetLabels()) ||
+		!equality.Semantic.DeepEqual(obj.GetAnnotations(), existing.GetAnnotations()) ||
+		!equality.Semantic.DeepEqual(obj.GetOwnerReferences(), existing.GetOwnerReferences()) {
+		return false
+	}
+	switch o := obj.(type) {
+	case *rbacv1.RoleBinding:
+		e := existing.(*rbacv1.RoleBinding)
+		return o.RoleRef == e.RoleRef && equality.Semantic.DeepEqual(o.Subjects, e.Subjects)
+	case *rbacv1.ClusterRoleBinding:
+		e := existing.(*rbacv1.ClusterRoleBinding)
+		return o.RoleRef == e.RoleRef && equality.Semantic.DeepEqual(o.Subjects, e.Subjects)
+	case *rbacv1.ClusterRole:
+		e := existing.(*rbacv1.ClusterRole)
+		return equality.Semantic.DeepEqual(o.Rules, e.Rules)
+	case *corev1.ServiceAccount:
+		e := existing.(*corev1.ServiceAccount)
+		return equality.Semantic.DeepEqual(o.AutomountServiceAccountToken, e.AutomountServiceAccountToken) &&
+			equality.Semantic.DeepEqual(o.ImagePullSecrets, e.ImagePullSecrets)
+	default:
+		return false
+	}
+}
+
+// roleRefChanged returns the roleRefs of obj and of the existing object when
+// they are bindings referring to different roles.
+func roleRefChanged(obj, existing client.Object) (rbacv1.RoleRef, rbacv1.RoleRef, bool) {
+	switch o := obj.(type) {
+	case *rbacv1.RoleBinding:
+		old := existing.(*rbacv1.RoleBinding).RoleRef
+		return o.RoleRef, old, o.RoleRef != old
+	case *rbacv1.ClusterRoleBinding:
+		old := existing.(*rbacv1.ClusterRoleBinding).RoleRef
+		return o.RoleRef, old, o.RoleRef != old
+	default:
+		return rbacv1.RoleRef{}, rbacv1.RoleRef{}, false
+	}
+}
+
+// mayAdopt reports whether the controller may update the existing object on
+// behalf of the rule.
+func mayAdopt(RBACRule *rbaccontrollerv1.RBACRule, existing client.Object) bool {
+	if metav1.IsControlledBy(existing, RBACRule) {
+		return true
+	}
+	switch RBACRule.Spec.AdoptExisting {
+	case rbaccontrollerv1.AdoptionPolicyAlways:
+		return true
+	case rbaccontrollerv1.AdoptionPolicyNever:
+		return false
+	default:
+		return existing.GetLabels()[constants.RBACRuleLabel] == RBACRule.Name
+	}
+}
+
+func (r *RBACRuleReconciler) reconcileDelete(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule) error {
+	log.FromContext(ctx).Info("Deleting RBACRule", "Name", RBACRule.Name, "Namespace", RBACRule.Namespace)
+	if controllerutil.ContainsFinalizer(RBACRule, RBACRuleFinalizer) {
+		observed := RBACRule.Status.DeepCopy()
+		r.setPhase(RBACRule)
+		if err := r.writeStatus(ctx, RBACRule, observed, false); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to update RBACRule status")
+			return err
+		}
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonRevoked, "Rule deleted , revoking its bindings")
+		if err := r.revoke(ctx, RBACRule); err != nil {
+			return err
 		}
+	}
+	base := RBACRule.DeepCopy()
+	controllerutil.RemoveFinalizer(RBACRule, RBACRuleFinalizer)
+	if err := r.Patch(ctx, RBACRule, client.MergeFrom(base)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove finalizer from RBACRule")
 		return err
 	}
 	return nil
+
 }
 
-func (r *RBACRuleReconciler) createCR(ctx context.Context, cr *rbacv1.RoleBinding) error {
-	if err := r.Create(ctx, cr); err != nil {
-		if apierrors.IsAlreadyExists(err) {
-			if err = r.Update(ctx, cr); err != nil {
+// revoke deletes everything the rule granted: its bindings , ServiceAccounts ,
+// token Secrets , the namespaces it created as allowed by its deletion policy
+// and what it propagated to member clusters or exported.
+func (r *RBACRuleReconciler) revoke(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule) error {
+	ls := labels.SelectorFromSet(map[string]string{constants.RBACRuleLabel: RBACRule.Name})
+	if err := r.deleteBindings(ctx, ls); err != nil {
+		log.FromContext(ctx).Error(err, "failed to delete bindings")
+		return err
+	}
+	if err := r.deleteServiceAccounts(ctx, ls); err != nil {
+		log.FromContext(ctx).Error(err, "failed to delete ServiceAccounts")
+		return err
+	}
+	if err := r.deleteClusterRoles(ctx, ls); err != nil {
+		log.FromContext(ctx).Error(err, "failed to delete the ClusterRoles of role templates")
+		return err
+	}
+	if err := r.deleteTokenSecrets(ctx, ls); err != nil {
+		log.FromContext(ctx).Error(err, "failed to delete token Secrets")
+		return err
+	}
+	if err := r.deleteNamespaces(ctx, RBACRule, ls); err != nil {
+		log.FromContext(ctx).Error(err, "failed to delete namespaces")
+		return err
+	}
+	if r.Clusters != nil {
+		for _, c := range RBACRule.Status.Clusters {
+			if err := r.revokeFromCluster(ctx, RBACRule, c.Name); err != nil {
+				log.FromContext(ctx).Error(err, "failed to revoke bindings from member cluster", "cluster", c.Name)
 				return err
 			}
-			return nil
 		}
+	}
+	if r.Exporter != nil {
+		if err := r.Exporter.Remove(ctx, RBACRule.Name); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to remove exported bindings", "rule", RBACRule.Name)
+		}
+	}
+	return nil
+}
+
+// expire revokes what the expired rule granted , keeping the rule with an
+// Expired condition. What was revoked is only reported once.
+func (r *RBACRuleReconciler) expire(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule) error {
+	if meta.IsStatusConditionTrue(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionExpired) {
+		forgetLifetime(RBACRule.Name)
+		return nil
+	}
+	end := RBACRule.Spec.EndTime.UTC().Format(time.RFC3339)
+	r.event(RBACRule, corev1.EventTypeNormal, ReasonExpired, "Rule expired at %s , revoking its bindings", end)
+	if err := r.revoke(ctx, RBACRule); err != nil {
 		return err
 	}
+	RBACRule.Status.Bindings = nil
+	RBACRule.Status.Clusters = nil
+	countBindings(RBACRule)
+	meta.SetStatusCondition(&RBACRule.Status.Conditions, metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionExpired,
+		Status:             metav1.ConditionTrue,
+		Reason:             rbaccontrollerv1.ReasonEndTimeReached,
+		Message:            "The rule expired at " + end + " , what it granted was revoked",
+		ObservedGeneration: RBACRule.Generation,
+	})
+	r.setPhase(RBACRule)
+	expiredTotal.Inc()
+	forgetLifetime(RBACRule.Name)
 	return nil
 }
 
-func (r *RBACRuleReconciler) reconcileDelete(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule) error {
-	r.Log.Info("Deleting RBACRule", "Name", RBACRule.Name, "Namespace", RBACRule.Namespace)
-	if controllerutil.ContainsFinalizer(RBACRule, RBACRuleFinalizer) {
-		ls := labels.SelectorFromSet(map[string]string{constants.RBACRuleLabel: strings.Join([]string{RBACRule.Name, RBACRule.Namespace}, "-")})
-		if err := r.deleteBindings(ctx, RBACRule, ls); err != nil {
-			r.Log.Error(err, "failed to delete bindings")
+func (r *RBACRuleReconciler) deleteBindings(ctx context.Context, ls labels.Selector) error {
+	rbs, err := r.listMetadata(ctx, rbacv1.SchemeGroupVersion.WithKind("RoleBinding"), &client.ListOptions{
+		LabelSelector: ls,
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list role bindings")
+		return err
+	}
+	for _, rb := range rbs {
+		if err := r.Delete(ctx, &rb); client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).Error(err, "failed to delete roleBinding", "name", rb.Name, "namespace", rb.Namespace)
+			return err
+		}
+		r.forget(&rb)
+	}
+
+	// no ClusterRoleBinding is created when the controller only manages some
+	// namespaces.
+	if r.Config.IsNamespaceRestricted() {
+		return nil
+	}
+	crbs, err := r.listMetadata(ctx, rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"), &client.ListOptions{
+		LabelSelector: ls,
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list cluster role bindings")
+		return err
+	}
+	for _, crb := range crbs {
+		if err := r.Delete(ctx, &crb); client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).Error(err, "failed to delete clusterRoleBinding", "name", crb.Name)
 			return err
 		}
-		if err := r.deleteServiceAccounts(ctx, ls); err != nil {
-			r.Log.Error(err, "failed to delete ServiceAccounts")
+		r.forget(&crb)
+	}
+
+	return nil
+}
+
+// pruneBindings deletes the bindings controlled by the rule that it doesn't
+// generate anymore , e.g bindings removed from its spec or named by a
+// previous naming scheme.
+func (r *RBACRuleReconciler) pruneBindings(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, generatedRBs []rbacv1.RoleBinding, generatedCRBs []rbacv1.ClusterRoleBinding) error {
+	ls := client.MatchingLabels{constants.RBACRuleLabel: RBACRule.Name}
+
+	rbs, err := r.listMetadata(ctx, rbacv1.SchemeGroupVersion.WithKind("RoleBinding"), ls)
+	if err != nil {
+		return err
+	}
+	for _, rb := range rbs {
+		if !metav1.IsControlledBy(&rb, RBACRule) || slices.ContainsFunc(generatedRBs, func(g rbacv1.RoleBinding) bool {
+			return g.Namespace == rb.Namespace && g.Name == rb.Name
+		}) {
+			continue
+		}
+		if err := r.Delete(ctx, &rb); client.IgnoreNotFound(err) != nil {
 			return err
 		}
+		r.forget(&rb)
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonRevoked, "RoleBinding %s/%s isn't generated by the rule anymore , it was deleted", rb.Namespace, rb.Name)
 	}
-	controllerutil.RemoveFinalizer(RBACRule, RBACRuleFinalizer)
-	if err := r.Update(ctx, RBACRule); err != nil {
-		r.Log.Error(err, "failed to remove finalizer from RBACRule")
+
+	if r.Config.IsNamespaceRestricted() {
+		return nil
+	}
+	crbs, err := r.listMetadata(ctx, rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"), ls)
+	if err != nil {
 		return err
 	}
+	for _, crb := range crbs {
+		if !metav1.IsControlledBy(&crb, RBACRule) || slices.ContainsFunc(generatedCRBs, func(g rbacv1.ClusterRoleBinding) bool {
+			return g.Name == crb.Name
+		}) {
+			continue
+		}
+		if err := r.Delete(ctx, &crb); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.forget(&crb)
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonRevoked, "ClusterRoleBinding %s isn't generated by the rule anymore , it was deleted", crb.Name)
+	}
 	return nil
+}
 
+// reactivate clears the Expired condition of a retained rule whose times were
+// edited , its resources are then created again as for a new rule.
+func (r *RBACRuleReconciler) reactivate(RBACRule *rbaccontrollerv1.RBACRule) {
+	window := "without an end time"
+	if end := RBACRule.Spec.EndTime; !end.IsZero() {
+		window = "until " + end.UTC().Format(time.RFC3339)
+	}
+	if start := RBACRule.Spec.StartTime; !start.IsZero() && start.After(r.now()) {
+		window = "from " + start.UTC().Format(time.RFC3339) + " " + window
+	}
+	r.event(RBACRule, corev1.EventTypeNormal, ReasonReactivated, "Rule was reactivated %s", window)
+	meta.SetStatusCondition(&RBACRule.Status.Conditions, metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionExpired,
+		Status:             metav1.ConditionFalse,
+		Reason:             rbaccontrollerv1.ReasonReactivated,
+		Message:            "The rule was reactivated " + window,
+		ObservedGeneration: RBACRule.Generation,
+	})
+	r.setPhase(RBACRule)
 }
 
-func (r *RBACRuleReconciler) deleteBindings(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, ls labels.Selector) error {
-	if len(RBACRule.Status.RoleBindings) > 0 {
-		rbs := rbacv1.RoleBindingList{}
-		if err := r.List(ctx, &rbs, &client.ListOptions{
-			LabelSelector: ls,
-		}); err != nil {
-			r.Log.Error(err, "failed to list role bindings")
+// deleteClusterRoles deletes the ClusterRoles generated from the rule's role
+// templates. They are deleted one by one , so the deletions are audited.
+func (r *RBACRuleReconciler) deleteClusterRoles(ctx context.Context, ls labels.Selector) error {
+	// no ClusterRole is created when the controller only manages some
+	// namespaces.
+	if r.Config.IsNamespaceRestricted() {
+		return nil
+	}
+	crs, err := r.listMetadata(ctx, rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), &client.ListOptions{
+		LabelSelector: ls,
+	})
+	if err != nil {
+		return err
+	}
+	for _, cr := range crs {
+		if err := r.Delete(ctx, &cr); client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).Error(err, "failed to delete ClusterRole", "name", cr.Name)
 			return err
 		}
-		for _, rb := range rbs.Items {
-			if err := r.Delete(ctx, &rb); err != nil {
-				r.Log.Error(err, "failed to delete roleBinding", "name", rb.Name, "namespace", rb.Namespace)
-				return err
-			}
-			i := slices.Index(RBACRule.Status.RoleBindings, rb.Name)
-			RBACRule.Status.RoleBindings = slices.Delete(RBACRule.Status.RoleBindings, i, i)
-			if err := r.Update(ctx, RBACRule); err != nil {
-				r.Log.Error(err, "failed to remove role binding from status", "name", rb.Name, "namepsace", rb.Namespace)
-				return err
-			}
-		}
+		r.forget(&cr)
 	}
-	if len(RBACRule.Status.ClusterRoleBindings) > 0 {
-		crbs := rbacv1.ClusterRoleBindingList{}
+	return nil
+}
 
-		if err := r.List(ctx, &crbs, &client.ListOptions{
-			LabelSelector: ls,
-		}); err != nil {
-			r.Log.Error(err, "failed to list role bindings")
+// deleteTokenSecrets deletes the Secrets holding the tokens generated for the
+// rule's ServiceAccounts , revoking the tokens. Secrets aren't cached , they
+// are listed through the API reader so the manager doesn't start caching
+// every Secret in the cluster.
+func (r *RBACRuleReconciler) deleteTokenSecrets(ctx context.Context, ls labels.Selector) error {
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := r.APIReader.List(ctx, secrets, &client.ListOptions{LabelSelector: ls}); err != nil {
+		return err
+	}
+	for _, secret := range secrets.Items {
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		if err := r.Delete(ctx, &secret); client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).Error(err, "failed to delete token Secret", "name", secret.Name, "namespace", secret.Namespace)
 			return err
 		}
-		for _, crb := range crbs.Items {
-			if err := r.Delete(ctx, &crb); err != nil {
-				r.Log.Error(err, "failed to delete clusterRoleBinding", "name", crb.Name)
-				return err
-			}
-			i := slices.Index(RBACRule.Status.ClusterRoleBindings, crb.Name)
-			RBACRule.Status.ClusterRoleBindings = slices.Delete(RBACRule.Status.ClusterRoleBindings, i, i)
-			if err := r.Update(ctx, RBACRule); err != nil {
-				r.Log.Error(err, "failed to remove cluster role binding from status", "name", crb.Name)
+	}
+	return nil
+}
+
+func (r *RBACRuleReconciler) deleteServiceAccounts(ctx context.Context, ls labels.Selector) error {
+	sas, err := r.listMetadata(ctx, corev1.SchemeGroupVersion.WithKind("ServiceAccount"), &client.ListOptions{
+		LabelSelector: ls,
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error listing Rule's serviceaccounts")
+		return err
+	}
+
+	for _, sa := range sas {
+		if err := r.Delete(ctx, &sa); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.FromContext(ctx).Error(err, "failed to delete service account", "name", sa.Name, "namespace", sa.Namespace)
 				return err
 			}
 		}
+		r.forget(&sa)
 	}
 
 	return nil
 }
 
-func (r *RBACRuleReconciler) deleteServiceAccounts(ctx context.Context, ls labels.Selector) error {
-	log := log.FromContext(ctx)
+// deleteNamespaces deletes the namespaces created for the rule when its
+// deletion policy allows it. Namespaces still holding workloads are retained.
+func (r *RBACRuleReconciler) deleteNamespaces(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, ls labels.Selector) error {
+	if RBACRule.Spec.DeletionPolicy == rbaccontrollerv1.DeletionPolicyRetain {
+		return nil
+	}
 
-	sas := corev1.ServiceAccountList{}
-	if err := r.List(ctx, &sas, &client.ListOptions{
+	nss, err := r.listMetadata(ctx, corev1.SchemeGroupVersion.WithKind("Namespace"), &client.ListOptions{
 		LabelSelector: ls,
-	}); err != nil {
-		log.Error(err, "error listing Rule's serviceaccounts")
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list namespaces")
 		return err
 	}
 
-	for _, sa := range sas.Items {
-		if err := r.Delete(ctx, &sa); err != nil {
+	for _, ns := range nss {
+		empty, err := r.namespaceIsEmpty(ctx, ns.Name)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to check namespace workloads", "namespace", ns.Name)
+			return err
+		}
+		if !empty {
+			log.FromContext(ctx).Info("Retaining namespace , it still holds workloads", "namespace", ns.Name)
+			r.event(RBACRule, corev1.EventTypeWarning, ReasonNamespaceRetained,
+				"Namespace %s was not deleted since it still holds workloads", ns.Name)
+			continue
+		}
+		if err := r.Delete(ctx, &ns); err != nil {
 			if !apierrors.IsNotFound(err) {
-				r.Log.Error(err, "failed to delete service account", "name", sa.Name, "namespace", sa.Namespace)
+				log.FromContext(ctx).Error(err, "failed to delete namespace", "namespace", ns.Name)
 				return err
 			}
 		}
@@ -341,14 +1604,202 @@ func (r *RBACRuleReconciler) deleteServiceAccounts(ctx context.Context, ls label
 	return nil
 }
 
+// listMetadata lists the metadata of the objects of the kind , the cache only
+// holds the metadata of the objects the controller generates.
+func (r *RBACRuleReconciler) listMetadata(ctx context.Context, gvk schema.GroupVersionKind, opts ...client.ListOption) ([]metav1.PartialObjectMetadata, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		list.Items[i].SetGroupVersionKind(gvk)
+	}
+	return list.Items, nil
+}
+
+// namespaceIsEmpty reports whether the namespace holds no pods and no
+// persistent volume claims. It goes through the API reader so the manager
+// doesn't start caching every pod in the cluster.
+func (r *RBACRuleReconciler) namespaceIsEmpty(ctx context.Context, ns string) (bool, error) {
+	for _, kind := range []string{"PodList", "PersistentVolumeClaimList"} {
+		items := &metav1.PartialObjectMetadataList{}
+		items.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(kind))
+		if err := r.APIReader.List(ctx, items, client.InNamespace(ns), client.Limit(1)); err != nil {
+			return false, err
+		}
+		if len(items.Items) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Add sets up the controller with the Manager , filling the reconciler
+// dependencies the manager provides. The client is only set when the caller
+// didn't provide one (e.g an auditing client). Each reconciliation logs
+// through a logger derived from rawLogger , carrying the rule , the binding
+// being reconciled and the reconcile ID.
+func Add(mgr ctrl.Manager, rawLogger logr.Logger, r *RBACRuleReconciler) error {
+	if r.Client == nil {
+		r.Client = mgr.GetClient()
+	}
+	r.Scheme = mgr.GetScheme()
+	r.Recorder = mgr.GetEventRecorderFor(ControllerName)
+	r.APIReader = mgr.GetAPIReader()
+	r.Log = rawLogger
+	lifetimes.now = r.now
+	if r.Scheduler != nil {
+		// the scheduler runs along with the controller , on the leader unless
+		// the rules are sharded across the replicas.
+		r.Scheduler.Times = r.ruleTransitions
+		r.Scheduler.LeaderElection = r.Shards == nil
+		if err := mgr.Add(r.Scheduler); err != nil {
+			return err
+		}
+	}
+	return r.SetupWithManager(mgr)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *RBACRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rbaccontrollerv1.RBACRule{}).
-		Owns(&corev1.ServiceAccount{}).     //Watches SAs owned by the rbac-rule controller
-		Owns(&rbacv1.RoleBinding{}).        //Watches RBs owned by the rbac-rule controller
-		Owns(&rbacv1.ClusterRoleBinding{}). //Watches CRBs owned by the rbac-rule controller
-		Owns(&corev1.Namespace{}).          //Watches NSs owned by the rbac-rule controller
-		Named(ControllerName).
-		Complete(r)
+		// generated objects are only watched , and cached , through their
+		// metadata.
+		Owns(&corev1.ServiceAccount{}, builder.OnlyMetadata). //Watches SAs owned by the rbac-rule controller
+		Owns(&rbacv1.RoleBinding{}, builder.OnlyMetadata).    //Watches RBs owned by the rbac-rule controller
+		// rules selecting namespaces by label are reconciled when a namespace
+		// appears or its labels change. Namespaces are watched through their
+		// metadata , as the parser lists them.
+		WatchesMetadata(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesSelectingNamespace),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		// rules are reconciled when a namespace they granted access in is
+		// deleted or recreated , to apply their resources in it again.
+		WatchesMetadata(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesBoundInNamespace),
+			builder.WithPredicates(createOrDelete)).
+		// rules are reconciled when a role they reference is deleted or
+		// recreated , to report it missing or to verify it again.
+		WatchesMetadata(&rbacv1.Role{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesReferencingRole),
+			builder.WithPredicates(createOrDelete)).
+		// rules selecting ServiceAccounts by label or name pattern , or
+		// enumerating them , are reconciled when one appears , disappears or
+		// its labels change.
+		WatchesMetadata(&corev1.ServiceAccount{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesSelectingServiceAccount),
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Named(ControllerName)
+	// ClusterRoleBindings and ClusterRoles can't be watched without cluster
+	// wide permissions.
+	if !r.Config.IsNamespaceRestricted() {
+		//Watches CRBs owned by the rbac-rule controller
+		b = b.Owns(&rbacv1.ClusterRoleBinding{}, builder.OnlyMetadata).
+			WatchesMetadata(&rbacv1.ClusterRole{},
+				handler.EnqueueRequestsFromMapFunc(r.rulesReferencingClusterRole),
+				builder.WithPredicates(createOrDelete))
+	}
+	if r.Config.GetMaxRulesPerTeam() > 0 {
+		// the rules of a team are reconciled when one of them is deleted , one
+		// beyond the team's limit may fit now.
+		b = b.Watches(&rbaccontrollerv1.RBACRule{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesOfTeam),
+			builder.WithPredicates(onlyDelete))
+	}
+	if r.Teams != nil {
+		// the rules of teams are reconciled when the catalog changes , which
+		// is read from the API server like the role bundles.
+		b = b.WatchesMetadata(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesOfTeams),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return client.ObjectKeyFromObject(o) == r.Teams.ConfigMap
+			})))
+	}
+	if r.RoleTemplates != nil {
+		// rules referencing a role template are reconciled when it changes ,
+		// the templates are read from the API server.
+		b = b.WatchesMetadata(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesUsingRoleTemplate),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetNamespace() == r.RoleTemplates.Namespace
+			})))
+	}
+	if r.Shards != nil {
+		// every replica reconciles the rules it owns , and every rule is
+		// reconciled again once the replicas changed so they are rebalanced.
+		rebalance := make(chan event.GenericEvent, 1)
+		r.Shards.OnChange = func() {
+			select {
+			case rebalance <- event.GenericEvent{Object: &rbaccontrollerv1.RBACRule{}}:
+			default:
+				// a rebalance is already pending.
+			}
+		}
+		b = b.WatchesRawSource(source.Channel(rebalance, handler.EnqueueRequestsFromMapFunc(r.allRules))).
+			WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)})
+	}
+	if r.Config != nil {
+		// every rule is reconciled again once the policy changed , e.g a
+		// namespace became protected.
+		changed := make(chan event.GenericEvent, 1)
+		r.Config.OnChange(func() {
+			select {
+			case changed <- event.GenericEvent{Object: &rbaccontrollerv1.RBACRule{}}:
+			default:
+				// a reconciliation of every rule is already pending.
+			}
+		})
+		b = b.WatchesRawSource(source.Channel(changed, handler.EnqueueRequestsFromMapFunc(r.allRules)))
+	}
+	if r.Scheduler != nil {
+		// rules are reconciled when they're activated , enter their expiring
+		// window or expire.
+		b = b.WatchesRawSource(source.Channel(r.Scheduler.Events(), &handler.EnqueueRequestForObject{}))
+	}
+	if r.Bundles != nil {
+		// rules referencing a bundle are reconciled when the catalog changes ,
+		// only the metadata of ConfigMaps is cached , the catalog is read
+		// from the API server.
+		b = b.WatchesMetadata(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.rulesUsingBundles),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return client.ObjectKeyFromObject(o) == r.Bundles.ConfigMap
+			})))
+	}
+	return b.Complete(r)
+}
+
+// rulesUsingBundles returns a request for every rule referencing a role
+// bundle.
+func (r *RBACRuleReconciler) rulesUsingBundles(ctx context.Context, _ client.Object) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		r.Log.Error(err, "Failed to list the rules using role bundles")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rule := range rules.Items {
+		if usesBundles(&rule) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+		}
+	}
+	return requests
+}
+
+func usesBundles(RBACRule *rbaccontrollerv1.RBACRule) bool {
+	for _, b := range RBACRule.Spec.Bindings {
+		for _, crb := range b.ClusterRoleBindings {
+			if crb.Bundle != "" {
+				return true
+			}
+		}
+		for _, rb := range b.RoleBindings {
+			if rb.Bundle != "" {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -18,19 +18,46 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	"github.com/go-logr/logr/funcr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	rbaccontrolleriov1alpha1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/config"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+	"github.com/GGh41th/rbac-controller/internal/utils"
 )
 
-var _ = Describe("RBACRule Controller", func() {
+var _ = Describe("RBACRule Controller", Ordered, func() {
+	BeforeAll(startTestEnv)
+	AfterAll(stopTestEnv)
+
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
 
@@ -82,3 +109,852 @@ var _ = Describe("RBACRule Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("detach", func() {
+	It("keeps the context until the timeout went by once the parent is canceled", func() {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := detach(parent, 50*time.Millisecond)
+		defer cancel()
+
+		cancelParent()
+		Consistently(ctx.Done(), 20*time.Millisecond).ShouldNot(BeClosed())
+		Eventually(ctx.Done()).Should(BeClosed())
+	})
+
+	It("never cancels the context with a negative timeout", func() {
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := detach(parent, -1)
+		defer cancel()
+
+		cancelParent()
+		Consistently(ctx.Done(), 50*time.Millisecond).ShouldNot(BeClosed())
+	})
+})
+
+var _ = DescribeTable("shouldCreateSA",
+	func(bindingCreateSA *bool, s rbaccontrolleriov1alpha1.Subject, expected bool) {
+		b := &rbaccontrolleriov1alpha1.Binding{CreateSA: bindingCreateSA}
+		Expect(shouldCreateSA(b, &s)).To(Equal(expected))
+	},
+	Entry("follows the subject when the binding doesn't set it",
+		nil, rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci", CreateSA: true}, true),
+	Entry("lets the binding disable it",
+		ptr.To(false), rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci", CreateSA: true}, false),
+	Entry("lets the binding enable it",
+		ptr.To(true), rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci"}, true),
+	Entry("never creates the ServiceAccounts selected by label",
+		ptr.To(true), rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.ServiceAccount, ServiceAccountSelector: &metav1.LabelSelector{}, CreateSA: true}, false),
+	Entry("never creates the ServiceAccounts bound by AllServiceAccounts",
+		ptr.To(true), rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.AllServiceAccounts, Namespaces: []string{"team-a"}}, false),
+)
+
+var _ = Describe("mayAdopt", func() {
+	rule := &rbaccontrolleriov1alpha1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "rule", UID: "rule-uid"}}
+	existing := func(labels map[string]string, controlled bool) client.Object {
+		rb := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "rb", Labels: labels}}
+		if controlled {
+			rb.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(rule, rbaccontrolleriov1alpha1.GroupVersion.WithKind("RBACRule"))}
+		}
+		return rb
+	}
+	labelled := map[string]string{constants.RBACRuleLabel: "rule"}
+
+	DescribeTable("adopts",
+		func(policy rbaccontrolleriov1alpha1.AdoptionPolicy, obj client.Object, expected bool) {
+			r := rule.DeepCopy()
+			r.Spec.AdoptExisting = policy
+			Expect(mayAdopt(r, obj)).To(Equal(expected))
+		},
+		Entry("the objects the rule controls , whatever the policy",
+			rbaccontrolleriov1alpha1.AdoptionPolicyNever, existing(nil, true), true),
+		Entry("the objects labelled for the rule by default",
+			rbaccontrolleriov1alpha1.AdoptionPolicy(""), existing(labelled, false), true),
+		Entry("no object labelled for another rule by default",
+			rbaccontrolleriov1alpha1.AdoptionPolicy(""), existing(map[string]string{constants.RBACRuleLabel: "other"}, false), false),
+		Entry("no unlabelled object by default",
+			rbaccontrolleriov1alpha1.AdoptionPolicy(""), existing(nil, false), false),
+		Entry("any object with the Always policy",
+			rbaccontrolleriov1alpha1.AdoptionPolicyAlways, existing(nil, false), true),
+		Entry("no labelled object with the Never policy",
+			rbaccontrolleriov1alpha1.AdoptionPolicyNever, existing(labelled, false), false),
+	)
+})
+
+// fakeNow is the time of the clock of the fake reconcilers.
+var fakeNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeReconciler runs the reconciliation against a fake client , the specs
+// running against the API server are in the RBACRule Controller container.
+type fakeReconciler struct {
+	*RBACRuleReconciler
+	recorder *record.FakeRecorder
+	clock    *clocktesting.FakeClock
+	// whether the controller may bind roles.
+	bindAllowed bool
+	// the writes of bindings , and their full reads.
+	writes, reads int
+	// the writes of the status of rules.
+	statusWrites int
+}
+
+func isBinding(obj client.Object) bool {
+	switch obj.(type) {
+	case *rbacv1.RoleBinding, *rbacv1.ClusterRoleBinding:
+		return true
+	}
+	return false
+}
+
+// newFakeReconciler returns a reconciler allowed to bind roles , reading and
+// writing the objects through a fake client. The namespace team-a and the
+// ClusterRole view are always there.
+func newFakeReconciler(objs ...client.Object) *fakeReconciler {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(rbaccontrolleriov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	f := &fakeReconciler{recorder: record.NewFakeRecorder(100), clock: clocktesting.NewFakeClock(fakeNow), bindAllowed: true}
+	objs = append(objs,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}},
+	)
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&rbaccontrolleriov1alpha1.RBACRule{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+					review.Status.Allowed = f.bindAllowed
+					return nil
+				}
+				if isBinding(obj) {
+					f.writes++
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if isBinding(obj) {
+					f.writes++
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if isBinding(obj) {
+					f.reads++
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+			SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+				if _, ok := obj.(*rbaccontrolleriov1alpha1.RBACRule); ok && subResourceName == "status" {
+					f.statusWrites++
+				}
+				return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	f.RBACRuleReconciler = &RBACRuleReconciler{
+		Client:    c,
+		APIReader: c,
+		Scheme:    scheme,
+		Log:       GinkgoLogr,
+		Recorder:  f.recorder,
+		Config:    &config.Config{},
+		Clock:     f.clock,
+	}
+	return f
+}
+
+// rule returns the rule as stored.
+func (f *fakeReconciler) rule(name string) *rbaccontrolleriov1alpha1.RBACRule {
+	rule := &rbaccontrolleriov1alpha1.RBACRule{}
+	Expect(f.Get(context.Background(), types.NamespacedName{Name: name}, rule)).To(Succeed())
+	return rule
+}
+
+// events drains the events recorded so far.
+func (f *fakeReconciler) events() []string {
+	var all []string
+	for {
+		select {
+		case e := <-f.recorder.Events:
+			all = append(all, e)
+		default:
+			return all
+		}
+	}
+}
+
+// newRule returns a rule granting ClusterRole view in namespace team-a to
+// alice , through its binding dev.
+func newRule() *rbaccontrolleriov1alpha1.RBACRule {
+	return &rbaccontrolleriov1alpha1.RBACRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "rule", UID: "rule-uid", Generation: 1},
+		Spec: rbaccontrolleriov1alpha1.RBACRuleSpec{Bindings: []rbaccontrolleriov1alpha1.Binding{{
+			Name:         "dev",
+			Subjects:     []rbaccontrolleriov1alpha1.Subject{{Kind: rbaccontrolleriov1alpha1.User, Name: "alice"}},
+			RoleBindings: []rbaccontrolleriov1alpha1.RoleBinding{{ClusterRole: "view", Namespaces: []string{"team-a"}}},
+		}}},
+	}
+}
+
+var _ = Describe("Reconcile", func() {
+	var (
+		ctx = context.Background()
+		req = reconcile.Request{NamespacedName: types.NamespacedName{Name: "rule"}}
+		// the key of the RoleBinding generated for newRule's binding.
+		rbKey = types.NamespacedName{Namespace: "team-a", Name: utils.GenerateName("rule-uid", "rule", "dev", parser.CRB, "view")}
+	)
+
+	getRoleBinding := func(r *fakeReconciler) (*rbacv1.RoleBinding, error) {
+		rb := &rbacv1.RoleBinding{}
+		return rb, r.Get(ctx, rbKey, rb)
+	}
+	ownedBy := func(rule *rbaccontrolleriov1alpha1.RBACRule) []metav1.OwnerReference {
+		return []metav1.OwnerReference{*metav1.NewControllerRef(rule, rbaccontrolleriov1alpha1.GroupVersion.WithKind("RBACRule"))}
+	}
+
+	It("creates the bindings and reports them", func() {
+		r := newFakeReconciler(newRule())
+		Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{}))
+
+		rb, err := getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rb.RoleRef.Name).To(Equal("view"))
+		Expect(rb.Subjects).To(ConsistOf(HaveField("Name", "alice")))
+		Expect(rb.Labels).To(HaveKeyWithValue(constants.RBACRuleLabel, "rule"))
+
+		rule := r.rule("rule")
+		Expect(rule.Finalizers).To(ContainElement(RBACRuleFinalizer))
+		Expect(rule.Status.Phase).To(Equal(rbaccontrolleriov1alpha1.RBACRulePhaseActive))
+		Expect(rule.Status.Bindings).To(ConsistOf(HaveField("RoleBindings", ConsistOf(rbKey.String()))))
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonBindingCreated)))
+	})
+
+	It("doesn't write the status again while it doesn't change", func() {
+		r := newFakeReconciler(newRule())
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.statusWrites).To(Equal(1))
+
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.statusWrites).To(Equal(1))
+	})
+
+	It("records when the rule was last reconciled and applied , refreshing it periodically", func() {
+		r := newFakeReconciler(newRule())
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		status := r.rule("rule").Status
+		Expect(status.LastReconcileTime.Time).To(BeTemporally("==", fakeNow))
+		Expect(status.LastAppliedTime.Time).To(BeTemporally("==", fakeNow))
+
+		r.clock.Step(statusRefreshPeriod - time.Second)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.rule("rule").Status.LastReconcileTime.Time).To(BeTemporally("==", fakeNow))
+
+		r.clock.Step(time.Second)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		status = r.rule("rule").Status
+		Expect(status.LastReconcileTime.Time).To(BeTemporally("==", fakeNow.Add(statusRefreshPeriod)))
+		Expect(status.LastAppliedTime.Time).To(BeTemporally("==", fakeNow.Add(statusRefreshPeriod)))
+	})
+
+	It("doesn't record the rules whose bindings weren't all applied as applied", func() {
+		rule := newRule()
+		rule.Spec.Bindings[0].RoleBindings[0].ClusterRole = "missing"
+		r := newFakeReconciler(rule)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		status := r.rule("rule").Status
+		Expect(status.LastReconcileTime.Time).To(BeTemporally("==", fakeNow))
+		Expect(status.LastAppliedTime).To(BeNil())
+	})
+
+	It("patches every condition set while reconciling at once", func() {
+		rule := newRule()
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(30 * time.Minute))
+		rule.Spec.Bindings[0].RoleBindings = append(rule.Spec.Bindings[0].RoleBindings,
+			rbaccontrolleriov1alpha1.RoleBinding{ClusterRole: "missing", Namespaces: []string{"team-a"}})
+		r := newFakeReconciler(rule)
+		r.Config.ExpiringWindow = time.Hour
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		Expect(r.statusWrites).To(Equal(1))
+		conditions := r.rule("rule").Status.Conditions
+		Expect(meta.IsStatusConditionTrue(conditions, rbaccontrolleriov1alpha1.ConditionExpiring)).To(BeTrue())
+		degraded := meta.FindStatusCondition(conditions, rbaccontrolleriov1alpha1.ConditionDegraded)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonRoleNotFound))
+	})
+
+	It("counts the bindings it generated", func() {
+		rule := newRule()
+		rule.Spec.Bindings[0].ClusterRoleBindings = []rbaccontrolleriov1alpha1.ClusterRoleBinding{{ClusterRole: "view"}}
+		r := newFakeReconciler(rule)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		status := r.rule("rule").Status
+		Expect(status.RoleBindingCount).To(BeEquivalentTo(1))
+		Expect(status.ClusterRoleBindingCount).To(BeEquivalentTo(1))
+	})
+
+	It("traces the reconcile and the parsing of its bindings", func() {
+		spans := tracetest.NewSpanRecorder()
+		previous := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+		DeferCleanup(otel.SetTracerProvider, previous)
+
+		r := newFakeReconciler(newRule())
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		// the reconcile span ends last.
+		ended := spans.Ended()
+		reconcileSpan := ended[len(ended)-1]
+		Expect(reconcileSpan.Name()).To(Equal("Reconcile"))
+		Expect(reconcileSpan.Attributes()).To(ContainElement(attribute.String("rbacrule", "rule")))
+		Expect(ended).To(ContainElement(And(
+			WithTransform(sdktrace.ReadOnlySpan.Name, Equal("Parse")),
+			WithTransform(sdktrace.ReadOnlySpan.Attributes, ContainElement(attribute.String("binding", "dev"))),
+			WithTransform(func(s sdktrace.ReadOnlySpan) trace.SpanID { return s.Parent().SpanID() }, Equal(reconcileSpan.SpanContext().SpanID())),
+		)))
+	})
+
+	It("logs through a logger carrying the rule , and the binding being reconciled", func() {
+		var lines []string
+		rule := newRule()
+		rule.Spec.NamespacePolicy = rbaccontrolleriov1alpha1.NamespacePolicySkip
+		rule.Spec.Bindings[0].Subjects = append(rule.Spec.Bindings[0].Subjects, rbaccontrolleriov1alpha1.Subject{
+			Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci", Namespaces: []string{"team-b"}, CreateSA: true,
+		})
+		r := newFakeReconciler(rule)
+		r.Log = funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{})
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		Expect(lines).NotTo(BeEmpty())
+		Expect(lines).To(HaveEach(ContainSubstring(`"rbacrule"="rule"`)))
+		Expect(lines).To(ContainElement(And(ContainSubstring("Skipping SA"), ContainSubstring(`"binding"="dev"`))))
+	})
+
+	It("doesn't read nor write the bindings again while they don't change", func() {
+		r := newFakeReconciler(newRule())
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.writes).To(Equal(1))
+
+		r.writes, r.reads = 0, 0
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.writes).To(BeZero())
+		Expect(r.reads).To(BeZero())
+
+		By("updating the bindings changed by someone else")
+		rb, err := getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		rb.Subjects = append(rb.Subjects, rbacv1.Subject{Kind: rbacv1.UserKind, Name: "mallory"})
+		Expect(r.Update(ctx, rb)).To(Succeed())
+		r.writes, r.reads = 0, 0
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.writes).To(Equal(1))
+		rb, err = getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rb.Subjects).To(ConsistOf(HaveField("Name", "alice")))
+	})
+
+	It("reads the existing bindings in full once , until they change", func() {
+		rule := newRule()
+		r := newFakeReconciler(rule, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       rbKey.Namespace,
+				Name:            rbKey.Name,
+				Labels:          map[string]string{constants.RBACRuleLabel: "rule"},
+				OwnerReferences: ownedBy(rule),
+			},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "alice"}},
+		})
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.reads).To(Equal(1))
+
+		r.reads, r.writes = 0, 0
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.reads).To(BeZero())
+		Expect(r.writes).To(BeZero())
+	})
+
+	It("recreates the bindings whose role changed", func() {
+		rule := newRule()
+		r := newFakeReconciler(rule, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       rbKey.Namespace,
+				Name:            rbKey.Name,
+				UID:             "old-uid",
+				Labels:          map[string]string{constants.RBACRuleLabel: "rule"},
+				OwnerReferences: ownedBy(rule),
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "deployer"},
+		})
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		rb, err := getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"}))
+		Expect(r.events()).To(ContainElement(And(ContainSubstring(ReasonBindingRecreated), ContainSubstring("from Role deployer to ClusterRole view"))))
+	})
+
+	It("leaves the bindings it may not adopt alone", func() {
+		r := newFakeReconciler(newRule(), &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rbKey.Namespace, Name: rbKey.Name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		})
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		rb, err := getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rb.Labels).To(BeEmpty())
+		Expect(rb.Subjects).To(BeEmpty())
+		Expect(r.writes).To(BeZero())
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonNotAdopted)))
+	})
+
+	It("reports the bindings it isn't allowed to write", func() {
+		r := newFakeReconciler(newRule())
+		r.bindAllowed = false
+		Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{RequeueAfter: permissionsRetryPeriod}))
+
+		_, err := getRoleBinding(r)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		rule := r.rule("rule")
+		degraded := meta.FindStatusCondition(rule.Status.Conditions, rbaccontrolleriov1alpha1.ConditionDegraded)
+		Expect(degraded).NotTo(BeNil())
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonInsufficientControllerPermissions))
+		Expect(degraded.Message).To(ContainSubstring("not allowed to bind ClusterRole view in namespace team-a"))
+		Expect(rule.Status.Phase).To(Equal(rbaccontrolleriov1alpha1.RBACRulePhaseFailed))
+	})
+
+	It("revokes the bindings left unused", func() {
+		rule := newRule()
+		rule.Finalizers = []string{RBACRuleFinalizer}
+		rule.Spec.RevokeIfUnusedFor = &metav1.Duration{Duration: time.Hour}
+		applied := metav1.NewTime(fakeNow.Add(-2 * time.Hour))
+		rule.Status.Bindings = []rbaccontrolleriov1alpha1.BindingStatus{{Name: "dev", AppliedAt: &applied, RoleBindings: []string{rbKey.String()}}}
+		r := newFakeReconciler(rule, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       rbKey.Namespace,
+				Name:            rbKey.Name,
+				Labels:          map[string]string{constants.RBACRuleLabel: "rule"},
+				OwnerReferences: ownedBy(rule),
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		})
+		r.UsageRecorded = true
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		_, err := getRoleBinding(r)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		status := r.rule("rule").Status.Bindings
+		Expect(status).To(HaveLen(1))
+		Expect(status[0].AppliedAt).To(BeNil())
+		ready := meta.FindStatusCondition(status[0].Conditions, rbaccontrolleriov1alpha1.ConditionReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonUnused))
+
+		By("keeping them revoked until the rule is updated")
+		r.writes = 0
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.writes).To(BeZero())
+	})
+
+	It("keeps the bindings used recently", func() {
+		rule := newRule()
+		rule.Spec.RevokeIfUnusedFor = &metav1.Duration{Duration: time.Hour}
+		applied := metav1.NewTime(fakeNow.Add(-2 * time.Hour))
+		used := metav1.NewTime(fakeNow.Add(-10 * time.Minute))
+		rule.Status.Bindings = []rbaccontrolleriov1alpha1.BindingStatus{{Name: "dev", AppliedAt: &applied, LastUsed: &used}}
+		r := newFakeReconciler(rule)
+		r.UsageRecorded = true
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(50 * time.Minute))
+
+		_, err = getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("applies the bindings again in recreated namespaces", func() {
+		rule := newRule()
+		rule.Spec.NamespacePolicy = rbaccontrolleriov1alpha1.NamespacePolicySkip
+		r := newFakeReconciler(rule)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		rb, err := getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		r.events()
+
+		By("deleting the namespace")
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+		Expect(r.Delete(ctx, ns)).To(Succeed())
+		Expect(r.Delete(ctx, rb)).To(Succeed())
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.rule("rule").Status.Bindings[0].DeletedNamespaces).To(ConsistOf("team-a"))
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonNamespaceDeleted)))
+
+		By("recreating the namespace")
+		ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+		Expect(r.Create(ctx, ns)).To(Succeed())
+		Expect(r.rulesBoundInNamespace(ctx, ns)).To(ConsistOf(reconcile.Request{NamespacedName: req.NamespacedName}))
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		_, err = getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.rule("rule").Status.Bindings[0].DeletedNamespaces).To(BeEmpty())
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonNamespaceRecreated)))
+	})
+
+	It("retains expired rules , revoking what they granted", func() {
+		rule := newRule()
+		rule.Finalizers = []string{RBACRuleFinalizer}
+		rule.Spec.ExpiredRulePolicy = rbaccontrolleriov1alpha1.ExpiredRulePolicyRetain
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(-time.Minute))
+		r := newFakeReconciler(rule, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       rbKey.Namespace,
+				Name:            rbKey.Name,
+				Labels:          map[string]string{constants.RBACRuleLabel: "rule"},
+				OwnerReferences: ownedBy(rule),
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+		})
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		_, err := getRoleBinding(r)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		rule = r.rule("rule")
+		Expect(rule.DeletionTimestamp).To(BeNil())
+		Expect(rule.Status.Phase).To(Equal(rbaccontrolleriov1alpha1.RBACRulePhaseExpired))
+		Expect(meta.IsStatusConditionTrue(rule.Status.Conditions, rbaccontrolleriov1alpha1.ConditionExpired)).To(BeTrue())
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonExpired)))
+
+		By("not revoking them again")
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.events()).To(BeEmpty())
+	})
+
+	It("reactivates retained expired rules once their end time is pushed back", func() {
+		rule := newRule()
+		rule.Finalizers = []string{RBACRuleFinalizer}
+		rule.Spec.ExpiredRulePolicy = rbaccontrolleriov1alpha1.ExpiredRulePolicyRetain
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(time.Hour))
+		rule.Status.Phase = rbaccontrolleriov1alpha1.RBACRulePhaseExpired
+		meta.SetStatusCondition(&rule.Status.Conditions, metav1.Condition{
+			Type: rbaccontrolleriov1alpha1.ConditionExpired, Status: metav1.ConditionTrue, Reason: rbaccontrolleriov1alpha1.ReasonEndTimeReached,
+		})
+		r := newFakeReconciler(rule)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		_, err := getRoleBinding(r)
+		Expect(err).NotTo(HaveOccurred())
+		status := r.rule("rule").Status
+		Expect(status.Phase).To(Equal(rbaccontrolleriov1alpha1.RBACRulePhaseActive))
+		expired := meta.FindStatusCondition(status.Conditions, rbaccontrolleriov1alpha1.ConditionExpired)
+		Expect(expired.Status).To(Equal(metav1.ConditionFalse))
+		Expect(expired.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonReactivated))
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonReactivated)))
+	})
+
+	It("reads the time from its clock", func() {
+		rule := newRule()
+		rule.Spec.ExpiredRulePolicy = rbaccontrolleriov1alpha1.ExpiredRulePolicyRetain
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(time.Hour))
+		r := newFakeReconciler(rule)
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+
+		r.clock.Step(time.Hour)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+		Expect(r.rule("rule").Status.Phase).To(Equal(rbaccontrolleriov1alpha1.RBACRulePhaseExpired))
+		_, err = getRoleBinding(r)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("warns that rules are expiring within their grace period , until their end time is pushed back", func() {
+		rule := newRule()
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(20 * time.Minute))
+		rule.Spec.RevocationGracePeriod = &metav1.Duration{Duration: 30 * time.Minute}
+		r := newFakeReconciler(rule)
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		rule = r.rule("rule")
+		expiring := meta.FindStatusCondition(rule.Status.Conditions, rbaccontrolleriov1alpha1.ConditionExpiring)
+		Expect(expiring).NotTo(BeNil())
+		Expect(expiring.Status).To(Equal(metav1.ConditionTrue))
+		Expect(expiring.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonEndTimeApproaching))
+		Expect(r.events()).To(ContainElement(ContainSubstring(ReasonExpiring)))
+
+		By("pushing back the end time")
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(2 * time.Hour))
+		Expect(r.Update(ctx, rule)).To(Succeed())
+		Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+		expiring = meta.FindStatusCondition(r.rule("rule").Status.Conditions, rbaccontrolleriov1alpha1.ConditionExpiring)
+		Expect(expiring.Status).To(Equal(metav1.ConditionFalse))
+		Expect(expiring.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonEndTimeExtended))
+		Expect(r.events()).NotTo(ContainElement(ContainSubstring(ReasonExpiring)))
+	})
+
+	Context("with ServiceAccount subjects", func() {
+		serviceAccountRule := func(bindingCreateSA *bool, createSA bool) *rbaccontrolleriov1alpha1.RBACRule {
+			rule := newRule()
+			rule.Spec.Bindings[0].CreateSA = bindingCreateSA
+			rule.Spec.Bindings[0].Subjects = []rbaccontrolleriov1alpha1.Subject{{
+				Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci", Namespaces: []string{"team-a"}, CreateSA: createSA,
+			}}
+			return rule
+		}
+		saKey := types.NamespacedName{Namespace: "team-a", Name: "ci"}
+
+		It("creates the ServiceAccounts the binding asks for , over the subject", func() {
+			r := newFakeReconciler(serviceAccountRule(ptr.To(true), false))
+			Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+			Expect(r.Get(ctx, saKey, &corev1.ServiceAccount{})).To(Succeed())
+		})
+
+		It("doesn't create the ServiceAccounts the binding opts out of , over the subject", func() {
+			r := newFakeReconciler(serviceAccountRule(ptr.To(false), true))
+			Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+			Expect(errors.IsNotFound(r.Get(ctx, saKey, &corev1.ServiceAccount{}))).To(BeTrue())
+			degraded := meta.FindStatusCondition(r.rule("rule").Status.Conditions, rbaccontrolleriov1alpha1.ConditionDegraded)
+			Expect(degraded.Reason).To(Equal(rbaccontrolleriov1alpha1.ReasonServiceAccountNotFound))
+		})
+
+		It("applies the ServiceAccount template to the ServiceAccounts it creates", func() {
+			rule := serviceAccountRule(nil, true)
+			rule.Spec.Bindings[0].Subjects[0].ServiceAccountTemplate = &rbaccontrolleriov1alpha1.ServiceAccountTemplate{
+				Labels:      map[string]string{"team": "a", constants.RBACRuleLabel: "other"},
+				Annotations: map[string]string{"iam.gke.io/gcp-service-account": "ci@project.iam.gserviceaccount.com"},
+			}
+			r := newFakeReconciler(rule)
+			Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+			sa := &corev1.ServiceAccount{}
+			Expect(r.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.Labels).To(HaveKeyWithValue("team", "a"))
+			Expect(sa.Labels).To(HaveKeyWithValue(constants.RBACRuleLabel, "rule"))
+			Expect(sa.Annotations).To(HaveKeyWithValue("iam.gke.io/gcp-service-account", "ci@project.iam.gserviceaccount.com"))
+		})
+
+		It("controls the token automount and the image pull secrets of the ServiceAccounts it creates", func() {
+			rule := serviceAccountRule(nil, true)
+			rule.Spec.Bindings[0].Subjects[0].ServiceAccountTemplate = &rbaccontrolleriov1alpha1.ServiceAccountTemplate{
+				AutomountServiceAccountToken: ptr.To(false),
+				ImagePullSecrets:             []corev1.LocalObjectReference{{Name: "registry"}},
+			}
+			r := newFakeReconciler(rule)
+			Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+			sa := &corev1.ServiceAccount{}
+			Expect(r.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.AutomountServiceAccountToken).To(HaveValue(BeFalse()))
+			Expect(sa.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "registry"}))
+		})
+
+		DescribeTable("follows the namespace policy for the ServiceAccounts of missing namespaces",
+			func(policy rbaccontrolleriov1alpha1.NamespacePolicy, created bool, reason string) {
+				rule := serviceAccountRule(nil, true)
+				rule.Spec.NamespacePolicy = policy
+				rule.Spec.Bindings[0].Subjects[0].Namespaces = []string{"team-b"}
+				r := newFakeReconciler(rule)
+				Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+				ns := &corev1.Namespace{}
+				err := r.Get(ctx, types.NamespacedName{Name: "team-b"}, ns)
+				saErr := r.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "ci"}, &corev1.ServiceAccount{})
+				status := r.rule("rule").Status
+				if created {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ns.Labels).To(HaveKeyWithValue(constants.RBACRuleLabel, "rule"))
+					Expect(saErr).NotTo(HaveOccurred())
+					Expect(status.Bindings[0].ServiceAccounts).To(ConsistOf("team-b/ci"))
+				} else {
+					Expect(errors.IsNotFound(err)).To(BeTrue())
+					Expect(errors.IsNotFound(saErr)).To(BeTrue())
+					Expect(status.Bindings[0].ServiceAccounts).To(BeEmpty())
+				}
+
+				degraded := meta.FindStatusCondition(status.Conditions, rbaccontrolleriov1alpha1.ConditionDegraded)
+				if reason == "" {
+					Expect(degraded == nil || degraded.Status == metav1.ConditionFalse).To(BeTrue())
+					_, err := getRoleBinding(r)
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(degraded).NotTo(BeNil())
+					Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+					Expect(degraded.Reason).To(Equal(reason))
+				}
+			},
+			Entry("creates the namespace by default", rbaccontrolleriov1alpha1.NamespacePolicy(""), true, ""),
+			Entry("creates the namespace with the Create policy", rbaccontrolleriov1alpha1.NamespacePolicyCreate, true, ""),
+			Entry("degrades the rule with the RequireExisting policy", rbaccontrolleriov1alpha1.NamespacePolicyRequireExisting, false, rbaccontrolleriov1alpha1.ReasonNamespaceNotFound),
+			Entry("skips the subject with the Skip policy", rbaccontrolleriov1alpha1.NamespacePolicySkip, false, ""),
+		)
+
+		It("binds existing ServiceAccounts it may not adopt without managing them", func() {
+			r := newFakeReconciler(serviceAccountRule(nil, true), &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ci"}})
+			Expect(r.Reconcile(ctx, req)).Error().NotTo(HaveOccurred())
+
+			sa := &corev1.ServiceAccount{}
+			Expect(r.Get(ctx, saKey, sa)).To(Succeed())
+			Expect(sa.OwnerReferences).To(BeEmpty())
+			Expect(r.events()).To(ContainElement(ContainSubstring(ReasonNotAdopted)))
+			_, err := getRoleBinding(r)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("deleteNamespaces", func() {
+	ctx := context.Background()
+	created := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{constants.RBACRuleLabel: "rule"}}}
+	}
+	ls := labels.SelectorFromSet(map[string]string{constants.RBACRuleLabel: "rule"})
+
+	var r *fakeReconciler
+
+	BeforeEach(func() {
+		r = newFakeReconciler(
+			created("empty"),
+			created("with-pod"),
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "with-pod", Name: "app"}},
+			created("with-pvc"),
+			&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "with-pvc", Name: "data"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		)
+	})
+
+	exists := func(name string) bool {
+		err := r.Get(ctx, types.NamespacedName{Name: name}, &corev1.Namespace{})
+		Expect(client.IgnoreNotFound(err)).To(Succeed())
+		return err == nil
+	}
+
+	It("deletes the empty namespaces the rule created , retaining those holding workloads", func() {
+		Expect(r.deleteNamespaces(ctx, newRule(), ls)).To(Succeed())
+
+		Expect(exists("empty")).To(BeFalse())
+		Expect(exists("with-pod")).To(BeTrue())
+		Expect(exists("with-pvc")).To(BeTrue())
+		Expect(r.events()).To(ConsistOf(
+			ContainSubstring("Namespace with-pod was not deleted"),
+			ContainSubstring("Namespace with-pvc was not deleted"),
+		))
+	})
+
+	It("never deletes the namespaces the rule didn't create", func() {
+		Expect(r.deleteNamespaces(ctx, newRule(), ls)).To(Succeed())
+		Expect(exists("team-b")).To(BeTrue())
+		Expect(exists("team-a")).To(BeTrue())
+	})
+
+	It("retains every namespace with the Retain policy", func() {
+		rule := newRule()
+		rule.Spec.DeletionPolicy = rbaccontrolleriov1alpha1.DeletionPolicyRetain
+		Expect(r.deleteNamespaces(ctx, rule, ls)).To(Succeed())
+		Expect(exists("empty")).To(BeTrue())
+	})
+})
+
+var _ = Describe("checkNamespace", func() {
+	ctx := context.Background()
+	ruleLabel := map[string]string{constants.RBACRuleLabel: "rule"}
+
+	It("creates the missing namespaces from the namespace template , keeping the rule label", func() {
+		r := newFakeReconciler()
+		spec := &rbaccontrolleriov1alpha1.RBACRuleSpec{NamespaceTemplate: &rbaccontrolleriov1alpha1.NamespaceTemplate{
+			Labels:      map[string]string{"pod-security.kubernetes.io/enforce": "restricted", constants.RBACRuleLabel: "other"},
+			Annotations: map[string]string{"scheduler.alpha.kubernetes.io/node-selector": "pool=team-b"},
+		}}
+		Expect(r.checkNamespace(ctx, "team-b", spec, ruleLabel)).To(BeTrue())
+
+		ns := &corev1.Namespace{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "team-b"}, ns)).To(Succeed())
+		Expect(ns.Labels).To(Equal(map[string]string{"pod-security.kubernetes.io/enforce": "restricted", constants.RBACRuleLabel: "rule"}))
+		Expect(ns.Annotations).To(Equal(map[string]string{"scheduler.alpha.kubernetes.io/node-selector": "pool=team-b"}))
+	})
+
+	It("leaves the existing namespaces alone", func() {
+		r := newFakeReconciler()
+		spec := &rbaccontrolleriov1alpha1.RBACRuleSpec{NamespaceTemplate: &rbaccontrolleriov1alpha1.NamespaceTemplate{
+			Labels: map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+		}}
+		Expect(r.checkNamespace(ctx, "team-a", spec, ruleLabel)).To(BeTrue())
+
+		ns := &corev1.Namespace{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "team-a"}, ns)).To(Succeed())
+		Expect(ns.Labels).To(BeEmpty())
+	})
+})
+
+var _ = DescribeTable("rulePhase",
+	func(edit func(*rbaccontrolleriov1alpha1.RBACRule), expected rbaccontrolleriov1alpha1.RBACRulePhase) {
+		r := &RBACRuleReconciler{Config: &config.Config{ExpiringWindow: time.Hour}}
+		rule := newRule()
+		edit(rule)
+		Expect(r.rulePhase(rule, fakeNow)).To(Equal(expected))
+	},
+	Entry("is Active without a schedule",
+		func(*rbaccontrolleriov1alpha1.RBACRule) {}, rbaccontrolleriov1alpha1.RBACRulePhaseActive),
+	Entry("is Pending before the start time",
+		func(rule *rbaccontrolleriov1alpha1.RBACRule) {
+			rule.Spec.StartTime = metav1.NewTime(fakeNow.Add(time.Minute))
+		}, rbaccontrolleriov1alpha1.RBACRulePhasePending),
+	Entry("is Pending while awaiting approvals",
+		func(rule *rbaccontrolleriov1alpha1.RBACRule) {
+			meta.SetStatusCondition(&rule.Status.Conditions, metav1.Condition{Type: rbaccontrolleriov1alpha1.ConditionApproved, Status: metav1.ConditionFalse, Reason: rbaccontrolleriov1alpha1.ReasonAwaitingApproval})
+		}, rbaccontrolleriov1alpha1.RBACRulePhasePending),
+	Entry("is Failed while degraded",
+		func(rule *rbaccontrolleriov1alpha1.RBACRule) {
+			meta.SetStatusCondition(&rule.Status.Conditions, metav1.Condition{Type: rbaccontrolleriov1alpha1.ConditionDegraded, Status: metav1.ConditionTrue, Reason: rbaccontrolleriov1alpha1.ReasonNamespaceNotFound})
+		}, rbaccontrolleriov1alpha1.RBACRulePhaseFailed),
+	Entry("is Expiring within the expiring window",
+		func(rule *rbaccontrolleriov1alpha1.RBACRule) {
+			rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(30 * time.Minute))
+		}, rbaccontrolleriov1alpha1.RBACRulePhaseExpiring),
+	Entry("is Expired from the end time",
+		func(rule *rbaccontrolleriov1alpha1.RBACRule) {
+			rule.Spec.EndTime = metav1.NewTime(fakeNow)
+		}, rbaccontrolleriov1alpha1.RBACRulePhaseExpired),
+	Entry("is Deleting once deleted , whatever its schedule",
+		func(rule *rbaccontrolleriov1alpha1.RBACRule) {
+			rule.DeletionTimestamp = ptr.To(metav1.NewTime(fakeNow))
+			rule.Spec.EndTime = metav1.NewTime(fakeNow)
+		}, rbaccontrolleriov1alpha1.RBACRulePhaseDeleting),
+)
+
+var _ = Describe("generatedMetadata", func() {
+	ruleLabel := map[string]string{constants.RBACRuleLabel: "rule"}
+
+	It("overrides the rule's common metadata with the binding's , keeping the rule label and the stamped annotations", func() {
+		rule := newRule()
+		rule.Annotations = map[string]string{constants.CreatedByAnnotation: "alice"}
+		rule.Spec.Justification = "on-call"
+		rule.Spec.CommonLabels = map[string]string{"team": "a", "tier": "frontend"}
+		rule.Spec.CommonAnnotations = map[string]string{"owner": "team-a", "contact": "team-a@example.com"}
+		b := &rule.Spec.Bindings[0]
+		b.Labels = map[string]string{"tier": "backend", constants.RBACRuleLabel: "other"}
+		b.Annotations = map[string]string{"owner": "sre", constants.JustificationAnnotation: "none"}
+
+		objLabels, objAnnotations := generatedMetadata(rule, b, ruleLabel)
+		Expect(objLabels).To(Equal(map[string]string{"team": "a", "tier": "backend", constants.RBACRuleLabel: "rule"}))
+		Expect(objAnnotations).To(Equal(map[string]string{
+			"owner":                           "sre",
+			"contact":                         "team-a@example.com",
+			constants.CreatedByAnnotation:     "alice",
+			constants.JustificationAnnotation: "on-call",
+		}))
+	})
+
+	It("only sets the rule label without metadata to propagate", func() {
+		rule := newRule()
+		objLabels, objAnnotations := generatedMetadata(rule, &rule.Spec.Bindings[0], ruleLabel)
+		Expect(objLabels).To(Equal(ruleLabel))
+		Expect(objAnnotations).To(BeNil())
+	})
+})
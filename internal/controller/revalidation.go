@@ -0,0 +1,90 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/notifier"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+)
+
+// revalidateRoles scores the roles the rule binds again , and sets its
+// RolesBroadened condition when they grant more than the risk level recorded
+// by the webhook. Broadened roles are announced once , when the condition
+// turns True. Rules without a recorded risk aren't revalidated , and
+// unscored roles don't broaden them.
+func (r *RBACRuleReconciler) revalidateRoles(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule) {
+	_, recorded, found := risk.FromAnnotations(RBACRule)
+	if !found {
+		meta.RemoveStatusCondition(&RBACRule.Status.Conditions, rbaccontrollerv1.ConditionRolesBroadened)
+		return
+	}
+	// the cache only holds the metadata of roles , they are read from the API
+	// server. ClusterRoles can't be read in namespace-restricted mode.
+	scorer := &risk.Scorer{
+		Reader:           r.APIReader,
+		Bundles:          r.Bundles,
+		Templates:        r.RoleTemplates,
+		SkipClusterRoles: r.Config.IsNamespaceRestricted(),
+	}
+	assessment := scorer.Score(ctx, RBACRule, r.now())
+	condition := metav1.Condition{
+		Type:               rbaccontrollerv1.ConditionRolesBroadened,
+		Status:             metav1.ConditionFalse,
+		Reason:             rbaccontrollerv1.ReasonWithinRisk,
+		Message:            fmt.Sprintf("The roles are within the %s risk level", recorded),
+		ObservedGeneration: RBACRule.Generation,
+	}
+	if assessment.Level.AtLeast(recorded) && assessment.Level != recorded {
+		condition.Status, condition.Reason = metav1.ConditionTrue, rbaccontrollerv1.ReasonRiskIncreased
+		condition.Message = fmt.Sprintf("The roles were broadened to a %s risk , the rule was admitted as %s: %s",
+			assessment.Level, recorded, strings.Join(assessment.Factors, ", "))
+		if !meta.IsStatusConditionTrue(RBACRule.Status.Conditions, rbaccontrollerv1.ConditionRolesBroadened) {
+			r.event(RBACRule, corev1.EventTypeWarning, ReasonRolesBroadened, "%s", condition.Message)
+			r.notifyRolesBroadened(ctx, RBACRule, condition.Message)
+		}
+	}
+	meta.SetStatusCondition(&RBACRule.Status.Conditions, condition)
+}
+
+// notifyRolesBroadened sends a notification about the broadened roles of the
+// rule when enabled. Failing to notify never fails the reconciliation.
+func (r *RBACRuleReconciler) notifyRolesBroadened(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, message string) {
+	if r.Notifier == nil || !r.NotifyRolesBroadened {
+		return
+	}
+	e := notifier.Event{
+		Type:         notifier.RolesBroadened,
+		Rule:         RBACRule.Name,
+		Message:      message,
+		Grants:       grants(RBACRule),
+		OwnerContact: RBACRule.Spec.OwnerContact,
+		DocsURL:      RBACRule.Spec.DocsURL,
+	}
+	if err := r.Notifier.Notify(ctx, e); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to send notification", "rule", RBACRule.Name, "type", e.Type)
+	}
+}
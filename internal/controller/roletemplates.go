@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/utils"
+)
+
+// roleTemplates returns the role templates the rule references , sorted.
+func roleTemplates(RBACRule *rbaccontrollerv1.RBACRule) []string {
+	var templates []string
+	for _, b := range RBACRule.Spec.Bindings {
+		for _, crb := range b.ClusterRoleBindings {
+			if crb.RoleTemplate != "" {
+				templates = append(templates, crb.RoleTemplate)
+			}
+		}
+		for _, rb := range b.RoleBindings {
+			if rb.RoleTemplate != "" {
+				templates = append(templates, rb.RoleTemplate)
+			}
+		}
+	}
+	slices.Sort(templates)
+	return slices.Compact(templates)
+}
+
+// templateClusterRoles returns the names of the ClusterRoles generated for the
+// role templates the rule references , by template.
+func templateClusterRoles(RBACRule *rbaccontrollerv1.RBACRule) map[string]string {
+	names := map[string]string{}
+	for _, t := range roleTemplates(RBACRule) {
+		names[t] = utils.GenerateName(RBACRule.UID, RBACRule.Name, "template", "ClusterRole", t)
+	}
+	return names
+}
+
+// applyRoleTemplates generates the ClusterRoles of the role templates the rule
+// references , and returns them by template. Templates that aren't defined ,
+// or all of them when role templates aren't configured , are left out so the
+// bindings referencing them fail to parse.
+func (r *RBACRuleReconciler) applyRoleTemplates(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, RBAClabels map[string]string, ownerRef []metav1.OwnerReference) (map[string]string, error) {
+	generated := map[string]string{}
+	if r.RoleTemplates == nil {
+		return generated, nil
+	}
+	for template, name := range templateClusterRoles(RBACRule) {
+		rules, found, err := r.RoleTemplates.Load(ctx, template)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		cr := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Labels:          RBAClabels,
+				OwnerReferences: ownerRef,
+			},
+			Rules: rules,
+		}
+		if err := r.createOrAdopt(ctx, r.Client, RBACRule, cr, &rbacv1.ClusterRole{}); err != nil {
+			return nil, err
+		}
+		generated[template] = name
+	}
+	return generated, nil
+}
+
+// pruneRoleTemplates deletes the ClusterRoles generated for role templates the
+// rule doesn't reference anymore.
+func (r *RBACRuleReconciler) pruneRoleTemplates(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, generated map[string]string) error {
+	crs, err := r.listMetadata(ctx, rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), client.MatchingLabels{constants.RBACRuleLabel: RBACRule.Name})
+	if err != nil {
+		return err
+	}
+	for _, cr := range crs {
+		if !metav1.IsControlledBy(&cr, RBACRule) || slices.Contains(slices.Collect(maps.Values(generated)), cr.Name) {
+			continue
+		}
+		if err := r.Delete(ctx, &cr); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.forget(&cr)
+		r.event(RBACRule, corev1.EventTypeNormal, ReasonRevoked, "ClusterRole %s isn't generated by the rule anymore , it was deleted", cr.Name)
+	}
+	return nil
+}
+
+// rulesUsingRoleTemplate returns a request for every rule referencing the
+// role template , so its ClusterRoles are generated again.
+func (r *RBACRuleReconciler) rulesUsingRoleTemplate(ctx context.Context, cm client.Object) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		r.Log.Error(err, "Failed to list the rules referencing role templates")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rule := range rules.Items {
+		if slices.Contains(roleTemplates(&rule), cm.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+		}
+	}
+	return requests
+}
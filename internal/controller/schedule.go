@@ -0,0 +1,92 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+// requeueAt reconciles the rule again at the given time , through the
+// scheduler when set so the time survives restarts of the controller and
+// isn't delayed behind a longer requeue. Nothing is scheduled when the time is
+// zero. The rule is still requeued after requeueAfter when it's positive.
+func (r *RBACRuleReconciler) requeueAt(RBACRule *rbaccontrollerv1.RBACRule, at time.Time, requeueAfter time.Duration) ctrl.Result {
+	if r.Scheduler != nil {
+		key := client.ObjectKeyFromObject(RBACRule)
+		if at == (time.Time{}) {
+			r.Scheduler.Forget(key)
+		} else {
+			r.Scheduler.Schedule(key, at)
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}
+	}
+	if at != (time.Time{}) {
+		if period := at.Sub(r.now()); requeueAfter == 0 || period < requeueAfter {
+			requeueAfter = period
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}
+}
+
+// nextTransition returns the next time the rule changes , when it's activated ,
+// enters its expiring window or expires. Rules that expired already are due
+// now , it returns a zero time when the rule doesn't change anymore.
+func (r *RBACRuleReconciler) nextTransition(RBACRule *rbaccontrollerv1.RBACRule, now time.Time) time.Time {
+	start, end := RBACRule.Spec.StartTime.Time, RBACRule.Spec.EndTime.Time
+	if end != (time.Time{}) && !end.After(now) {
+		return now
+	}
+	transitions := []time.Time{start}
+	if end != (time.Time{}) {
+		transitions = append(transitions, end.Add(-r.expiringWindow(RBACRule)), end)
+	}
+	var next time.Time
+	for _, t := range transitions {
+		if t.After(now) && (next == (time.Time{}) || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+// ruleTransitions returns the next transition of every rule , so the
+// scheduler is rebuilt from the cache when the controller starts.
+func (r *RBACRuleReconciler) ruleTransitions(ctx context.Context) (map[types.NamespacedName]time.Time, error) {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		return nil, err
+	}
+	now := r.now()
+	times := map[types.NamespacedName]time.Time{}
+	for i := range rules.Items {
+		rule := &rules.Items[i]
+		if rule.DeletionTimestamp != nil {
+			continue
+		}
+		if next := r.nextTransition(rule, now); next != (time.Time{}) {
+			times[client.ObjectKeyFromObject(rule)] = next
+		}
+	}
+	return times, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+const (
+	// TokenSecretKey is the key of the generated Secret holding the token.
+	TokenSecretKey = "token"
+
+	defaultTokenExpirationSeconds int64 = 3600
+	// the TokenRequest API refuses tokens living less than 10 minutes.
+	minTokenExpirationSeconds int64 = 600
+)
+
+// TokenSecretName returns the name of the Secret holding the token generated
+// for the given ServiceAccount.
+func TokenSecretName(sa string) string {
+	return sa + "-token"
+}
+
+// reconcileToken makes sure the ServiceAccount has a valid token stored in
+// its token Secret. The token is bound to the Secret , so deleting the Secret
+// (e.g when the rule goes away) revokes it. It returns the duration after
+// which the token should be renewed , 0 means it doesn't need renewal. An
+// existing Secret is only taken over when it carries the rule label ,
+// errNotAdopted is returned otherwise.
+func (r *RBACRuleReconciler) reconcileToken(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule, sa parser.ServiceAccount, RBACLabel map[string]string, ownerRef []metav1.OwnerReference) (time.Duration, error) {
+	end := RBACRule.Spec.EndTime.Time
+	lifetime := tokenExpirationSeconds(sa.Subject, end, r.now())
+
+	// Secrets aren't cached , the token Secret is read from the API server.
+	secret := &corev1.Secret{}
+	err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: sa.Namespace, Name: TokenSecretName(sa.Name)}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return 0, err
+	}
+	if err == nil && !metav1.IsControlledBy(secret, RBACRule) {
+		// a Secret the rule didn't create is only taken over when it carries
+		// the rule label , its data would be overwritten by the token.
+		if secret.Labels[constants.RBACRuleLabel] != RBACRule.Name || metav1.GetControllerOf(secret) != nil {
+			return 0, errNotAdopted
+		}
+		for k, v := range RBACLabel {
+			secret.Labels[k] = v
+		}
+		secret.OwnerReferences = append(secret.OwnerReferences, ownerRef...)
+		if err := r.Update(ctx, secret); err != nil {
+			return 0, err
+		}
+	}
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            TokenSecretName(sa.Name),
+				Namespace:       sa.Namespace,
+				Labels:          RBACLabel,
+				OwnerReferences: ownerRef,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return 0, err
+		}
+	} else if exp, err := time.Parse(time.RFC3339, secret.Annotations[constants.TokenExpirationAnnotation]); err == nil {
+		if tokenCoversGrant(exp, end) {
+			return 0, nil
+		}
+		if renew := tokenRenewal(exp, lifetime, r.now()); renew > 0 {
+			return renew, nil
+		}
+	}
+
+	tr := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &lifetime,
+			BoundObjectRef: &authenticationv1.BoundObjectReference{
+				Kind:       "Secret",
+				APIVersion: "v1",
+				Name:       secret.Name,
+				UID:        secret.UID,
+			},
+		},
+	}
+	account := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		},
+	}
+	if err := r.SubResource("token").Create(ctx, account, tr); err != nil {
+		return 0, err
+	}
+
+	exp := tr.Status.ExpirationTimestamp.Time
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constants.TokenExpirationAnnotation] = exp.UTC().Format(time.RFC3339)
+	secret.Data = map[string][]byte{
+		TokenSecretKey: []byte(tr.Status.Token),
+		"namespace":    []byte(sa.Namespace),
+	}
+	if err := r.Update(ctx, secret); err != nil {
+		return 0, err
+	}
+
+	if tokenCoversGrant(exp, end) {
+		return 0, nil
+	}
+	return tokenRenewal(exp, lifetime, r.now()), nil
+}
+
+// tokenExpirationSeconds returns the lifetime of a token requested at now.
+// When the rule has an EndTime the token is clamped to it , the TokenRequest
+// API minimum still applies during the last minutes of the grant.
+func tokenExpirationSeconds(s *rbaccontrollerv1.Subject, end, now time.Time) int64 {
+	lifetime := defaultTokenExpirationSeconds
+	if s.TokenExpirationSeconds != nil {
+		lifetime = *s.TokenExpirationSeconds
+	}
+	if end != (time.Time{}) {
+		lifetime = min(lifetime, int64(end.Sub(now).Seconds()))
+	}
+	return max(lifetime, minTokenExpirationSeconds)
+}
+
+// tokenCoversGrant reports whether a token expiring at exp lives as long as
+// the grant itself , in which case it never needs to be renewed. The API
+// server works with whole seconds , hence the tolerance.
+func tokenCoversGrant(exp, end time.Time) bool {
+	return end != (time.Time{}) && exp.Add(time.Minute).After(end)
+}
+
+// tokenRenewal returns how long to wait , from now , before renewing a token
+// expiring at exp , tokens are renewed once 80% of their lifetime went by.
+func tokenRenewal(exp time.Time, lifetime int64, now time.Time) time.Duration {
+	return exp.Add(-time.Duration(lifetime) * time.Second / 5).Sub(now)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rbaccontrolleriov1alpha1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+var _ = DescribeTable("tokenExpirationSeconds",
+	func(requested *int64, end time.Time, expected int64) {
+		s := &rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci", TokenExpirationSeconds: requested}
+		Expect(tokenExpirationSeconds(s, end, fakeNow)).To(Equal(expected))
+	},
+	Entry("defaults to an hour", nil, time.Time{}, int64(3600)),
+	Entry("follows the requested lifetime", ptr.To[int64](7200), time.Time{}, int64(7200)),
+	Entry("keeps the requested lifetime ending before the grant", ptr.To[int64](1800), fakeNow.Add(24*time.Hour), int64(1800)),
+	Entry("keeps the default lifetime ending before the grant", nil, fakeNow.Add(24*time.Hour), int64(3600)),
+	Entry("clamps the lifetime to the end of the grant", ptr.To[int64](7200), fakeNow.Add(time.Hour), int64(3600)),
+	Entry("never goes below the TokenRequest API minimum", ptr.To[int64](7200), fakeNow.Add(time.Minute), int64(600)),
+)
+
+var _ = DescribeTable("tokenRenewal",
+	func(exp time.Time, lifetime int64, expected time.Duration) {
+		Expect(tokenRenewal(exp, lifetime, fakeNow)).To(Equal(expected))
+	},
+	Entry("renews fresh tokens once 80% of their lifetime went by", fakeNow.Add(time.Hour), int64(3600), 48*time.Minute),
+	Entry("accounts for the time already gone by", fakeNow.Add(30*time.Minute), int64(3600), 18*time.Minute),
+	Entry("renews tokens past 80% of their lifetime right away", fakeNow.Add(5*time.Minute), int64(3600), -7*time.Minute),
+)
+
+var _ = Describe("reconcileToken", func() {
+	var (
+		ctx       = context.Background()
+		secretKey = types.NamespacedName{Namespace: "team-a", Name: TokenSecretName("ci")}
+		rule      *rbaccontrolleriov1alpha1.RBACRule
+		sa        parser.ServiceAccount
+		ruleLabel = map[string]string{constants.RBACRuleLabel: "rule"}
+	)
+
+	BeforeEach(func() {
+		rule = newRule()
+		rule.Spec.EndTime = metav1.NewTime(fakeNow.Add(24 * time.Hour))
+		sa = parser.ServiceAccount{
+			Name:      "ci",
+			Namespace: "team-a",
+			Subject:   &rbaccontrolleriov1alpha1.Subject{Kind: rbaccontrolleriov1alpha1.ServiceAccount, Name: "ci", GenerateToken: true},
+		}
+	})
+
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ci"}}
+	ownerRef := func() []metav1.OwnerReference {
+		return []metav1.OwnerReference{*metav1.NewControllerRef(rule, rbaccontrolleriov1alpha1.GroupVersion.WithKind("RBACRule"))}
+	}
+	existingSecret := func(labels map[string]string, owners ...metav1.OwnerReference) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretKey.Namespace, Name: secretKey.Name, Labels: labels, OwnerReferences: owners},
+			Data:       map[string][]byte{"password": []byte("secret")},
+		}
+	}
+	getSecret := func(r *fakeReconciler) *corev1.Secret {
+		secret := &corev1.Secret{}
+		Expect(r.Get(ctx, secretKey, secret)).To(Succeed())
+		return secret
+	}
+
+	It("stores a token in a new Secret", func() {
+		r := newFakeReconciler(serviceAccount.DeepCopy())
+		Expect(r.reconcileToken(ctx, rule, sa, ruleLabel, ownerRef())).To(BeZero())
+
+		secret := getSecret(r)
+		Expect(secret.Data).To(HaveKeyWithValue(TokenSecretKey, []byte("fake-token")))
+		Expect(secret.Annotations).To(HaveKey(constants.TokenExpirationAnnotation))
+		Expect(metav1.IsControlledBy(secret, rule)).To(BeTrue())
+	})
+
+	It("takes over an existing Secret carrying the rule label", func() {
+		r := newFakeReconciler(serviceAccount.DeepCopy(), existingSecret(ruleLabel))
+		Expect(r.reconcileToken(ctx, rule, sa, ruleLabel, ownerRef())).To(BeZero())
+
+		secret := getSecret(r)
+		Expect(metav1.IsControlledBy(secret, rule)).To(BeTrue())
+		Expect(secret.Data).To(HaveKeyWithValue(TokenSecretKey, []byte("fake-token")))
+	})
+
+	DescribeTable("leaves the Secrets it may not adopt alone",
+		func(secret *corev1.Secret) {
+			r := newFakeReconciler(serviceAccount.DeepCopy(), secret)
+			Expect(r.reconcileToken(ctx, rule, sa, ruleLabel, ownerRef())).Error().To(MatchError(errNotAdopted))
+
+			Expect(getSecret(r).Data).To(Equal(map[string][]byte{"password": []byte("secret")}))
+		},
+		Entry("without the rule label", existingSecret(nil)),
+		Entry("labelled for another rule", existingSecret(map[string]string{constants.RBACRuleLabel: "other"})),
+		Entry("controlled by another object", existingSecret(ruleLabel, metav1.OwnerReference{
+			APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "owner-uid", Controller: ptr.To(true),
+		})),
+	)
+
+	It("doesn't request a token while the stored one covers the grant", func() {
+		secret := existingSecret(ruleLabel, ownerRef()...)
+		secret.Annotations = map[string]string{constants.TokenExpirationAnnotation: fakeNow.Add(25 * time.Hour).Format(time.RFC3339)}
+		r := newFakeReconciler(serviceAccount.DeepCopy(), secret)
+		Expect(r.reconcileToken(ctx, rule, sa, ruleLabel, ownerRef())).To(BeZero())
+
+		Expect(getSecret(r).Data).NotTo(HaveKey(TokenSecretKey))
+	})
+})
+
+var _ = Describe("deleteTokenSecrets", func() {
+	It("deletes the token Secrets of the rule , listing them from the API server", func() {
+		ctx := context.Background()
+		ruleLabel := map[string]string{constants.RBACRuleLabel: "rule"}
+		r := newFakeReconciler(
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ci-token", Labels: ruleLabel}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "other"}},
+		)
+		// listing Secrets through the cache would start an informer on every
+		// Secret of the cluster.
+		r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if list.GetObjectKind().GroupVersionKind().Kind == "SecretList" {
+					return errors.New("Secrets are listed through the cache")
+				}
+				return c.List(ctx, list, opts...)
+			},
+		})
+		Expect(r.deleteTokenSecrets(ctx, labels.SelectorFromSet(ruleLabel))).To(Succeed())
+
+		Expect(apierrors.IsNotFound(r.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "ci-token"}, &corev1.Secret{}))).To(BeTrue())
+		Expect(r.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: "other"}, &corev1.Secret{})).To(Succeed())
+	})
+})
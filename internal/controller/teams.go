@@ -0,0 +1,120 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+	"github.com/GGh41th/rbac-controller/internal/teams"
+)
+
+// teamScope is what the rules of a team may grant during a reconciliation.
+type teamScope struct {
+	team    string
+	catalog teams.Catalog
+	// whether the namespaces already looked up belong to the team.
+	owned map[string]bool
+}
+
+// loadTeamScope returns the scope of the rule's team , nil when the rule
+// doesn't belong to a team or teams aren't configured.
+func (r *RBACRuleReconciler) loadTeamScope(ctx context.Context, RBACRule *rbaccontrollerv1.RBACRule) (*teamScope, error) {
+	team, found := RBACRule.Labels[constants.TeamLabel]
+	if r.Teams == nil || !found {
+		return nil, nil
+	}
+	catalog, err := r.Teams.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &teamScope{team: team, catalog: catalog, owned: map[string]bool{}}, nil
+}
+
+// scopeToTeam removes from the resources rendered for the binding the ones
+// granting access outside of the team: ServiceAccounts and RoleBindings in
+// namespaces it doesn't own , bindings to ClusterRoles outside of its
+// allowlist and ClusterRoleBindings. It returns the removed resources.
+func (r *RBACRuleReconciler) scopeToTeam(ctx context.Context, scope *teamScope, p *parser.Parser) ([]string, error) {
+	var removed []string
+	var err error
+	p.ServiceAccounts = slices.DeleteFunc(p.ServiceAccounts, func(sa parser.ServiceAccount) bool {
+		owned, getErr := scope.owns(ctx, r.Client, sa.Namespace)
+		if getErr != nil {
+			err = getErr
+		}
+		if !owned {
+			removed = append(removed, fmt.Sprintf("ServiceAccount %s/%s", sa.Namespace, sa.Name))
+		}
+		return !owned
+	})
+	p.RoleBindings = slices.DeleteFunc(p.RoleBindings, func(rb rbacv1.RoleBinding) bool {
+		owned, getErr := scope.owns(ctx, r.Client, rb.Namespace)
+		if getErr != nil {
+			err = getErr
+		}
+		allowed := rb.RoleRef.Kind != parser.CRB || scope.catalog.AllowsClusterRole(scope.team, rb.RoleRef.Name)
+		if !owned || !allowed {
+			removed = append(removed, fmt.Sprintf("RoleBinding %s/%s", rb.Namespace, rb.Name))
+		}
+		return !owned || !allowed
+	})
+	for _, crb := range p.ClusterRoleBindings {
+		removed = append(removed, "ClusterRoleBinding "+crb.Name)
+	}
+	p.ClusterRoleBindings = nil
+	return removed, err
+}
+
+// owns returns whether the team owns the namespace , missing namespaces
+// don't belong to any team.
+func (s *teamScope) owns(ctx context.Context, c client.Reader, ns string) (bool, error) {
+	if owned, ok := s.owned[ns]; ok {
+		return owned, nil
+	}
+	meta := &metav1.PartialObjectMetadata{}
+	meta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	if err := c.Get(ctx, client.ObjectKey{Name: ns}, meta); client.IgnoreNotFound(err) != nil {
+		return false, err
+	}
+	s.owned[ns] = teams.Owns(s.team, meta.Labels)
+	return s.owned[ns], nil
+}
+
+// rulesOfTeams returns a request for every rule belonging to a team.
+func (r *RBACRuleReconciler) rulesOfTeams(ctx context.Context, _ client.Object) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules, client.HasLabels{constants.TeamLabel}); err != nil {
+		r.Log.Error(err, "Failed to list the rules of teams")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(rules.Items))
+	for _, rule := range rules.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+	}
+	return requests
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// grantRetryPeriod is how long after a denied access review it is run again ,
+// to tell a grant that isn't effective from one that isn't effective yet.
+const grantRetryPeriod = 5 * time.Second
+
+// grant is an access granted by a binding.
+type grant struct {
+	namespace string
+	subject   rbacv1.Subject
+	rule      authorizationv1.ResourceAttributes
+}
+
+// verifyGrants runs a SubjectAccessReview for up to GrantSamples accesses
+// granted by each binding , taken from the rules of the bound role and
+// spread over the binding's subjects.
+func (r *RBACRuleReconciler) verifyGrants(ctx context.Context, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) ([]rbaccontrollerv1.GrantVerification, error) {
+	var grants []grant
+	for _, crb := range crbs {
+		g, err := r.sampleGrants(ctx, crb.RoleRef, "", crb.Subjects)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, g...)
+	}
+	for _, rb := range rbs {
+		g, err := r.sampleGrants(ctx, rb.RoleRef, rb.Namespace, rb.Subjects)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, g...)
+	}
+
+	var results []rbaccontrollerv1.GrantVerification
+	for _, g := range grants {
+		attrs := g.rule
+		attrs.Namespace = g.namespace
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{ResourceAttributes: &attrs},
+		}
+		subject := g.subject.Kind + ":" + g.subject.Name
+		switch g.subject.Kind {
+		case rbacv1.UserKind:
+			review.Spec.User = g.subject.Name
+		case rbacv1.GroupKind:
+			review.Spec.Groups = []string{g.subject.Name}
+		case rbacv1.ServiceAccountKind:
+			subject = g.subject.Kind + ":" + g.subject.Namespace + "/" + g.subject.Name
+			review.Spec.User = fmt.Sprintf("system:serviceaccount:%s:%s", g.subject.Namespace, g.subject.Name)
+			review.Spec.Groups = []string{"system:serviceaccounts", "system:serviceaccounts:" + g.subject.Namespace}
+		}
+		if err := r.Create(ctx, review); err != nil {
+			return nil, err
+		}
+		reason := review.Status.Reason
+		if review.Status.EvaluationError != "" {
+			reason = review.Status.EvaluationError
+		}
+		results = append(results, rbaccontrollerv1.GrantVerification{
+			Subject:   subject,
+			Verb:      attrs.Verb,
+			Group:     attrs.Group,
+			Resource:  attrs.Resource,
+			Namespace: attrs.Namespace,
+			Allowed:   review.Status.Allowed,
+			Reason:    reason,
+		})
+	}
+	return results, nil
+}
+
+// sampleGrants returns up to GrantSamples accesses granted by the role , one
+// per rule of the role , paired with the subjects in turn. Non resource
+// rules are skipped. A missing role grants nothing.
+func (r *RBACRuleReconciler) sampleGrants(ctx context.Context, ref rbacv1.RoleRef, namespace string, subjects []rbacv1.Subject) ([]grant, error) {
+	if len(subjects) == 0 {
+		return nil, nil
+	}
+	var rules []rbacv1.PolicyRule
+	if ref.Kind == parser.RB {
+		role := &rbacv1.Role{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, role); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		rules = role.Rules
+	} else {
+		role := &rbacv1.ClusterRole{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name}, role); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		rules = role.Rules
+	}
+
+	var grants []grant
+	for _, rule := range rules {
+		if len(grants) == r.GrantSamples {
+			break
+		}
+		if len(rule.Verbs) == 0 || len(rule.Resources) == 0 {
+			continue
+		}
+		attrs := authorizationv1.ResourceAttributes{Verb: rule.Verbs[0], Resource: rule.Resources[0]}
+		if len(rule.APIGroups) > 0 {
+			attrs.Group = rule.APIGroups[0]
+		}
+		if len(rule.ResourceNames) > 0 {
+			attrs.Name = rule.ResourceNames[0]
+		}
+		grants = append(grants, grant{
+			namespace: namespace,
+			subject:   subjects[len(grants)%len(subjects)],
+			rule:      attrs,
+		})
+	}
+	return grants, nil
+}
+
+// notEffective returns the accesses denied by the current and the previous
+// verification. The API server authorizes from a cache , so accesses only
+// denied by the current one may not be effective yet , they are pending.
+func notEffective(results, prev []rbaccontrollerv1.GrantVerification) ([]string, bool) {
+	var denied []string
+	pending := false
+	for _, v := range results {
+		if v.Allowed {
+			continue
+		}
+		if !slices.ContainsFunc(prev, func(p rbaccontrollerv1.GrantVerification) bool {
+			return !p.Allowed && p.Subject == v.Subject && p.Verb == v.Verb && p.Group == v.Group &&
+				p.Resource == v.Resource && p.Namespace == v.Namespace
+		}) {
+			pending = true
+			continue
+		}
+		denied = append(denied, fmt.Sprintf("%s %s %s", v.Subject, v.Verb, v.Resource))
+	}
+	return denied, pending
+}
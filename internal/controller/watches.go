@@ -0,0 +1,298 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"path"
+	"slices"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// rulesSelectingNamespace returns a request for every rule whose namespace
+// selectors , or exclusion selectors , match the namespace. Namespace updates
+// are mapped from both the old and the new object , so rules are reconciled
+// when a namespace stops matching too.
+func (r *RBACRuleReconciler) rulesSelectingNamespace(ctx context.Context, ns client.Object) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		r.Log.Error(err, "Failed to list the rules selecting namespaces")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rule := range rules.Items {
+		if selectsNamespace(&rule, ns.GetName(), labels.Set(ns.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+		}
+	}
+	return requests
+}
+
+func selectsNamespace(RBACRule *rbaccontrollerv1.RBACRule, name string, nsLabels labels.Set) bool {
+	for _, b := range RBACRule.Spec.Bindings {
+		for _, s := range b.Subjects {
+			if matches(&s.NameSpaceSelector, nsLabels) || matches(&s.ExcludeNamespaceSelector, nsLabels) ||
+				matchesExpression(s.NamespaceMatchExpression, name) {
+				return true
+			}
+		}
+		for _, rb := range b.RoleBindings {
+			if matches(&rb.NameSpaceSelector, nsLabels) || matches(&rb.ExcludeNamespaceSelector, nsLabels) ||
+				matchesExpression(rb.NamespaceMatchExpression, name) {
+				return true
+			}
+		}
+		for _, crb := range b.ClusterRoleBindings {
+			if crb.NameSpaceSelector != nil && matches(crb.NameSpaceSelector, nsLabels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesSelectingServiceAccount returns a request for every rule with a
+// serviceAccountSelector or a name pattern matching the ServiceAccount , or
+// enumerating the ServiceAccounts of namespaces. Like namespaces , updates
+// are mapped from both the old and the new object.
+func (r *RBACRuleReconciler) rulesSelectingServiceAccount(ctx context.Context, sa client.Object) []reconcile.Request {
+	return r.rulesReferencing(ctx, func(RBACRule *rbaccontrollerv1.RBACRule) bool {
+		return selectsServiceAccount(RBACRule, sa.GetName(), labels.Set(sa.GetLabels()))
+	})
+}
+
+func selectsServiceAccount(RBACRule *rbaccontrollerv1.RBACRule, name string, saLabels labels.Set) bool {
+	for _, b := range RBACRule.Spec.Bindings {
+		for _, s := range b.Subjects {
+			// the namespaces of the subject aren't looked up , they are
+			// resolved by the reconciliation.
+			if s.Kind == rbaccontrollerv1.AllServiceAccounts && s.Rendering == rbaccontrollerv1.SubjectRenderingEnumerate {
+				return true
+			}
+			if s.Kind == rbaccontrollerv1.ServiceAccount && slices.ContainsFunc(parser.SubjectNames(&s), func(pattern string) bool {
+				return parser.IsGlob(pattern) && matchesGlob(pattern, name)
+			}) {
+				return true
+			}
+			if s.ServiceAccountSelector == nil {
+				continue
+			}
+			// unlike namespace selectors , an empty selector matches every
+			// ServiceAccount.
+			selector, err := metav1.LabelSelectorAsSelector(s.ServiceAccountSelector)
+			if err == nil && selector.Matches(saLabels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches reports whether the selector matches the labels , empty selectors
+// don't select any namespace.
+func matches(ls *metav1.LabelSelector, set labels.Set) bool {
+	if len(ls.MatchLabels) == 0 && len(ls.MatchExpressions) == 0 {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(set)
+}
+
+// matchesExpression reports whether the namespaceMatchExpression matches the
+// name of the namespace , invalid expressions don't match any.
+func matchesExpression(expr, name string) bool {
+	if expr == "" {
+		return false
+	}
+	re, err := parser.CompileMatchExpression(expr)
+	return err == nil && re.MatchString(name)
+}
+
+// matchesGlob reports whether the name pattern of a ServiceAccount subject
+// matches the name , invalid patterns don't match any.
+func matchesGlob(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// rulesReferencingClusterRole returns a request for every rule binding the
+// ClusterRole , directly or through a role bundle.
+func (r *RBACRuleReconciler) rulesReferencingClusterRole(ctx context.Context, role client.Object) []reconcile.Request {
+	catalog := bundles.Catalog{}
+	if r.Bundles != nil {
+		var err error
+		if catalog, err = r.Bundles.Load(ctx); err != nil {
+			r.Log.Error(err, "Failed to load the role bundles")
+		}
+	}
+	// the ClusterRoles generated from role templates are labeled with their
+	// rule.
+	generatedFor := role.GetLabels()[constants.RBACRuleLabel]
+	return r.rulesReferencing(ctx, func(RBACRule *rbaccontrollerv1.RBACRule) bool {
+		return RBACRule.Name == generatedFor || referencesRole(RBACRule, parser.CRB, role.GetName(), catalog)
+	})
+}
+
+// rulesReferencingRole returns a request for every rule binding a Role with
+// the same name , whatever its namespace.
+func (r *RBACRuleReconciler) rulesReferencingRole(ctx context.Context, role client.Object) []reconcile.Request {
+	return r.rulesReferencing(ctx, func(RBACRule *rbaccontrollerv1.RBACRule) bool {
+		return referencesRole(RBACRule, parser.RB, role.GetName(), nil)
+	})
+}
+
+func (r *RBACRuleReconciler) rulesReferencing(ctx context.Context, references func(*rbaccontrollerv1.RBACRule) bool) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		r.Log.Error(err, "Failed to list the rules referencing roles")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, rule := range rules.Items {
+		if references(&rule) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+		}
+	}
+	return requests
+}
+
+// referencesRole reports whether a binding of the rule references the Role or
+// ClusterRole , depending on kind.
+func referencesRole(RBACRule *rbaccontrollerv1.RBACRule, kind, name string, catalog bundles.Catalog) bool {
+	for _, b := range RBACRule.Spec.Bindings {
+		for _, rb := range b.RoleBindings {
+			if kind == parser.RB && rb.Role == name {
+				return true
+			}
+			if kind == parser.CRB && (rb.ClusterRole == name || slices.Contains(catalog[rb.Bundle], name)) {
+				return true
+			}
+		}
+		if kind != parser.CRB {
+			continue
+		}
+		for _, crb := range b.ClusterRoleBindings {
+			if crb.ClusterRole == name || slices.Contains(catalog[crb.Bundle], name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesOfTeam returns a request for every other rule of the team of the rule ,
+// so the rules beyond the team's limit are applied once a rule is deleted.
+func (r *RBACRuleReconciler) rulesOfTeam(ctx context.Context, rule client.Object) []reconcile.Request {
+	team, found := rule.GetLabels()[constants.TeamLabel]
+	if !found {
+		return nil
+	}
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules, client.MatchingLabels{constants.TeamLabel: team}); err != nil {
+		r.Log.Error(err, "Failed to list the rules of the team", "team", team)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, other := range rules.Items {
+		if other.Name != rule.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&other)})
+		}
+	}
+	return requests
+}
+
+// allRules returns a request for every rule , e.g so the rules are rebalanced
+// once the replicas sharing them changed.
+func (r *RBACRuleReconciler) allRules(ctx context.Context, _ client.Object) []reconcile.Request {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := r.List(ctx, rules); err != nil {
+		r.Log.Error(err, "Failed to list the rules")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(rules.Items))
+	for _, rule := range rules.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rule)})
+	}
+	return requests
+}
+
+// onlyDelete only lets through the deletion of objects.
+var onlyDelete = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// createOrDelete only lets through the creation and deletion of objects.
+var createOrDelete = predicate.Funcs{
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// checkRole appends the role a binding in the namespace refers to , to the
+// missing roles when it doesn't exist.
+func (r *RBACRuleReconciler) checkRole(ctx context.Context, ref rbacv1.RoleRef, namespace string, missing []string) ([]string, error) {
+	found, err := r.roleExists(ctx, ref, namespace)
+	if err != nil || found {
+		return missing, err
+	}
+	role := ref.Kind + " " + ref.Name
+	if ref.Kind == parser.RB {
+		role = ref.Kind + " " + namespace + "/" + ref.Name
+	}
+	if slices.Contains(missing, role) {
+		return missing, nil
+	}
+	return append(missing, role), nil
+}
+
+// roleExists reports whether the role a binding in the namespace refers to
+// exists , namespace being empty for ClusterRoleBindings.
+func (r *RBACRuleReconciler) roleExists(ctx context.Context, ref rbacv1.RoleRef, namespace string) (bool, error) {
+	role := &metav1.PartialObjectMetadata{}
+	role.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind(ref.Kind))
+	key := client.ObjectKey{Name: ref.Name}
+	if ref.Kind == parser.RB {
+		key.Namespace = namespace
+	} else if r.Config.IsNamespaceRestricted() {
+		// ClusterRoles can't be read without cluster wide permissions.
+		return true, nil
+	}
+	if err := r.Get(ctx, key, role); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rbaccontrolleriov1alpha1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/config"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/roletemplates"
+	"github.com/GGh41th/rbac-controller/internal/teams"
+)
+
+// recordingCache records the kinds the controller watches , and whether it
+// only watches their metadata.
+type recordingCache struct {
+	informertest.FakeInformers
+	scheme *runtime.Scheme
+
+	mu sync.Mutex
+	// the kinds whose metadata , or whole objects , are watched.
+	metadata, objects map[string]bool
+}
+
+func (c *recordingCache) GetInformer(_ context.Context, obj client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		c.metadata[gvk.Kind] = true
+	} else {
+		c.objects[gvk.Kind] = true
+	}
+	return &controllertest.FakeInformer{Synced: true}, nil
+}
+
+// watched returns the kinds whose metadata , and whole objects , are watched.
+func (c *recordingCache) watched() (metadata, objects []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for kind := range c.metadata {
+		metadata = append(metadata, kind)
+	}
+	for kind := range c.objects {
+		objects = append(objects, kind)
+	}
+	return metadata, objects
+}
+
+// startWatches starts the controller of the reconciler , against a cache
+// recording what it watches , until the spec ends.
+func startWatches(r *RBACRuleReconciler) *recordingCache {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(rbaccontrolleriov1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	c := &recordingCache{scheme: scheme, metadata: map[string]bool{}, objects: map[string]bool{}}
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "https://127.0.0.1:1"}, ctrl.Options{
+		Scheme:     scheme,
+		NewCache:   func(*rest.Config, cache.Options) (cache.Cache, error) { return c, nil },
+		Metrics:    metricsserver.Options{BindAddress: "0"},
+		Controller: ctrlconfig.Controller{SkipNameValidation: ptr.To(true)},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	r.Log = GinkgoLogr
+	r.Recorder = record.NewFakeRecorder(100)
+	if r.Config == nil {
+		r.Config = &config.Config{}
+	}
+	Expect(r.SetupWithManager(mgr)).To(Succeed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	DeferCleanup(cancel)
+	go func() {
+		defer GinkgoRecover()
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+	Eventually(func() []string {
+		_, objects := c.watched()
+		return objects
+	}).Should(ContainElement("RBACRule"))
+	return c
+}
+
+var _ = Describe("SetupWithManager", func() {
+	It("only watches the metadata of the objects it generates and references", func() {
+		c := startWatches(&RBACRuleReconciler{})
+
+		metadata, objects := c.watched()
+		Expect(metadata).To(ContainElements("ServiceAccount", "RoleBinding", "ClusterRoleBinding", "Namespace", "Role", "ClusterRole"))
+		Expect(objects).To(ConsistOf("RBACRule"))
+	})
+
+	It("only watches the metadata of the role bundles ConfigMap", func() {
+		c := startWatches(&RBACRuleReconciler{
+			Bundles: &bundles.ConfigMapCatalog{ConfigMap: types.NamespacedName{Namespace: "rbac-controller-system", Name: "role-bundles"}},
+		})
+
+		metadata, objects := c.watched()
+		Expect(metadata).To(ContainElement("ConfigMap"))
+		Expect(objects).NotTo(ContainElement("ConfigMap"))
+	})
+
+	It("only watches the metadata of the role templates ConfigMaps", func() {
+		c := startWatches(&RBACRuleReconciler{
+			RoleTemplates: &roletemplates.Source{Namespace: "rbac-controller-system"},
+		})
+
+		metadata, objects := c.watched()
+		Expect(metadata).To(ContainElement("ConfigMap"))
+		Expect(objects).NotTo(ContainElement("ConfigMap"))
+	})
+
+	It("only watches the metadata of the teams ConfigMap", func() {
+		c := startWatches(&RBACRuleReconciler{
+			Teams: &teams.ConfigMapCatalog{ConfigMap: types.NamespacedName{Namespace: "rbac-controller-system", Name: "teams"}},
+		})
+
+		metadata, objects := c.watched()
+		Expect(metadata).To(ContainElement("ConfigMap"))
+		Expect(objects).NotTo(ContainElement("ConfigMap"))
+	})
+})
+
+// requestsFor returns the requests reconciling the rules.
+func requestsFor(names ...string) []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(names))
+	for _, name := range names {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+	}
+	return requests
+}
+
+var _ = Describe("rulesSelectingNamespace", func() {
+	ctx := context.Background()
+	selecting := func(name string, edit func(*rbaccontrolleriov1alpha1.Subject)) *rbaccontrolleriov1alpha1.RBACRule {
+		rule := newRule()
+		rule.Name = name
+		edit(&rule.Spec.Bindings[0].Subjects[0])
+		return rule
+	}
+
+	var r *fakeReconciler
+
+	BeforeEach(func() {
+		r = newFakeReconciler(
+			selecting("by-label", func(s *rbaccontrolleriov1alpha1.Subject) {
+				s.NameSpaceSelector = metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}
+			}),
+			selecting("by-exclusion", func(s *rbaccontrolleriov1alpha1.Subject) {
+				s.ExcludeNamespaceSelector = metav1.LabelSelector{MatchLabels: map[string]string{"tier": "system"}}
+			}),
+			selecting("by-expression", func(s *rbaccontrolleriov1alpha1.Subject) {
+				s.NamespaceMatchExpression = "team-.*"
+			}),
+			newRule(),
+		)
+	})
+
+	It("maps namespaces to the rules whose selectors or expressions match them", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}}
+		Expect(r.rulesSelectingNamespace(ctx, ns)).To(ConsistOf(requestsFor("by-label", "by-expression")))
+	})
+
+	It("maps namespaces to the rules whose exclusion selectors match them", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "infra", Labels: map[string]string{"tier": "system"}}}
+		Expect(r.rulesSelectingNamespace(ctx, ns)).To(ConsistOf(requestsFor("by-exclusion")))
+	})
+
+	It("doesn't map namespaces to the rules selecting none", func() {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+		Expect(r.rulesSelectingNamespace(ctx, ns)).To(BeEmpty())
+	})
+})
+
+var _ = Describe("rulesReferencingRole and rulesReferencingClusterRole", func() {
+	ctx := context.Background()
+	bundlesKey := types.NamespacedName{Namespace: "rbac-controller-system", Name: "role-bundles"}
+	binding := func(name string, edit func(*rbaccontrolleriov1alpha1.Binding)) *rbaccontrolleriov1alpha1.RBACRule {
+		rule := newRule()
+		rule.Name = name
+		edit(&rule.Spec.Bindings[0])
+		return rule
+	}
+
+	var r *fakeReconciler
+
+	BeforeEach(func() {
+		r = newFakeReconciler(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: bundlesKey.Namespace, Name: bundlesKey.Name},
+				Data:       map[string]string{"ops": "view,cluster-admin"},
+			},
+			newRule(),
+			binding("by-bundle", func(b *rbaccontrolleriov1alpha1.Binding) {
+				b.RoleBindings = []rbaccontrolleriov1alpha1.RoleBinding{{Bundle: "ops", Namespaces: []string{"team-a"}}}
+			}),
+			binding("cluster-wide", func(b *rbaccontrolleriov1alpha1.Binding) {
+				b.RoleBindings = nil
+				b.ClusterRoleBindings = []rbaccontrolleriov1alpha1.ClusterRoleBinding{{ClusterRole: "edit"}}
+			}),
+			binding("by-role", func(b *rbaccontrolleriov1alpha1.Binding) {
+				b.RoleBindings = []rbaccontrolleriov1alpha1.RoleBinding{{Role: "deployer", Namespaces: []string{"team-a"}}}
+			}),
+		)
+		r.Bundles = &bundles.ConfigMapCatalog{Reader: r.Client, ConfigMap: bundlesKey}
+	})
+
+	clusterRole := func(name string, labels map[string]string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	It("maps ClusterRoles to the rules binding them , directly or through bundles", func() {
+		Expect(r.rulesReferencingClusterRole(ctx, clusterRole("view", nil))).To(ConsistOf(requestsFor("rule", "by-bundle")))
+		Expect(r.rulesReferencingClusterRole(ctx, clusterRole("cluster-admin", nil))).To(ConsistOf(requestsFor("by-bundle")))
+		Expect(r.rulesReferencingClusterRole(ctx, clusterRole("edit", nil))).To(ConsistOf(requestsFor("cluster-wide")))
+	})
+
+	It("maps the ClusterRoles generated for a rule to it", func() {
+		generated := clusterRole("generated", map[string]string{constants.RBACRuleLabel: "by-role"})
+		Expect(r.rulesReferencingClusterRole(ctx, generated)).To(ConsistOf(requestsFor("by-role")))
+	})
+
+	It("maps Roles to the rules binding their name , whatever their namespace", func() {
+		role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: "team-b", Name: "deployer"}}
+		Expect(r.rulesReferencingRole(ctx, role)).To(ConsistOf(requestsFor("by-role")))
+		Expect(r.rulesReferencingClusterRole(ctx, clusterRole("deployer", nil))).To(BeEmpty())
+	})
+})
+
+var _ = Describe("rulesBoundInNamespace", func() {
+	ctx := context.Background()
+	namespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	It("maps namespaces to the rules listing them , or that granted access in them", func() {
+		listing := newRule()
+		listing.Name = "listing"
+		bound := newRule()
+		bound.Name = "bound"
+		bound.Spec.Bindings[0].RoleBindings[0].Namespaces = nil
+		bound.Status.Bindings = []rbaccontrolleriov1alpha1.BindingStatus{{Name: "dev", RoleBindings: []string{"team-b/dev"}}}
+		deleted := newRule()
+		deleted.Name = "deleted"
+		deleted.Spec.Bindings[0].RoleBindings[0].Namespaces = nil
+		deleted.Status.Bindings = []rbaccontrolleriov1alpha1.BindingStatus{{Name: "dev", DeletedNamespaces: []string{"team-c"}}}
+		r := newFakeReconciler(listing, bound, deleted)
+
+		Expect(r.rulesBoundInNamespace(ctx, namespace("team-a"))).To(ConsistOf(requestsFor("listing")))
+		Expect(r.rulesBoundInNamespace(ctx, namespace("team-b"))).To(ConsistOf(requestsFor("bound")))
+		Expect(r.rulesBoundInNamespace(ctx, namespace("team-c"))).To(ConsistOf(requestsFor("deleted")))
+		Expect(r.rulesBoundInNamespace(ctx, namespace("default"))).To(BeEmpty())
+	})
+})
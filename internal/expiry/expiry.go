@@ -0,0 +1,134 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package expiry deletes the RoleBindings and ClusterRoleBindings annotated
+// with an expiry time once it went by , so bindings managed outside of
+// RBACRules can be time-bound too.
+package expiry
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	log "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings;clusterrolebindings,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// ControllerName is the name of the controllers , suffixed by the kind of
+// bindings they expire.
+const ControllerName = "binding-expiry"
+
+// Reasons of the events emitted on the bindings.
+const (
+	ReasonExpired       = "Expired"
+	ReasonInvalidExpiry = "InvalidExpiry"
+)
+
+// Reconciler deletes the bindings of one kind whose expiry annotation , an
+// RFC 3339 time , went by. The bindings are requeued until they expire.
+type Reconciler struct {
+	client.Client
+	Recorder record.EventRecorder
+	// The metadata of an empty binding of the reconciled kind , only the
+	// metadata of the bindings is cached.
+	Object *metav1.PartialObjectMetadata
+}
+
+// SetupWithManager sets up a controller for RoleBindings and one for
+// ClusterRoleBindings , both only watching the annotated bindings. Bindings
+// are deleted through c (e.g an auditing client).
+func SetupWithManager(mgr ctrl.Manager, c client.Client) error {
+	annotated := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		_, found := o.GetAnnotations()[constants.ExpiresAtAnnotation]
+		return found
+	})
+	for name, obj := range map[string]client.Object{
+		"rolebinding":        &rbacv1.RoleBinding{},
+		"clusterrolebinding": &rbacv1.ClusterRoleBinding{},
+	} {
+		gvk, err := apiutil.GVKForObject(obj, mgr.GetScheme())
+		if err != nil {
+			return err
+		}
+		meta := &metav1.PartialObjectMetadata{}
+		meta.SetGroupVersionKind(gvk)
+		r := &Reconciler{
+			Client:   c,
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Object:   meta,
+		}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			For(obj, builder.OnlyMetadata, builder.WithPredicates(annotated)).
+			Named(ControllerName + "-" + name).
+			Complete(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	obj := r.Object.DeepCopy()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	value, found := obj.GetAnnotations()[constants.ExpiresAtAnnotation]
+	if !found || obj.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	// an invalid time is reported , the binding is reconciled again once
+	// its annotation is fixed.
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		r.Recorder.Eventf(obj, corev1.EventTypeWarning, ReasonInvalidExpiry,
+			"Annotation %s isn't an RFC 3339 time: %s", constants.ExpiresAtAnnotation, value)
+		return ctrl.Result{}, nil
+	}
+	if remaining := time.Until(expiresAt); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// the binding isn't deleted when it changed since it was read , e.g its
+	// expiry was pushed back , it is reconciled again.
+	if err := r.Delete(ctx, obj, client.Preconditions{
+		UID:             ptr(obj.GetUID()),
+		ResourceVersion: ptr(obj.GetResourceVersion()),
+	}); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	logger.Info("Deleted expired binding", "expiresAt", value)
+	r.Recorder.Eventf(obj, corev1.EventTypeNormal, ReasonExpired, "Binding expired at %s , it was deleted", value)
+	return ctrl.Result{}, nil
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
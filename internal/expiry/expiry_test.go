@@ -0,0 +1,104 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package expiry
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+func binding(name, expiresAt string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "team-a",
+			Annotations: map[string]string{constants.ExpiresAtAnnotation: expiresAt},
+		},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+	}
+}
+
+var _ = Describe("Reconciler", func() {
+	ctx := context.Background()
+
+	var (
+		c        client.Client
+		recorder *record.FakeRecorder
+		r        *Reconciler
+	)
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().WithObjects(
+			binding("expired", time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)),
+			binding("active", time.Now().Add(time.Hour).UTC().Format(time.RFC3339)),
+			binding("invalid", "tomorrow"),
+		).Build()
+		recorder = record.NewFakeRecorder(10)
+		meta := &metav1.PartialObjectMetadata{}
+		meta.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind("RoleBinding"))
+		r = &Reconciler{Client: c, Recorder: recorder, Object: meta}
+	})
+
+	reconcile := func(name string) ctrl.Result {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "team-a", Name: name}})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+	exists := func(name string) bool {
+		err := c.Get(ctx, types.NamespacedName{Namespace: "team-a", Name: name}, &rbacv1.RoleBinding{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	It("deletes expired bindings", func() {
+		Expect(reconcile("expired")).To(Equal(ctrl.Result{}))
+		Expect(exists("expired")).To(BeFalse())
+		Expect(recorder.Events).To(Receive(ContainSubstring(ReasonExpired)))
+	})
+
+	It("requeues bindings until they expire", func() {
+		result := reconcile("active")
+		Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		Expect(exists("active")).To(BeTrue())
+	})
+
+	It("reports invalid expiry times", func() {
+		Expect(reconcile("invalid")).To(Equal(ctrl.Result{}))
+		Expect(exists("invalid")).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring(ReasonInvalidExpiry)))
+	})
+
+	It("ignores deleted bindings", func() {
+		Expect(reconcile("missing")).To(Equal(ctrl.Result{}))
+	})
+})
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package exporter writes the RBAC generated for each rule outside of the
+// cluster , so the granted state can be reviewed and diffed.
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Exporter stores the bindings generated for rules.
+type Exporter interface {
+	// Export replaces the bindings stored for the rule.
+	Export(ctx context.Context, rule string, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) error
+	// Remove drops the bindings stored for the rule.
+	Remove(ctx context.Context, rule string) error
+}
+
+// GitExporter commits the bindings of each rule to <Path>/<rule>.yaml in a
+// git working copy , and pushes them when Push is set. Cloning the repository
+// and setting up its credentials is left to the deployment (e.g an init
+// container).
+type GitExporter struct {
+	// Directory of the working copy.
+	Dir string
+	// Directory , relative to Dir , holding the rules files.
+	Path string
+	// Whether commits are pushed to the upstream of the current branch.
+	Push bool
+
+	mu sync.Mutex
+}
+
+func (g *GitExporter) Export(ctx context.Context, rule string, rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) error {
+	out, err := render(rbs, crbs)
+	if err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	file := g.file(rule)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, out, 0o644); err != nil {
+		return err
+	}
+	return g.commit(ctx, file, fmt.Sprintf("Update RBAC of rule %s", rule))
+}
+
+func (g *GitExporter) Remove(ctx context.Context, rule string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	file := g.file(rule)
+	if err := os.Remove(file); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return g.commit(ctx, file, fmt.Sprintf("Remove RBAC of rule %s", rule))
+}
+
+func (g *GitExporter) file(rule string) string {
+	return filepath.Join(g.Dir, g.Path, rule+".yaml")
+}
+
+// commit commits the changes made to file , if any.
+func (g *GitExporter) commit(ctx context.Context, file, message string) error {
+	if _, err := g.git(ctx, "add", "--all", "--", file); err != nil {
+		return err
+	}
+	// diff exits with 1 when there are staged changes.
+	if _, err := g.git(ctx, "diff", "--cached", "--quiet", "--", file); err == nil {
+		return nil
+	}
+	if _, err := g.git(ctx, "commit", "--quiet", "-m", message, "--", file); err != nil {
+		return err
+	}
+	if g.Push {
+		if _, err := g.git(ctx, "push", "--quiet"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GitExporter) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", g.Dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// render renders the bindings as a multi document YAML , sorted so that
+// unchanged bindings always render the same. Owner references are cluster
+// specific , so they are left out.
+func render(rbs []rbacv1.RoleBinding, crbs []rbacv1.ClusterRoleBinding) ([]byte, error) {
+	rbs = slices.Clone(rbs)
+	slices.SortFunc(rbs, func(a, b rbacv1.RoleBinding) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	crbs = slices.Clone(crbs)
+	slices.SortFunc(crbs, func(a, b rbacv1.ClusterRoleBinding) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var docs [][]byte
+	for _, crb := range crbs {
+		crb.TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"}
+		crb.OwnerReferences = nil
+		out, err := yaml.Marshal(crb)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, out)
+	}
+	for _, rb := range rbs {
+		rb.TypeMeta = metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"}
+		rb.OwnerReferences = nil
+		out, err := yaml.Marshal(rb)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, out)
+	}
+	return bytes.Join(docs, []byte("---\n")), nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exporter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("GitExporter", func() {
+	ctx := context.Background()
+	crbs := []rbacv1.ClusterRoleBinding{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "oncall-view",
+			OwnerReferences: []metav1.OwnerReference{{Name: "oncall", UID: "1234"}},
+		},
+		Subjects: []rbacv1.Subject{{Kind: "User", Name: "alice"}},
+		RoleRef:  rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+	}}
+
+	var g *GitExporter
+
+	BeforeEach(func() {
+		g = &GitExporter{Dir: GinkgoT().TempDir(), Path: "rbac"}
+		for _, args := range [][]string{
+			{"init", "--quiet"},
+			{"config", "user.name", "rbac-controller"},
+			{"config", "user.email", "rbac-controller@example.com"},
+			{"commit", "--quiet", "--allow-empty", "-m", "init"},
+		} {
+			_, err := g.git(ctx, args...)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	commits := func() []string {
+		out, err := g.git(ctx, "log", "--format=%s")
+		Expect(err).NotTo(HaveOccurred())
+		return strings.Split(strings.TrimSpace(out), "\n")
+	}
+
+	It("should commit the rendered bindings of the rule", func() {
+		Expect(g.Export(ctx, "oncall", nil, crbs)).To(Succeed())
+
+		out, err := os.ReadFile(filepath.Join(g.Dir, "rbac", "oncall.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("kind: ClusterRoleBinding"))
+		Expect(string(out)).To(ContainSubstring("name: alice"))
+		Expect(string(out)).NotTo(ContainSubstring("ownerReferences"))
+		Expect(commits()).To(Equal([]string{"Update RBAC of rule oncall", "init"}))
+	})
+
+	It("should not commit unchanged bindings", func() {
+		Expect(g.Export(ctx, "oncall", nil, crbs)).To(Succeed())
+		Expect(g.Export(ctx, "oncall", nil, crbs)).To(Succeed())
+		Expect(commits()).To(HaveLen(2))
+	})
+
+	It("should remove the rule file", func() {
+		Expect(g.Export(ctx, "oncall", nil, crbs)).To(Succeed())
+		Expect(g.Remove(ctx, "oncall")).To(Succeed())
+		Expect(filepath.Join(g.Dir, "rbac", "oncall.yaml")).NotTo(BeAnExistingFile())
+		Expect(commits()[0]).To(Equal("Remove RBAC of rule oncall"))
+
+		Expect(g.Remove(ctx, "oncall")).To(Succeed())
+	})
+})
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health holds the health checks served by the controller manager.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// checkTimeout bounds how long a probe waits for the caches to sync.
+const checkTimeout = time.Second
+
+// CacheSyncer waits for informer caches to sync , it is implemented by the
+// cache of the manager.
+type CacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// ReadyChecker returns a readiness check failing until the caches synced , and
+// whenever the API server can't be reached , so no traffic is routed to a
+// controller that can't serve it.
+func ReadyChecker(cache CacheSyncer, server discovery.ServerVersionInterface) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), checkTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(ctx) {
+			return errors.New("the caches didn't sync yet")
+		}
+		if _, err := server.ServerVersion(); err != nil {
+			return fmt.Errorf("the API server can't be reached: %w", err)
+		}
+		return nil
+	}
+}
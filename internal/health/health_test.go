@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// cache is a CacheSyncer whose caches synced when synced is true.
+type cache struct {
+	synced bool
+}
+
+func (c *cache) WaitForCacheSync(context.Context) bool {
+	return c.synced
+}
+
+var _ = Describe("ReadyChecker", func() {
+	var server *fakediscovery.FakeDiscovery
+
+	BeforeEach(func() {
+		server = &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	})
+
+	check := func(c *cache) error {
+		return ReadyChecker(c, server)(httptest.NewRequest("GET", "/readyz", nil))
+	}
+
+	It("should be ready once the caches synced and the API server is reachable", func() {
+		Expect(check(&cache{synced: true})).To(Succeed())
+	})
+
+	It("should not be ready until the caches synced", func() {
+		Expect(check(&cache{})).To(MatchError(ContainSubstring("caches didn't sync")))
+	})
+
+	It("should not be ready when the API server can't be reached", func() {
+		server.PrependReactor("get", "version", func(clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("connection refused")
+		})
+		Expect(check(&cache{synced: true})).To(MatchError(ContainSubstring("connection refused")))
+	})
+})
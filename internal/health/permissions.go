@@ -0,0 +1,227 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var missingPermission = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "rbac_controller_missing_permission",
+	Help: "Whether the controller lacks each permission it needs , 1 when it is missing.",
+}, []string{"verb", "group", "resource", "namespace"})
+
+func init() {
+	metrics.Registry.MustRegister(missingPermission)
+}
+
+// Permission is an access the controller needs.
+type Permission struct {
+	Verb     string
+	Group    string
+	Resource string
+	// The namespace the access is needed in , it is needed cluster wide when
+	// empty.
+	Namespace string
+}
+
+func (p Permission) String() string {
+	s := p.Verb + " " + p.Resource
+	if p.Group != "" {
+		s += "." + p.Group
+	}
+	if p.Namespace != "" {
+		s += " in " + p.Namespace
+	}
+	return s
+}
+
+// rulePermissions are the accesses the controller needs on rules.
+var rulePermissions = []Permission{
+	{Verb: "list", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules"},
+	{Verb: "watch", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules"},
+	{Verb: "patch", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules"},
+	{Verb: "patch", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules/status"},
+	{Verb: "update", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules/finalizers"},
+	{Verb: "list", Resource: "namespaces"},
+	{Verb: "watch", Resource: "namespaces"},
+}
+
+// bindingPermissions are the accesses the controller needs in the namespaces
+// it manages.
+var bindingPermissions = []Permission{
+	{Verb: "list", Resource: "serviceaccounts"},
+	{Verb: "create", Resource: "serviceaccounts"},
+	{Verb: "delete", Resource: "serviceaccounts"},
+	{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+	{Verb: "delete", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+	// bindings can only grant roles the controller may bind , or holds.
+	{Verb: "bind", Group: "rbac.authorization.k8s.io", Resource: "roles"},
+	{Verb: "bind", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+}
+
+// clusterPermissions are the accesses the controller needs when it manages
+// every namespace.
+var clusterPermissions = []Permission{
+	{Verb: "create", Resource: "namespaces"},
+	{Verb: "delete", Resource: "namespaces"},
+	{Verb: "create", Resource: "events"},
+	{Verb: "list", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "delete", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+	{Verb: "escalate", Group: "rbac.authorization.k8s.io", Resource: "clusterroles"},
+}
+
+// Permissions returns the accesses the controller needs to reconcile rules ,
+// whatever features are enabled , when it only manages the given namespaces ,
+// or every namespace when there are none.
+func Permissions(namespaces []string) []Permission {
+	permissions := slices.Clone(rulePermissions)
+	if len(namespaces) == 0 {
+		permissions = append(permissions, bindingPermissions...)
+		return append(permissions, clusterPermissions...)
+	}
+	// the events of rules , which aren't namespaced , are recorded in the
+	// default namespace.
+	permissions = append(permissions, Permission{Verb: "create", Resource: "events", Namespace: metav1.NamespaceDefault})
+	for _, ns := range namespaces {
+		for _, p := range bindingPermissions {
+			p.Namespace = ns
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// PermissionChecker checks , through SelfSubjectAccessReviews , that the
+// controller holds the permissions it needs when it starts , and again every
+// Interval while some are missing. The missing permissions are logged ,
+// exported by the rbac_controller_missing_permission metric and make the
+// controller not ready.
+type PermissionChecker struct {
+	Client      client.Client
+	Log         logr.Logger
+	Permissions []Permission
+	Interval    time.Duration
+
+	mu      sync.Mutex
+	checked bool
+	missing []Permission
+}
+
+// Start implements manager.Runnable.
+func (c *PermissionChecker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		missing, err := c.Check(ctx)
+		if err != nil {
+			c.Log.Error(err, "Failed to check the controller's permissions")
+		} else if len(missing) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable , every replica
+// checks its permissions.
+func (c *PermissionChecker) NeedLeaderElection() bool {
+	return false
+}
+
+// Check reviews every permission and returns the missing ones.
+func (c *PermissionChecker) Check(ctx context.Context) ([]Permission, error) {
+	var missing []Permission
+	for _, p := range c.Permissions {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:      p.Verb,
+					Group:     p.Group,
+					Resource:  p.Resource,
+					Namespace: p.Namespace,
+				},
+			},
+		}
+		// subresources are reviewed apart from their resource.
+		if resource, sub, found := strings.Cut(p.Resource, "/"); found {
+			review.Spec.ResourceAttributes.Resource = resource
+			review.Spec.ResourceAttributes.Subresource = sub
+		}
+		if err := c.Client.Create(ctx, review); err != nil {
+			return nil, fmt.Errorf("reviewing %s: %w", p, err)
+		}
+		value := 0.0
+		if !review.Status.Allowed {
+			missing = append(missing, p)
+			value = 1
+		}
+		missingPermission.WithLabelValues(p.Verb, p.Group, p.Resource, p.Namespace).Set(value)
+	}
+
+	c.mu.Lock()
+	c.checked, c.missing = true, missing
+	c.mu.Unlock()
+	if len(missing) > 0 {
+		c.Log.Info("The controller is missing permissions , it won't be ready until they are granted", "missing", names(missing))
+	} else {
+		c.Log.Info("The controller holds every permission it needs")
+	}
+	return missing, nil
+}
+
+// Ready is a readiness check failing until the permissions were checked , and
+// while some are missing.
+func (c *PermissionChecker) Ready(_ *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.checked {
+		return errors.New("the permissions weren't checked yet")
+	}
+	if len(c.missing) > 0 {
+		return fmt.Errorf("missing permissions: %s", strings.Join(names(c.missing), ", "))
+	}
+	return nil
+}
+
+func names(permissions []Permission) []string {
+	s := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		s = append(s, p.String())
+	}
+	return s
+}
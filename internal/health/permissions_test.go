@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("PermissionChecker", func() {
+	ctx := context.Background()
+	req := httptest.NewRequest("GET", "/readyz", nil)
+	bindRoles := Permission{Verb: "bind", Group: "rbac.authorization.k8s.io", Resource: "roles"}
+	patchStatus := Permission{Verb: "patch", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules/status"}
+
+	var (
+		denied   map[Permission]bool
+		reviewed []authorizationv1.ResourceAttributes
+		checker  *PermissionChecker
+	)
+
+	BeforeEach(func() {
+		denied, reviewed = map[Permission]bool{}, nil
+		c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review := obj.(*authorizationv1.SelfSubjectAccessReview)
+				attrs := *review.Spec.ResourceAttributes
+				reviewed = append(reviewed, attrs)
+				resource := attrs.Resource
+				if attrs.Subresource != "" {
+					resource += "/" + attrs.Subresource
+				}
+				review.Status.Allowed = !denied[Permission{Verb: attrs.Verb, Group: attrs.Group, Resource: resource, Namespace: attrs.Namespace}]
+				return nil
+			},
+		}).Build()
+		checker = &PermissionChecker{
+			Client:      c,
+			Log:         logr.Discard(),
+			Permissions: []Permission{bindRoles, patchStatus},
+		}
+	})
+
+	It("should not be ready until the permissions were checked", func() {
+		Expect(checker.Ready(req)).To(MatchError(ContainSubstring("weren't checked")))
+	})
+
+	It("should be ready when every permission is granted", func() {
+		missing, err := checker.Check(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(BeEmpty())
+		Expect(checker.Ready(req)).To(Succeed())
+		Expect(testutil.ToFloat64(missingPermission.WithLabelValues("bind", "rbac.authorization.k8s.io", "roles", ""))).To(BeZero())
+	})
+
+	It("should report the missing permissions", func() {
+		denied[bindRoles] = true
+
+		missing, err := checker.Check(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(missing).To(ConsistOf(bindRoles))
+		Expect(checker.Ready(req)).To(MatchError(ContainSubstring("bind roles.rbac.authorization.k8s.io")))
+		Expect(testutil.ToFloat64(missingPermission.WithLabelValues("bind", "rbac.authorization.k8s.io", "roles", ""))).To(Equal(1.0))
+
+		By("becoming ready once they are granted")
+		denied = map[Permission]bool{}
+		_, err = checker.Check(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checker.Ready(req)).To(Succeed())
+	})
+
+	It("should review subresources apart from their resource", func() {
+		_, err := checker.Check(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviewed).To(ContainElement(authorizationv1.ResourceAttributes{
+			Verb: "patch", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules", Subresource: "status",
+		}))
+	})
+
+	It("should fail when a review can't be created", func() {
+		checker.Client = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+				return errors.New("connection refused")
+			},
+		}).Build()
+		_, err := checker.Check(ctx)
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+		Expect(checker.Ready(req)).To(HaveOccurred())
+	})
+
+	It("should only need namespaced accesses to bindings when restricted to some namespaces", func() {
+		permissions := Permissions([]string{"team-a", "team-b"})
+		Expect(permissions).To(ContainElements(
+			Permission{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "rolebindings", Namespace: "team-a"},
+			Permission{Verb: "bind", Group: "rbac.authorization.k8s.io", Resource: "clusterroles", Namespace: "team-b"},
+			Permission{Verb: "list", Group: "rbac-controller.ggh41th.io", Resource: "rbacrules"},
+		))
+		Expect(permissions).NotTo(ContainElement(HaveField("Resource", "clusterrolebindings")))
+		Expect(permissions).NotTo(ContainElement(Permission{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"}))
+	})
+
+	It("should need cluster wide accesses when managing every namespace", func() {
+		Expect(Permissions(nil)).To(ContainElements(
+			Permission{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "clusterrolebindings"},
+			Permission{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+		))
+	})
+})
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package impersonation builds clients acting on behalf of the users who
+// created rules , so the API server's escalation prevention applies to the
+// bindings created for them rather than the controller's own permissions.
+package impersonation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// User is the identity of a requester , as recorded at admission.
+type User struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// Creator returns the user recorded as the creator of obj , false when none
+// was recorded.
+func Creator(obj client.Object) (User, bool, error) {
+	v, found := obj.GetAnnotations()[constants.CreatedByAnnotation]
+	if !found {
+		return User{}, false, nil
+	}
+	var u User
+	if err := json.Unmarshal([]byte(v), &u); err != nil {
+		return User{}, false, fmt.Errorf("invalid %s annotation: %w", constants.CreatedByAnnotation, err)
+	}
+	if u.Username == "" {
+		return User{}, false, fmt.Errorf("invalid %s annotation: %w", constants.CreatedByAnnotation, errors.New("missing username"))
+	}
+	return u, true, nil
+}
+
+// SetCreator records the user as the creator of obj.
+func SetCreator(obj client.Object, u User) error {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.CreatedByAnnotation] = string(b)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// Clients builds clients impersonating users from Config , they are cached
+// per user.
+type Clients struct {
+	Config *rest.Config
+	Scheme *runtime.Scheme
+	Mapper meta.RESTMapper
+	// Wrap , when set , wraps the built clients , e.g to audit their
+	// mutations.
+	Wrap func(client.Client) client.Client
+
+	mu      sync.Mutex
+	clients map[string]client.Client
+}
+
+// For returns a client impersonating the user.
+func (c *Clients) For(u User) (client.Client, error) {
+	key := strings.Join(append([]string{u.Username, u.UID}, u.Groups...), "\x00")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cl, ok := c.clients[key]; ok {
+		return cl, nil
+	}
+	cfg := rest.CopyConfig(c.Config)
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: u.Username,
+		UID:      u.UID,
+		Groups:   u.Groups,
+	}
+	cl, err := client.New(cfg, client.Options{Scheme: c.Scheme, Mapper: c.Mapper})
+	if err != nil {
+		return nil, err
+	}
+	if c.Wrap != nil {
+		cl = c.Wrap(cl)
+	}
+	if c.clients == nil {
+		c.clients = map[string]client.Client{}
+	}
+	c.clients[key] = cl
+	return cl, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impersonation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Creator", func() {
+	alice := User{Username: "alice", UID: "1234", Groups: []string{"dev", "system:authenticated"}}
+
+	It("round trips through the annotation", func() {
+		rule := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{constants.OwnerContactAnnotation: "#team"},
+		}}
+		Expect(SetCreator(rule, alice)).To(Succeed())
+		Expect(rule.Annotations).To(HaveKeyWithValue(constants.OwnerContactAnnotation, "#team"))
+
+		u, found, err := Creator(rule)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(u).To(Equal(alice))
+	})
+
+	It("reports rules without a creator", func() {
+		_, found, err := Creator(&rbaccontrollerv1.RBACRule{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("rejects malformed annotations", func() {
+		for _, v := range []string{"alice", `{"groups":["dev"]}`} {
+			rule := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{constants.CreatedByAnnotation: v},
+			}}
+			_, _, err := Creator(rule)
+			Expect(err).To(HaveOccurred())
+		}
+	})
+})
+
+var _ = Describe("Clients", func() {
+	It("caches a client per user", func() {
+		c := &Clients{
+			Config: &rest.Config{Host: "https://kubernetes.example.com"},
+			Scheme: scheme.Scheme,
+			Mapper: meta.NewDefaultRESTMapper(nil),
+		}
+		alice, err := c.For(User{Username: "alice", Groups: []string{"dev"}})
+		Expect(err).NotTo(HaveOccurred())
+		again, err := c.For(User{Username: "alice", Groups: []string{"dev"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(alice))
+
+		other, err := c.For(User{Username: "alice", Groups: []string{"ops"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other).NotTo(BeIdenticalTo(alice))
+		Expect(c.Config.Impersonate.UserName).To(BeEmpty())
+	})
+})
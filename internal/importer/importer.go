@@ -0,0 +1,253 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer converts the RoleBindings and ClusterRoleBindings created
+// outside of the controller to RBACRules , to migrate them onto it.
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// bootstrapLabel marks the bindings created by the API server.
+const bootstrapLabel = "kubernetes.io/bootstrapping"
+
+// Imported is a rule converted from existing bindings.
+type Imported struct {
+	Rule *rbaccontrollerv1.RBACRule
+	// The bindings the rule was converted from.
+	Bindings []client.Object
+}
+
+// Importer lists the existing bindings and converts them to rules. A rule is
+// generated for each ClusterRoleBinding , and for each set of RoleBindings
+// sharing their name , role and subjects across namespaces.
+type Importer struct {
+	Client client.Client
+	Log    logr.Logger
+	// Only import the RoleBindings of this namespace , ClusterRoleBindings
+	// are skipped when set.
+	Namespace string
+	// Only import the bindings matching this selector.
+	Selector labels.Selector
+	// Also import the bindings created by the API server and the ones named
+	// system:* , which are skipped otherwise.
+	IncludeSystem bool
+}
+
+// Import returns the rules converted from the existing bindings. The bindings
+// already managed by the controller are skipped.
+func (i *Importer) Import(ctx context.Context) ([]Imported, error) {
+	unmanaged, err := labels.NewRequirement(constants.RBACRuleLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.NewSelector()
+	if i.Selector != nil {
+		if reqs, ok := i.Selector.Requirements(); ok {
+			selector = selector.Add(reqs...)
+		}
+	}
+	opts := []client.ListOption{client.MatchingLabelsSelector{Selector: selector.Add(*unmanaged)}}
+
+	var imported []Imported
+	names := map[string]bool{}
+
+	if i.Namespace == "" {
+		crbs := &rbacv1.ClusterRoleBindingList{}
+		if err := i.Client.List(ctx, crbs, opts...); err != nil {
+			return nil, err
+		}
+		slices.SortFunc(crbs.Items, func(a, b rbacv1.ClusterRoleBinding) int { return strings.Compare(a.Name, b.Name) })
+		for _, crb := range crbs.Items {
+			if !i.importable(&crb, crb.Subjects) {
+				continue
+			}
+			binding := rbaccontrollerv1.Binding{
+				Name:                ruleName(crb.Name),
+				Subjects:            subjects(crb.Subjects),
+				ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: crb.RoleRef.Name}},
+			}
+			imported = append(imported, Imported{
+				Rule:     newRule(uniqueName(names, crb.Name), binding),
+				Bindings: []client.Object{crb.DeepCopy()},
+			})
+		}
+	} else {
+		opts = append(opts, client.InNamespace(i.Namespace))
+	}
+
+	rbs := &rbacv1.RoleBindingList{}
+	if err := i.Client.List(ctx, rbs, opts...); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(rbs.Items, func(a, b rbacv1.RoleBinding) int {
+		return strings.Compare(a.Name+"/"+a.Namespace, b.Name+"/"+b.Namespace)
+	})
+	// the RoleBindings granting the same role to the same subjects under the
+	// same name are merged in a single rule.
+	groups := map[string]int{}
+	for _, rb := range rbs.Items {
+		if !i.importable(&rb, rb.Subjects) {
+			continue
+		}
+		key := fmt.Sprintf("%s/%v/%v", rb.Name, rb.RoleRef, rb.Subjects)
+		if idx, ok := groups[key]; ok {
+			spec := &imported[idx].Rule.Spec.Bindings[0].RoleBindings[0]
+			spec.Namespaces = append(spec.Namespaces, rb.Namespace)
+			imported[idx].Bindings = append(imported[idx].Bindings, rb.DeepCopy())
+			continue
+		}
+		roleBinding := rbaccontrollerv1.RoleBinding{Namespaces: []string{rb.Namespace}}
+		if rb.RoleRef.Kind == "Role" {
+			roleBinding.Role = rb.RoleRef.Name
+		} else {
+			roleBinding.ClusterRole = rb.RoleRef.Name
+		}
+		binding := rbaccontrollerv1.Binding{
+			Name:         ruleName(rb.Name),
+			Subjects:     subjects(rb.Subjects),
+			RoleBindings: []rbaccontrollerv1.RoleBinding{roleBinding},
+		}
+		groups[key] = len(imported)
+		imported = append(imported, Imported{
+			Rule:     newRule(uniqueName(names, rb.Name), binding),
+			Bindings: []client.Object{rb.DeepCopy()},
+		})
+	}
+	return imported, nil
+}
+
+// importable reports whether the binding is converted.
+func (i *Importer) importable(obj client.Object, s []rbacv1.Subject) bool {
+	if !i.IncludeSystem && (strings.HasPrefix(obj.GetName(), "system:") || obj.GetLabels()[bootstrapLabel] != "") {
+		return false
+	}
+	if len(s) == 0 {
+		i.Log.Info("Skipping binding without subjects", "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return false
+	}
+	return true
+}
+
+// Adopt creates the rule and labels the bindings it was converted from with
+// it. The labeled bindings are deleted along with the rule.
+func (i *Importer) Adopt(ctx context.Context, imported Imported) error {
+	if err := i.Client.Create(ctx, imported.Rule.DeepCopy()); err != nil {
+		return fmt.Errorf("failed to create rule %s: %w", imported.Rule.Name, err)
+	}
+	for _, obj := range imported.Bindings {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		l := obj.GetLabels()
+		if l == nil {
+			l = map[string]string{}
+		}
+		l[constants.RBACRuleLabel] = imported.Rule.Name
+		obj.SetLabels(l)
+		if err := i.Client.Patch(ctx, obj, patch); err != nil {
+			return fmt.Errorf("failed to label binding %s: %w", client.ObjectKeyFromObject(obj), err)
+		}
+	}
+	return nil
+}
+
+// Render renders the rules as a multi document YAML.
+func Render(imported []Imported) ([]byte, error) {
+	var out bytes.Buffer
+	for n, i := range imported {
+		b, err := yaml.Marshal(i.Rule)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(b)
+	}
+	return out.Bytes(), nil
+}
+
+func newRule(name string, binding rbaccontrollerv1.Binding) *rbaccontrollerv1.RBACRule {
+	return &rbaccontrollerv1.RBACRule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbaccontrollerv1.GroupVersion.String(),
+			Kind:       "RBACRule",
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       rbaccontrollerv1.RBACRuleSpec{Bindings: []rbaccontrollerv1.Binding{binding}},
+	}
+}
+
+func subjects(s []rbacv1.Subject) []rbaccontrollerv1.Subject {
+	var out []rbaccontrollerv1.Subject
+	for _, sub := range s {
+		subject := rbaccontrollerv1.Subject{
+			Kind: rbaccontrollerv1.SubjectType(sub.Kind),
+			Name: sub.Name,
+		}
+		if sub.Kind == rbacv1.ServiceAccountKind {
+			subject.Namespaces = []string{sub.Namespace}
+		}
+		out = append(out, subject)
+	}
+	return out
+}
+
+var invalidChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// ruleName turns a binding name into a valid rule name , usable as a label
+// value.
+func ruleName(name string) string {
+	name = invalidChars.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	name = strings.Trim(name, ".-")
+	if name == "" {
+		return "imported"
+	}
+	return name
+}
+
+// uniqueName returns a rule name derived from the binding name that isn't
+// taken yet.
+func uniqueName(taken map[string]bool, binding string) string {
+	base := ruleName(binding)
+	name := base
+	for n := 2; taken[name]; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		name = strings.Trim(base[:min(len(base), 63-len(suffix))], ".-") + suffix
+	}
+	taken[name] = true
+	return name
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+func ruleNames(imported []Imported) []string {
+	n := []string{}
+	for _, i := range imported {
+		n = append(n, i.Rule.Name)
+	}
+	return n
+}
+
+var _ = Describe("Importer", func() {
+	ctx := context.Background()
+	var c client.Client
+	var i *Importer
+	sre := []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "sre"}}
+	view := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"}
+
+	rb := func(name, namespace string, subjects []rbacv1.Subject) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Subjects:   subjects,
+			RoleRef:    view,
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "sre-view"},
+				Subjects:   sre,
+				RoleRef:    view,
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "system:basic-user", Labels: map[string]string{bootstrapLabel: "rbac-defaults"}},
+				Subjects:   sre,
+				RoleRef:    view,
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "managed", Labels: map[string]string{constants.RBACRuleLabel: "oncall"}},
+				Subjects:   sre,
+				RoleRef:    view,
+			},
+			rb("sre-view", "dev", sre),
+			rb("sre-view", "prod", sre),
+			rb("sre-view", "ops", []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "pager", Namespace: "ops"}}),
+		).Build()
+		i = &Importer{Client: c, Log: logr.Discard()}
+	})
+
+	It("converts unmanaged bindings to rules", func() {
+		imported, err := i.Import(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ruleNames(imported)).To(Equal([]string{"sre-view", "sre-view-2", "sre-view-3"}))
+
+		Expect(imported[0].Rule.Spec.Bindings[0].ClusterRoleBindings).To(Equal([]rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}}))
+
+		// the RoleBindings sharing their subjects are merged.
+		Expect(imported[1].Rule.Spec.Bindings[0].RoleBindings[0].Namespaces).To(Equal([]string{"dev", "prod"}))
+		Expect(imported[1].Bindings).To(HaveLen(2))
+		Expect(imported[2].Rule.Spec.Bindings[0].Subjects).To(Equal([]rbaccontrollerv1.Subject{{
+			Kind:       rbaccontrollerv1.ServiceAccount,
+			Name:       "pager",
+			Namespaces: []string{"ops"},
+		}}))
+
+		out, err := Render(imported)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).To(ContainSubstring("kind: RBACRule"))
+	})
+
+	It("only imports the RoleBindings of the namespace", func() {
+		i.Namespace = "ops"
+		imported, err := i.Import(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ruleNames(imported)).To(Equal([]string{"sre-view"}))
+	})
+
+	It("creates the rules and labels the bindings when adopting", func() {
+		imported, err := i.Import(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.Adopt(ctx, imported[1])).To(Succeed())
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "sre-view-2"}, &rbaccontrollerv1.RBACRule{})).To(Succeed())
+		for _, ns := range []string{"dev", "prod"} {
+			b := &rbacv1.RoleBinding{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: "sre-view", Namespace: ns}, b)).To(Succeed())
+			Expect(b.Labels).To(HaveKeyWithValue(constants.RBACRuleLabel, "sre-view-2"))
+		}
+	})
+
+	It("turns binding names into valid rule names", func() {
+		Expect(ruleName("system:Controller:job")).To(Equal("system-controller-job"))
+		Expect(uniqueName(map[string]bool{"a": true}, "a")).To(Equal("a-2"))
+	})
+})
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notifier posts the lifecycle of RBACRules (activation , expiry ,
+// failures) to chat tools such as Slack or Microsoft Teams.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the notifier Secret , each one holds an incoming webhook URL.
+const (
+	SlackWebhookURLKey = "slackWebhookURL"
+	TeamsWebhookURLKey = "teamsWebhookURL"
+)
+
+// EventType is the lifecycle step a notification is about.
+type EventType string
+
+const (
+	Activated EventType = "Activated"
+	Expiring  EventType = "Expiring"
+	Expired   EventType = "Expired"
+	Failed    EventType = "Failed"
+	// BreakGlass is the activation of a break-glass rule.
+	BreakGlass EventType = "BreakGlass"
+	// RolesBroadened is the broadening of the roles bound by an active rule
+	// beyond its recorded risk level.
+	RolesBroadened EventType = "RolesBroadened"
+)
+
+// Event describes a step in the lifecycle of a rule.
+type Event struct {
+	Type EventType
+	// Name of the rule.
+	Rule    string
+	Message string
+	// Human readable description of what the rule grants , and to whom.
+	Grants       []string
+	OwnerContact string
+	DocsURL      string
+}
+
+// Text renders the event as a message , it only relies on formatting that
+// Slack and Teams render the same way.
+func (e Event) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "RBACRule %s: %s", e.Rule, e.Type)
+	if e.Message != "" {
+		fmt.Fprintf(&b, " , %s", e.Message)
+	}
+	for _, g := range e.Grants {
+		fmt.Fprintf(&b, "\n- %s", g)
+	}
+	if e.OwnerContact != "" {
+		fmt.Fprintf(&b, "\nOwner: %s", e.OwnerContact)
+	}
+	if e.DocsURL != "" {
+		fmt.Fprintf(&b, "\nDocs: %s", e.DocsURL)
+	}
+	return b.String()
+}
+
+// Notifier sends lifecycle notifications.
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// SecretNotifier posts events to the webhooks configured in a Secret. The
+// Secret is read on each notification , so webhooks can be added or rotated
+// without restarting the controller. A missing Secret disables notifications.
+type SecretNotifier struct {
+	Reader client.Reader
+	Secret types.NamespacedName
+	// Returns the Secret to read instead of Secret when set , so it can be
+	// changed without restarting the controller. Notifications are disabled
+	// while it returns an empty name.
+	SecretName func() types.NamespacedName
+	// Defaults to a client with a 10 seconds timeout.
+	HTTPClient *http.Client
+}
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+type teamsMessage struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Summary string `json:"summary"`
+	Text    string `json:"text"`
+}
+
+func (n *SecretNotifier) Notify(ctx context.Context, e Event) error {
+	key := n.Secret
+	if n.SecretName != nil {
+		if key = n.SecretName(); key.Name == "" {
+			return nil
+		}
+	}
+	secret := &corev1.Secret{}
+	if err := n.Reader.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	text := e.Text()
+	var errs []error
+	if url := string(secret.Data[SlackWebhookURLKey]); url != "" {
+		errs = append(errs, n.post(ctx, url, slackMessage{Text: text}))
+	}
+	if url := string(secret.Data[TeamsWebhookURLKey]); url != "" {
+		errs = append(errs, n.post(ctx, url, teamsMessage{
+			Type:    "MessageCard",
+			Context: "https://schema.org/extensions",
+			Summary: fmt.Sprintf("RBACRule %s: %s", e.Rule, e.Type),
+			// Teams renders text as markdown , which needs blank lines to
+			// break lines.
+			Text: strings.ReplaceAll(text, "\n", "\n\n"),
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+func (n *SecretNotifier) post(ctx context.Context, url string, msg any) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	c := n.HTTPClient
+	if c == nil {
+		c = defaultHTTPClient
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("SecretNotifier", func() {
+	ctx := context.Background()
+	secretName := types.NamespacedName{Namespace: "rbac-controller-system", Name: "notifier"}
+	event := Event{
+		Type:         Activated,
+		Rule:         "oncall",
+		Message:      "access granted",
+		Grants:       []string{"oncall: User alice -> ClusterRole view cluster wide"},
+		OwnerContact: "#sre",
+	}
+
+	var (
+		server   *httptest.Server
+		received []map[string]any
+	)
+
+	BeforeEach(func() {
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			msg := map[string]any{}
+			Expect(json.NewDecoder(r.Body).Decode(&msg)).To(Succeed())
+			received = append(received, msg)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post to every configured webhook", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+			Data: map[string][]byte{
+				SlackWebhookURLKey: []byte(server.URL + "/slack"),
+				TeamsWebhookURLKey: []byte(server.URL + "/teams"),
+			},
+		}
+		n := &SecretNotifier{
+			Reader: fake.NewClientBuilder().WithObjects(secret).Build(),
+			Secret: secretName,
+		}
+
+		Expect(n.Notify(ctx, event)).To(Succeed())
+		Expect(received).To(HaveLen(2))
+		Expect(received[0]).To(HaveKeyWithValue("text", event.Text()))
+		Expect(received[1]).To(HaveKeyWithValue("@type", "MessageCard"))
+		Expect(received[1]["text"]).To(ContainSubstring("User alice"))
+	})
+
+	It("should do nothing when the Secret doesn't exist", func() {
+		n := &SecretNotifier{
+			Reader: fake.NewClientBuilder().Build(),
+			Secret: secretName,
+		}
+
+		Expect(n.Notify(ctx, event)).To(Succeed())
+		Expect(received).To(BeEmpty())
+	})
+
+	It("should read the Secret it is given at runtime", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+			Data:       map[string][]byte{SlackWebhookURLKey: []byte(server.URL + "/slack")},
+		}
+		current := types.NamespacedName{}
+		n := &SecretNotifier{
+			Reader:     fake.NewClientBuilder().WithObjects(secret).Build(),
+			SecretName: func() types.NamespacedName { return current },
+		}
+
+		Expect(n.Notify(ctx, event)).To(Succeed())
+		Expect(received).To(BeEmpty())
+
+		current = secretName
+		Expect(n.Notify(ctx, event)).To(Succeed())
+		Expect(received).To(HaveLen(1))
+	})
+
+	It("should fail when a webhook rejects the message", func() {
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: secretName.Namespace, Name: secretName.Name},
+			Data:       map[string][]byte{SlackWebhookURLKey: []byte(server.URL)},
+		}
+		n := &SecretNotifier{
+			Reader: fake.NewClientBuilder().WithObjects(secret).Build(),
+			Secret: secretName,
+		}
+
+		Expect(n.Notify(ctx, event)).To(MatchError(ContainSubstring("403")))
+	})
+})
+
+var _ = Describe("Event", func() {
+	It("should render the grants and contacts", func() {
+		e := Event{
+			Type:         Expired,
+			Rule:         "oncall",
+			Grants:       []string{"oncall: User alice -> ClusterRole view cluster wide"},
+			OwnerContact: "#sre",
+			DocsURL:      "https://wiki/oncall",
+		}
+		Expect(e.Text()).To(Equal("RBACRule oncall: Expired\n- oncall: User alice -> ClusterRole view cluster wide\nOwner: #sre\nDocs: https://wiki/oncall"))
+	})
+})
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// ErrQueueFull is returned when an event is dropped , too many events are
+// waiting to be sent.
+var ErrQueueFull = errors.New("too many notifications are pending , the notification was dropped")
+
+// Queue sends the events through a Notifier in the background , so the
+// reconciliations don't wait for the chat tools to respond. The events still
+// pending when the controller stops are lost.
+type Queue struct {
+	Notifier Notifier
+	// the most events waiting to be sent , later events are dropped. Defaults
+	// to 100.
+	Size int
+	// how long sending an event may take. Defaults to 10 seconds.
+	Timeout time.Duration
+	Log     logr.Logger
+
+	once   sync.Once
+	events chan Event
+}
+
+var _ Notifier = &Queue{}
+
+func (q *Queue) init() {
+	q.once.Do(func() {
+		size := q.Size
+		if size <= 0 {
+			size = 100
+		}
+		q.events = make(chan Event, size)
+	})
+}
+
+// Notify queues the event , it returns ErrQueueFull when it was dropped.
+func (q *Queue) Notify(_ context.Context, e Event) error {
+	q.init()
+	select {
+	case q.events <- e:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Start implements manager.Runnable , it sends the queued events one at a
+// time until ctx is canceled.
+func (q *Queue) Start(ctx context.Context) error {
+	q.init()
+	timeout := q.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-q.events:
+			sendCtx, cancel := context.WithTimeout(ctx, timeout)
+			if err := q.Notifier.Notify(sendCtx, e); err != nil {
+				q.Log.Error(err, "Failed to send notification", "rule", e.Rule, "type", e.Type)
+			}
+			cancel()
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable , every replica
+// sends the notifications of the rules it reconciles.
+func (q *Queue) NeedLeaderElection() bool {
+	return false
+}
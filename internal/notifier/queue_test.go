@@ -0,0 +1,103 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// blockingNotifier records the events it sends , each send waiting for
+// release.
+type blockingNotifier struct {
+	mu      sync.Mutex
+	sent    []string
+	release chan struct{}
+}
+
+func (n *blockingNotifier) Notify(ctx context.Context, e Event) error {
+	select {
+	case <-n.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, e.Rule)
+	return nil
+}
+
+func (n *blockingNotifier) Sent() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.sent...)
+}
+
+var _ = Describe("Queue", func() {
+	var (
+		n      *blockingNotifier
+		q      *Queue
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		n = &blockingNotifier{release: make(chan struct{})}
+		q = &Queue{Notifier: n, Size: 2, Log: GinkgoLogr}
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+	})
+
+	It("doesn't wait for the events to be sent", func() {
+		go q.Start(ctx) //nolint:errcheck
+
+		Expect(q.Notify(ctx, Event{Rule: "first"})).To(Succeed())
+		Expect(q.Notify(ctx, Event{Rule: "second"})).To(Succeed())
+		Expect(n.Sent()).To(BeEmpty())
+
+		close(n.release)
+		Eventually(n.Sent).Should(Equal([]string{"first", "second"}))
+	})
+
+	It("drops the events once the queue is full", func() {
+		Expect(q.Notify(ctx, Event{Rule: "first"})).To(Succeed())
+		Expect(q.Notify(ctx, Event{Rule: "second"})).To(Succeed())
+		Expect(errors.Is(q.Notify(ctx, Event{Rule: "third"}), ErrQueueFull)).To(BeTrue())
+
+		close(n.release)
+		go q.Start(ctx) //nolint:errcheck
+		Eventually(n.Sent).Should(Equal([]string{"first", "second"}))
+	})
+
+	It("gives up on the events that take too long to send", func() {
+		q.Timeout = 10 * time.Millisecond
+		go q.Start(ctx) //nolint:errcheck
+
+		Expect(q.Notify(ctx, Event{Rule: "slow"})).To(Succeed())
+		Expect(q.Notify(ctx, Event{Rule: "next"})).To(Succeed())
+		Consistently(n.Sent, 50*time.Millisecond).Should(BeEmpty())
+
+		close(n.release)
+		Expect(q.Notify(ctx, Event{Rule: "last"})).To(Succeed())
+		Eventually(n.Sent).Should(Equal([]string{"last"}))
+	})
+})
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import "errors"
+
+// ErrorKind classifies the errors of the parser , so the reconciler can report
+// them through specific conditions.
+type ErrorKind string
+
+const (
+	// InvalidSelector is the kind of the errors of label selectors and match
+	// expressions that can't be compiled.
+	InvalidSelector ErrorKind = "InvalidSelector"
+	// ListFailed is the kind of the errors of namespaces that couldn't be
+	// listed , they are usually transient.
+	ListFailed ErrorKind = "ListFailed"
+	// EmptyResult is the kind of the namespace selections matching no
+	// namespace. They aren't returned by Parse but recorded in
+	// Parser.Unmatched , a selection can legitimately match nothing yet.
+	EmptyResult ErrorKind = "EmptyResult"
+)
+
+// Error is an error of the parser with the field of the binding it is about.
+type Error struct {
+	Kind ErrorKind
+	// The path of the field in the binding , e.g roleBindings[0].nameSpaceSelector.
+	Field string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the kind of the parser error , empty when err isn't one.
+func KindOf(err error) ErrorKind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return ""
+}
+
+// atField sets the field of err when it is a parser error without one.
+func atField(err error, field string) error {
+	var e *Error
+	if errors.As(err, &e) && e.Field == "" {
+		e.Field = field
+	}
+	return err
+}
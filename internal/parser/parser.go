@@ -1,14 +1,27 @@
 package parser
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
 
 	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/tracing"
 	"github.com/GGh41th/rbac-controller/internal/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -18,14 +31,59 @@ const (
 	RB           = "Role"
 )
 
+// ServiceAccount is a ServiceAccount subject resolved to a single namespace,
+// along with the RBACRule subject it was generated from.
+type ServiceAccount struct {
+	Name      string
+	Namespace string
+	Subject   *rbaccontrollerv1.Subject
+	// Whether the ServiceAccount was listed rather than named by the subject ,
+	// it exists already and is never created.
+	Listed bool
+}
+
+// NamespaceCache holds the namespaces matched by label selectors , keyed by
+// selector. It is shared by the parsers of a reconciliation so each selector
+// is only listed once.
+type NamespaceCache map[string][]string
+
 type Parser struct {
 	client.Client
+	// The role bundles bindings can reference.
+	Bundles bundles.Catalog
+	// The ClusterRoles generated from the role templates bindings can
+	// reference , by template.
+	Templates map[string]string
+	// The namespaces already resolved , selectors are listed each time when
+	// nil.
+	Namespaces NamespaceCache
+	// The annotations of the generated bindings.
+	Annotations map[string]string
+	// Prefixes prepended to the names of User and Group subjects , by kind ,
+	// unless they already start with it.
+	Prefixes map[string]string
+	// The template the names of User subjects are rendered with , before being
+	// prefixed. Names are kept when nil.
+	UsernameTemplate *template.Template
+	// The namespace selections that matched no namespace.
+	Unmatched           []*Error
 	Subjects            []rbacv1.Subject
+	ServiceAccounts     []ServiceAccount
 	RoleBindings        []rbacv1.RoleBinding
 	ClusterRoleBindings []rbacv1.ClusterRoleBinding
 }
 
-func (p *Parser) Parse(ctx context.Context, binding *rbaccontrollerv1.Binding, RBACLabels map[string]string, ownerRef []metav1.OwnerReference, RBACRuleName string) error {
+func (p *Parser) Parse(ctx context.Context, binding *rbaccontrollerv1.Binding, RBACLabels map[string]string, ownerRef []metav1.OwnerReference, RBACRule metav1.Object) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Parse", trace.WithAttributes(attribute.String("binding", binding.Name)))
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("roleBindings", len(p.RoleBindings)),
+			attribute.Int("clusterRoleBindings", len(p.ClusterRoleBindings)),
+		)
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	//we start by parsing the subjects contained in the binding
 	if len(binding.Subjects) > 0 {
 		err := p.parseSubjects(ctx, binding.Subjects, RBACLabels, ownerRef)
@@ -37,10 +95,12 @@ func (p *Parser) Parse(ctx context.Context, binding *rbaccontrollerv1.Binding, R
 	// extracted earlier
 
 	if len(binding.ClusterRoleBindings) > 0 {
-		p.parseCRBs(RBACRuleName, binding.Name, binding.ClusterRoleBindings, RBACLabels, ownerRef)
+		if err := p.parseCRBs(ctx, RBACRule, binding.Name, binding.ClusterRoleBindings, RBACLabels, ownerRef); err != nil {
+			return err
+		}
 	}
 	if len(binding.RoleBindings) > 0 {
-		if err := p.parseRBs(ctx, RBACRuleName, binding.Name, binding.RoleBindings, RBACLabels, ownerRef); err != nil {
+		if err := p.parseRBs(ctx, RBACRule, binding.Name, binding.RoleBindings, RBACLabels, ownerRef); err != nil {
 			return err
 		}
 	}
@@ -48,97 +108,416 @@ func (p *Parser) Parse(ctx context.Context, binding *rbaccontrollerv1.Binding, R
 }
 
 func (p *Parser) parseSubjects(ctx context.Context, subjects []rbaccontrollerv1.Subject, RBACLabels map[string]string, ownerRef []metav1.OwnerReference) error {
-	for _, s := range subjects {
+	for i, s := range subjects {
 		switch s.Kind {
 		case rbaccontrollerv1.User:
-			{
-				p.Subjects = append(p.Subjects, rbacv1.Subject{
+			for _, n := range SubjectNames(&s) {
+				name, err := p.username(n)
+				if err != nil {
+					return fmt.Errorf("subjects[%d]: %w", i, err)
+				}
+				p.addSubject(rbacv1.Subject{
 					APIGroup:  RBACApiGroup,
 					Kind:      string(rbaccontrollerv1.User),
-					Name:      s.Name,
+					Name:      p.prefixed(s.Kind, name),
 					Namespace: "",
 				})
 			}
 		case rbaccontrollerv1.Group:
-			{
-				p.Subjects = append(p.Subjects, rbacv1.Subject{
+			for _, n := range SubjectNames(&s) {
+				p.addSubject(rbacv1.Subject{
 					APIGroup:  RBACApiGroup,
 					Kind:      string(rbaccontrollerv1.Group),
-					Name:      s.Name,
+					Name:      p.prefixed(s.Kind, n),
 					Namespace: "",
 				})
 			}
 		case rbaccontrollerv1.ServiceAccount:
+			if s.ServiceAccountSelector != nil {
+				if err := p.selectServiceAccounts(ctx, fmt.Sprintf("subjects[%d]", i), &subjects[i]); err != nil {
+					return err
+				}
+				continue
+			}
 			{
-				ns, err := p.retrieveNamespaces(ctx, &s.NameSpaceSelector)
-				ns = append(ns, s.Namespaces...)
+				ns, err := p.selectNamespaces(ctx, fmt.Sprintf("subjects[%d]", i), s.Namespaces, &s.NameSpaceSelector,
+					s.NamespaceMatchExpression, s.ExcludeNamespaces, &s.ExcludeNamespaceSelector)
 				if err != nil {
 					return err
 				}
+				names := SubjectNames(&s)
+				var globbed []metav1.PartialObjectMetadata
+				if len(ns) > 0 && slices.ContainsFunc(names, IsGlob) {
+					field := fmt.Sprintf("subjects[%d].name", i)
+					if len(s.Names) > 0 {
+						field += "s"
+					}
+					if globbed, err = p.globServiceAccounts(ctx, names); err != nil {
+						return atField(err, field)
+					}
+				}
 				for _, n := range ns {
-					p.Subjects = append(p.Subjects, rbacv1.Subject{
-						APIGroup:  "",
-						Kind:      string(rbaccontrollerv1.ServiceAccount),
-						Name:      s.Name,
-						Namespace: n,
-					})
+					for _, name := range names {
+						if !IsGlob(name) {
+							p.addServiceAccount(name, n, &subjects[i], false)
+						}
+					}
+					for _, sa := range globbed {
+						if sa.Namespace == n {
+							p.addServiceAccount(sa.Name, n, &subjects[i], true)
+						}
+					}
 				}
 			}
+		case rbaccontrollerv1.AllServiceAccounts:
+			if err := p.allServiceAccounts(ctx, fmt.Sprintf("subjects[%d]", i), &subjects[i]); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func (p *Parser) parseCRBs(RBACRuleName, BindingName string, CRBs []rbaccontrollerv1.ClusterRoleBinding, RBACLabels map[string]string, ownerRef []metav1.OwnerReference) {
-	for _, crb := range CRBs {
-		p.ClusterRoleBindings = append(p.ClusterRoleBindings, rbacv1.ClusterRoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:            utils.GenerateName(RBACRuleName, BindingName, CRB, crb.ClusterRole),
-				Labels:          RBACLabels,
-				OwnerReferences: ownerRef,
-			},
-			Subjects: p.Subjects,
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: RBACApiGroup,
-				Kind:     CRB,
-				Name:     crb.ClusterRole,
-			},
+// SubjectNames returns the names of the subject , from names or name.
+func SubjectNames(s *rbaccontrollerv1.Subject) []string {
+	if len(s.Names) > 0 {
+		return s.Names
+	}
+	if s.Name == "" {
+		return nil
+	}
+	return []string{s.Name}
+}
+
+// SubjectCount returns the number of subjects , counting each of their names.
+// Subjects selecting ServiceAccounts count as one.
+func SubjectCount(subjects []rbaccontrollerv1.Subject) int {
+	count := 0
+	for i := range subjects {
+		count += max(len(SubjectNames(&subjects[i])), 1)
+	}
+	return count
+}
+
+// addServiceAccount adds the ServiceAccount to the subjects of the bindings ,
+// unless it is already one of them.
+func (p *Parser) addServiceAccount(name, namespace string, s *rbaccontrollerv1.Subject, listed bool) {
+	subject := rbacv1.Subject{
+		APIGroup:  "",
+		Kind:      string(rbaccontrollerv1.ServiceAccount),
+		Name:      name,
+		Namespace: namespace,
+	}
+	if slices.Contains(p.Subjects, subject) {
+		return
+	}
+	p.Subjects = append(p.Subjects, subject)
+	p.ServiceAccounts = append(p.ServiceAccounts, ServiceAccount{
+		Name:      name,
+		Namespace: namespace,
+		Subject:   s,
+		Listed:    listed,
+	})
+}
+
+// selectServiceAccounts adds the existing ServiceAccounts matched by the
+// serviceAccountSelector of the subject at field , in the namespaces it
+// selects or in every namespace when it selects none. Exclusions apply in
+// both cases.
+func (p *Parser) selectServiceAccounts(ctx context.Context, field string, s *rbaccontrollerv1.Subject) error {
+	ctx, span := tracing.Tracer().Start(ctx, "SelectServiceAccounts")
+	defer span.End()
+
+	selector, err := metav1.LabelSelectorAsSelector(s.ServiceAccountSelector)
+	if err != nil {
+		return &Error{Kind: InvalidSelector, Field: field + ".serviceAccountSelector",
+			Err: fmt.Errorf("failed to extract a selector from the label selector %w", err)}
+	}
+	var ns []string
+	selected := len(s.Namespaces) > 0 || s.NamespaceMatchExpression != "" ||
+		len(s.NameSpaceSelector.MatchLabels) > 0 || len(s.NameSpaceSelector.MatchExpressions) > 0
+	if selected {
+		if ns, err = p.selectNamespaces(ctx, field, s.Namespaces, &s.NameSpaceSelector,
+			s.NamespaceMatchExpression, s.ExcludeNamespaces, &s.ExcludeNamespaceSelector); err != nil {
+			return err
+		}
+		if len(ns) == 0 {
+			return nil
+		}
+	}
+
+	sas, err := p.listServiceAccounts(ctx, selector)
+	if err != nil {
+		return atField(err, field+".serviceAccountSelector")
+	}
+	if !selected {
+		for _, sa := range sas {
+			ns = append(ns, sa.Namespace)
+		}
+		if ns, err = p.excludeNamespaces(ctx, ns, s.ExcludeNamespaces, &s.ExcludeNamespaceSelector); err != nil {
+			return atField(err, field+".excludeNamespaceSelector")
+		}
+	}
+	matched := 0
+	for _, sa := range sas {
+		if slices.Contains(ns, sa.Namespace) {
+			p.addServiceAccount(sa.Name, sa.Namespace, s, true)
+			matched++
+		}
+	}
+	span.SetAttributes(attribute.Int("serviceAccounts", matched))
+	return nil
+}
+
+// IsGlob reports whether the name of a ServiceAccount subject is a shell
+// pattern , matched against the names of the existing ServiceAccounts.
+func IsGlob(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// CompileGlob checks the shell pattern , in the syntax of path.Match.
+func CompileGlob(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return &Error{Kind: InvalidSelector, Err: fmt.Errorf("invalid pattern %q: %w", pattern, err)}
+	}
+	return nil
+}
+
+// globServiceAccounts returns the existing ServiceAccounts whose name matches
+// one of the patterns among names , in every namespace.
+func (p *Parser) globServiceAccounts(ctx context.Context, names []string) ([]metav1.PartialObjectMetadata, error) {
+	var patterns []string
+	for _, name := range names {
+		if !IsGlob(name) {
+			continue
+		}
+		if err := CompileGlob(name); err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, name)
+	}
+	sas, err := p.listServiceAccounts(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(sas, func(sa metav1.PartialObjectMetadata) bool {
+		return !slices.ContainsFunc(patterns, func(pattern string) bool {
+			matched, _ := path.Match(pattern, sa.Name)
+			return matched
 		})
+	}), nil
+}
+
+// allServiceAccounts adds the ServiceAccounts of the namespaces selected by
+// the AllServiceAccounts subject at field , through the group of each
+// namespace's ServiceAccounts or the existing ones depending on its rendering.
+func (p *Parser) allServiceAccounts(ctx context.Context, field string, s *rbaccontrollerv1.Subject) error {
+	ns, err := p.selectNamespaces(ctx, field, s.Namespaces, &s.NameSpaceSelector,
+		s.NamespaceMatchExpression, s.ExcludeNamespaces, &s.ExcludeNamespaceSelector)
+	if err != nil {
+		return err
+	}
+	if s.Rendering != rbaccontrollerv1.SubjectRenderingEnumerate {
+		for _, n := range ns {
+			p.addSubject(rbacv1.Subject{
+				APIGroup:  RBACApiGroup,
+				Kind:      string(rbaccontrollerv1.Group),
+				Name:      serviceaccount.MakeNamespaceGroupName(n),
+				Namespace: "",
+			})
+		}
+		return nil
+	}
+	if len(ns) == 0 {
+		return nil
+	}
+	sas, err := p.listServiceAccounts(ctx, labels.Everything())
+	if err != nil {
+		return atField(err, field)
+	}
+	for _, sa := range sas {
+		if slices.Contains(ns, sa.Namespace) {
+			p.addServiceAccount(sa.Name, sa.Namespace, s, true)
+		}
+	}
+	return nil
+}
+
+// listServiceAccounts returns the metadata of the ServiceAccounts matching the
+// selector , sorted by namespace and name so the bindings don't depend on the
+// order they are listed in.
+func (p *Parser) listServiceAccounts(ctx context.Context, selector labels.Selector) ([]metav1.PartialObjectMetadata, error) {
+	sas := &metav1.PartialObjectMetadataList{}
+	sas.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "",
+		Version: "v1",
+		Kind:    "ServiceAccountList",
+	})
+	if err := p.List(ctx, sas, &client.ListOptions{LabelSelector: selector}); err != nil {
+		return nil, &Error{Kind: ListFailed, Err: fmt.Errorf("failed to list serviceaccounts metadata %w", err)}
+	}
+	slices.SortFunc(sas.Items, func(a, b metav1.PartialObjectMetadata) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return sas.Items, nil
+}
+
+// username renders the name of a User subject with the username template.
+func (p *Parser) username(name string) (string, error) {
+	if p.UsernameTemplate == nil {
+		return name, nil
+	}
+	var b strings.Builder
+	if err := p.UsernameTemplate.Execute(&b, map[string]string{"name": name}); err != nil {
+		return "", fmt.Errorf("failed to render the username of %s: %w", name, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// prefixed returns the name of a User or Group subject with the prefix of its
+// kind , names already prefixed are kept so bindings written with the full
+// identity (e.g imported ones) aren't prefixed twice.
+func (p *Parser) prefixed(kind rbaccontrollerv1.SubjectType, name string) string {
+	prefix := p.Prefixes[string(kind)]
+	if strings.HasPrefix(name, prefix) {
+		return name
+	}
+	return prefix + name
+}
+
+// addSubject adds the subject to the subjects of the bindings , unless it is
+// already one of them.
+func (p *Parser) addSubject(subject rbacv1.Subject) {
+	if !slices.Contains(p.Subjects, subject) {
+		p.Subjects = append(p.Subjects, subject)
 	}
 }
 
-func (p *Parser) parseRBs(ctx context.Context, RBACRuleName, BindingName string, RBs []rbaccontrollerv1.RoleBinding, RBAClabels map[string]string, ownerRef []metav1.OwnerReference) error {
-	for _, rb := range RBs {
-		ns, err := p.retrieveNamespaces(ctx, &rb.NameSpaceSelector)
-		ns = append(ns, rb.Namespaces...)
+func (p *Parser) parseCRBs(ctx context.Context, RBACRule metav1.Object, BindingName string, CRBs []rbaccontrollerv1.ClusterRoleBinding, RBACLabels map[string]string, ownerRef []metav1.OwnerReference) error {
+	for j, crb := range CRBs {
+		clusterRoles := []string{crb.ClusterRole}
+		if crb.Bundle != "" {
+			roles, err := p.Bundles.Resolve(crb.Bundle)
+			if err != nil {
+				return err
+			}
+			clusterRoles = roles
+		}
+		if crb.RoleTemplate != "" {
+			cr, err := p.resolveTemplate(crb.RoleTemplate)
+			if err != nil {
+				return err
+			}
+			clusterRoles = []string{cr}
+		}
+		// ClusterRoles limited to the selected namespaces are granted through
+		// RoleBindings , named like the ones of roleBindings entries.
+		if crb.Scope == rbaccontrollerv1.ClusterRoleBindingScopeSelectedNamespaces {
+			selector := crb.NameSpaceSelector
+			if selector == nil {
+				selector = &metav1.LabelSelector{}
+			}
+			ns, err := p.selectNamespaces(ctx, fmt.Sprintf("clusterRoleBindings[%d]", j), nil, selector, "", nil, &metav1.LabelSelector{})
+			if err != nil {
+				return err
+			}
+			for _, cr := range clusterRoles {
+				for _, n := range ns {
+					p.addRoleBinding(rbacv1.RoleBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            utils.GenerateName(RBACRule.GetUID(), RBACRule.GetName(), BindingName, CRB, cr),
+							Namespace:       n,
+							Labels:          RBACLabels,
+							Annotations:     p.Annotations,
+							OwnerReferences: ownerRef,
+						},
+						Subjects: p.Subjects,
+						RoleRef: rbacv1.RoleRef{
+							APIGroup: RBACApiGroup,
+							Kind:     CRB,
+							Name:     cr,
+						},
+					})
+				}
+			}
+			continue
+		}
+		for _, cr := range clusterRoles {
+			name := utils.GenerateName(RBACRule.GetUID(), RBACRule.GetName(), BindingName, CRB, cr)
+			if slices.ContainsFunc(p.ClusterRoleBindings, func(b rbacv1.ClusterRoleBinding) bool { return b.Name == name }) {
+				continue
+			}
+			p.ClusterRoleBindings = append(p.ClusterRoleBindings, rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            name,
+					Labels:          RBACLabels,
+					Annotations:     p.Annotations,
+					OwnerReferences: ownerRef,
+				},
+				Subjects: p.Subjects,
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: RBACApiGroup,
+					Kind:     CRB,
+					Name:     cr,
+				},
+			})
+		}
+	}
+	return nil
+}
+
+func (p *Parser) parseRBs(ctx context.Context, RBACRule metav1.Object, BindingName string, RBs []rbaccontrollerv1.RoleBinding, RBAClabels map[string]string, ownerRef []metav1.OwnerReference) error {
+	for j, rb := range RBs {
+		ns, err := p.selectNamespaces(ctx, fmt.Sprintf("roleBindings[%d]", j), rb.Namespaces, &rb.NameSpaceSelector,
+			rb.NamespaceMatchExpression, rb.ExcludeNamespaces, &rb.ExcludeNamespaceSelector)
 		if err != nil {
 			return err
 		}
+		var clusterRoles []string
 		if rb.ClusterRole != "" {
+			clusterRoles = append(clusterRoles, rb.ClusterRole)
+		}
+		if rb.Bundle != "" {
+			roles, err := p.Bundles.Resolve(rb.Bundle)
+			if err != nil {
+				return err
+			}
+			clusterRoles = append(clusterRoles, roles...)
+		}
+		if rb.RoleTemplate != "" {
+			cr, err := p.resolveTemplate(rb.RoleTemplate)
+			if err != nil {
+				return err
+			}
+			clusterRoles = append(clusterRoles, cr)
+		}
+		for _, cr := range clusterRoles {
 			for _, n := range ns {
-				p.RoleBindings = append(p.RoleBindings, rbacv1.RoleBinding{
+				p.addRoleBinding(rbacv1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:            utils.GenerateName(RBACRuleName, BindingName, RB, rb.ClusterRole),
+						Name:            utils.GenerateName(RBACRule.GetUID(), RBACRule.GetName(), BindingName, CRB, cr),
 						Namespace:       n,
 						Labels:          RBAClabels,
+						Annotations:     p.Annotations,
 						OwnerReferences: ownerRef,
 					},
 					Subjects: p.Subjects,
 					RoleRef: rbacv1.RoleRef{
 						APIGroup: RBACApiGroup,
 						Kind:     CRB,
-						Name:     rb.ClusterRole,
+						Name:     cr,
 					},
 				})
 			}
 		}
 		if rb.Role != "" {
 			for _, n := range ns {
-				p.RoleBindings = append(p.RoleBindings, rbacv1.RoleBinding{
+				p.addRoleBinding(rbacv1.RoleBinding{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:            utils.GenerateName(RBACRuleName, BindingName, RB, rb.Role),
+						Name:            utils.GenerateName(RBACRule.GetUID(), RBACRule.GetName(), BindingName, RB, rb.Role),
 						Namespace:       n,
 						Labels:          RBAClabels,
+						Annotations:     p.Annotations,
 						OwnerReferences: ownerRef,
 					},
 					Subjects: p.Subjects,
@@ -155,27 +534,140 @@ func (p *Parser) parseRBs(ctx context.Context, RBACRuleName, BindingName string,
 	return nil
 }
 
+// resolveTemplate returns the ClusterRole generated from the role template.
+func (p *Parser) resolveTemplate(name string) (string, error) {
+	cr, ok := p.Templates[name]
+	if !ok {
+		return "", fmt.Errorf("role template %s is not defined", name)
+	}
+	return cr, nil
+}
+
+// addRoleBinding adds the RoleBinding , unless one with the same name was
+// already generated in its namespace.
+func (p *Parser) addRoleBinding(rb rbacv1.RoleBinding) {
+	if !slices.ContainsFunc(p.RoleBindings, func(o rbacv1.RoleBinding) bool {
+		return o.Namespace == rb.Namespace && o.Name == rb.Name
+	}) {
+		p.RoleBindings = append(p.RoleBindings, rb)
+	}
+}
+
+// selectNamespaces returns the namespaces selected by a subject or RoleBinding
+// at field , sorted and without duplicates. Selections matching no namespace
+// are recorded in Unmatched.
+func (p *Parser) selectNamespaces(ctx context.Context, field string, names []string, ls *metav1.LabelSelector, expr string, excluded []string, excludedLs *metav1.LabelSelector) ([]string, error) {
+	ns, err := p.retrieveNamespaces(ctx, ls)
+	if err != nil {
+		return nil, atField(err, field+".nameSpaceSelector")
+	}
+	ns = append(ns, names...)
+	matched, err := p.matchNamespaces(ctx, expr)
+	if err != nil {
+		return nil, atField(err, field+".namespaceMatchExpression")
+	}
+	ns = append(ns, matched...)
+	ns, err = p.excludeNamespaces(ctx, ns, excluded, excludedLs)
+	if err != nil {
+		return nil, atField(err, field+".excludeNamespaceSelector")
+	}
+	if len(ns) == 0 {
+		p.Unmatched = append(p.Unmatched, &Error{Kind: EmptyResult, Field: field, Err: errors.New("no namespace matched")})
+	}
+	return uniqueNamespaces(ns), nil
+}
+
+// uniqueNamespaces sorts the namespaces and removes the duplicates , so the
+// generated objects don't depend on how the namespaces were selected.
+func uniqueNamespaces(ns []string) []string {
+	slices.Sort(ns)
+	return slices.Compact(ns)
+}
+
+// CompileMatchExpression compiles a namespaceMatchExpression , a regular
+// expression matching the names of namespaces as a whole.
+func CompileMatchExpression(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, &Error{Kind: InvalidSelector, Err: fmt.Errorf("invalid namespaceMatchExpression %q: %w", expr, err)}
+	}
+	return re, nil
+}
+
+// matchNamespaces returns the namespaces whose name matches the expression ,
+// none when it is empty. Namespaces are listed once per cache.
+func (p *Parser) matchNamespaces(ctx context.Context, expr string) ([]string, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	re, err := CompileMatchExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	// every namespace is matched by the empty selector.
+	all, err := p.listNamespaces(ctx, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(all, func(n string) bool { return !re.MatchString(n) }), nil
+}
+
+// excludeNamespaces removes from ns the excluded namespaces and the ones
+// matching the exclusion selector.
+func (p *Parser) excludeNamespaces(ctx context.Context, ns []string, excluded []string, ls *metav1.LabelSelector) ([]string, error) {
+	matched, err := p.retrieveNamespaces(ctx, ls)
+	if err != nil {
+		return nil, err
+	}
+	excluded = append(matched, excluded...)
+	if len(excluded) == 0 {
+		return ns, nil
+	}
+	return slices.DeleteFunc(ns, func(n string) bool {
+		return slices.Contains(excluded, n)
+	}), nil
+}
+
 func (p *Parser) retrieveNamespaces(ctx context.Context, ls *metav1.LabelSelector) ([]string, error) {
+	if len(ls.MatchExpressions) == 0 && ls.MatchLabels == nil {
+		return []string{}, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return nil, &Error{Kind: InvalidSelector, Err: fmt.Errorf("failed to extract a selector from the label selector %w", err)}
+	}
+	return p.listNamespaces(ctx, selector)
+}
+
+// listNamespaces returns the namespaces matching the selector , through the
+// cache when there is one.
+func (p *Parser) listNamespaces(ctx context.Context, selector labels.Selector) ([]string, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "RetrieveNamespaces")
+	defer span.End()
+
+	// callers modify the returned namespaces , the cached ones are copied.
+	if cached, ok := p.Namespaces[selector.String()]; ok {
+		span.SetAttributes(attribute.Int("namespaces", len(cached)), attribute.Bool("cached", true))
+		return slices.Clone(cached), nil
+	}
 	nsMetaData := &metav1.PartialObjectMetadataList{}
 	nsMetaData.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "",
 		Version: "v1",
 		Kind:    "Namespace",
 	})
-	if len(ls.MatchExpressions) > 0 || ls.MatchLabels != nil {
-		selector, err := metav1.LabelSelectorAsSelector(ls)
-		if err != nil {
-			return nil, fmt.Errorf("failed to extract a selector from the label selector %w", err)
-		}
-		if err := p.List(ctx, nsMetaData, &client.ListOptions{
-			LabelSelector: selector,
-		}); err != nil {
-			return nil, fmt.Errorf("failed to list namespaces metadata %w", err)
-		}
+	if err := p.List(ctx, nsMetaData, &client.ListOptions{
+		LabelSelector: selector,
+	}); err != nil {
+		return nil, &Error{Kind: ListFailed, Err: fmt.Errorf("failed to list namespaces metadata %w", err)}
 	}
 	ns := []string{}
 	for _, i := range nsMetaData.Items {
 		ns = append(ns, i.Name)
 	}
+	if p.Namespaces != nil {
+		p.Namespaces[selector.String()] = slices.Clone(ns)
+	}
+	span.SetAttributes(attribute.Int("namespaces", len(ns)))
 	return ns, nil
 }
@@ -0,0 +1,551 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"text/template"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func serviceAccount(namespace, name string, labels map[string]string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+}
+
+func namespacesOf(rbs []rbacv1.RoleBinding) []string {
+	ns := []string{}
+	for _, rb := range rbs {
+		ns = append(ns, rb.Namespace)
+	}
+	return ns
+}
+
+var _ = Describe("Parser", func() {
+	ctx := context.Background()
+	team := map[string]string{"team": "x"}
+	rule := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "rule", UID: "6f1c1d1e"}}
+	var p *Parser
+
+	BeforeEach(func() {
+		p = &Parser{
+			Client: fake.NewClientBuilder().WithObjects(
+				namespace("team-x-dev", team),
+				namespace("team-x-staging", team),
+				namespace("team-x-prod", map[string]string{"team": "x", "env": "prod"}),
+				namespace("other", nil),
+			).Build(),
+		}
+	})
+
+	It("excludes namespaces from RoleBindings", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:     "team-x",
+			Subjects: []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.User, Name: "alice"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{
+				ClusterRole:       "edit",
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+				ExcludeNamespaces: []string{"team-x-staging"},
+				ExcludeNamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"env": "prod"},
+				},
+			}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(namespacesOf(p.RoleBindings)).To(ConsistOf("team-x-dev"))
+	})
+
+	It("excludes namespaces from ServiceAccount subjects", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-x",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:              rbaccontrollerv1.ServiceAccount,
+				Name:              "deployer",
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+				ExcludeNamespaces: []string{"team-x-prod"},
+			}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.ServiceAccounts).To(HaveLen(2))
+		for _, sa := range p.ServiceAccounts {
+			Expect(sa.Namespace).To(BeElementOf("team-x-dev", "team-x-staging"))
+		}
+	})
+
+	It("expands role bundles", func() {
+		p.Bundles = map[string][]string{"debugger": {"view", "pod-debugger"}}
+		b := &rbaccontrollerv1.Binding{
+			Name:                "debug",
+			Subjects:            []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.Group, Name: "sre"}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{Bundle: "debugger"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{
+				Bundle:     "debugger",
+				Namespaces: []string{"other"},
+			}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.ClusterRoleBindings).To(HaveLen(2))
+		Expect(p.ClusterRoleBindings[0].RoleRef.Name).To(Equal("view"))
+		Expect(p.ClusterRoleBindings[1].RoleRef.Name).To(Equal("pod-debugger"))
+		Expect(p.RoleBindings).To(HaveLen(2))
+	})
+
+	It("fails on an undefined role bundle", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:                "debug",
+			Subjects:            []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.Group, Name: "sre"}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{Bundle: "debugger"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(MatchError("role bundle debugger is not defined"))
+	})
+
+	It("lists the namespaces of a selector once per cache", func() {
+		lists := 0
+		c := fake.NewClientBuilder().WithObjects(
+			namespace("team-x-dev", team),
+			namespace("team-x-staging", team),
+		).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists++
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()
+		b := &rbaccontrollerv1.Binding{
+			Name:     "team-x",
+			Subjects: []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.User, Name: "alice"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{
+				ClusterRole:       "edit",
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+				ExcludeNamespaces: []string{"team-x-staging"},
+			}, {
+				ClusterRole:       "view",
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+			}},
+		}
+
+		cache := NamespaceCache{}
+		for range 2 {
+			p := &Parser{Client: c, Namespaces: cache}
+			Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+			Expect(namespacesOf(p.RoleBindings)).To(ConsistOf("team-x-dev", "team-x-dev", "team-x-staging"))
+		}
+		Expect(lists).To(Equal(1))
+	})
+
+	It("generates names unique per rule , binding and role", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:     strings.Repeat("b", 300),
+			Subjects: []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.User, Name: "alice"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{
+				{ClusterRole: "view", Namespaces: []string{"other"}},
+				{Role: "view", Namespaces: []string{"other"}},
+			},
+		}
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.RoleBindings).To(HaveLen(2))
+		Expect(p.RoleBindings[0].Name).NotTo(Equal(p.RoleBindings[1].Name))
+		Expect(len(p.RoleBindings[0].Name)).To(BeNumerically("<=", 253))
+
+		// a rule recreated with the same name gets new names.
+		recreated := &Parser{Client: p.Client}
+		Expect(recreated.Parse(ctx, b, nil, nil, &rbaccontrollerv1.RBACRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rule", UID: "0d4b2a9c"},
+		})).To(Succeed())
+		Expect(recreated.RoleBindings[0].Name).NotTo(Equal(p.RoleBindings[0].Name))
+	})
+
+	It("sets the given labels and annotations on the bindings", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:                "oncall",
+			Subjects:            []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.Group, Name: "sre"}},
+			RoleBindings:        []rbaccontrollerv1.RoleBinding{{Role: "edit", Namespaces: []string{"other"}}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+		p.Annotations = map[string]string{"ticket": "OPS-42"}
+		Expect(p.Parse(ctx, b, team, nil, rule)).To(Succeed())
+		Expect(p.RoleBindings[0].Labels).To(Equal(team))
+		Expect(p.RoleBindings[0].Annotations).To(Equal(p.Annotations))
+		Expect(p.ClusterRoleBindings[0].Labels).To(Equal(team))
+		Expect(p.ClusterRoleBindings[0].Annotations).To(Equal(p.Annotations))
+	})
+
+	It("matches the names of namespaces against the match expression", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-x",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:                     rbaccontrollerv1.ServiceAccount,
+				Name:                     "deployer",
+				NamespaceMatchExpression: "team-x-(dev|staging)",
+			}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{
+				ClusterRole:              "edit",
+				NamespaceMatchExpression: "team-x-.*",
+				ExcludeNamespaces:        []string{"team-x-prod"},
+			}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(namespacesOf(p.RoleBindings)).To(ConsistOf("team-x-dev", "team-x-staging"))
+		Expect(p.ServiceAccounts).To(HaveLen(2))
+	})
+
+	It("fails on an invalid match expression", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:         "team-x",
+			Subjects:     []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.User, Name: "alice"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{ClusterRole: "edit", NamespaceMatchExpression: "team-("}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(MatchError(ContainSubstring(`invalid namespaceMatchExpression "team-("`)))
+	})
+
+	It("deduplicates subjects , namespaces and roles", func() {
+		p.Bundles = map[string][]string{"debugger": {"view", "pod-debugger"}}
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-x",
+			Subjects: []rbaccontrollerv1.Subject{
+				{Kind: rbaccontrollerv1.User, Name: "alice"},
+				{Kind: rbaccontrollerv1.User, Name: "alice"},
+				{
+					Kind:              rbaccontrollerv1.ServiceAccount,
+					Name:              "deployer",
+					NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+					Namespaces:        []string{"team-x-dev"},
+				},
+				{Kind: rbaccontrollerv1.ServiceAccount, Name: "deployer", Namespaces: []string{"team-x-prod"}},
+			},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}, {Bundle: "debugger"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{
+				ClusterRole:       "view",
+				Bundle:            "debugger",
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+				Namespaces:        []string{"team-x-staging", "team-x-dev"},
+			}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(HaveLen(4))
+		Expect(p.ServiceAccounts).To(HaveLen(3))
+		Expect(p.ClusterRoleBindings).To(HaveLen(2))
+		Expect(namespacesOf(p.RoleBindings)).To(Equal([]string{
+			"team-x-dev", "team-x-prod", "team-x-staging",
+			"team-x-dev", "team-x-prod", "team-x-staging",
+		}))
+	})
+
+	It("prefixes the names of Users and Groups", func() {
+		p.Prefixes = map[string]string{"User": "oidc:", "Group": "ldap:"}
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-x",
+			Subjects: []rbaccontrollerv1.Subject{
+				{Kind: rbaccontrollerv1.User, Name: "alice"},
+				{Kind: rbaccontrollerv1.User, Name: "oidc:bob"},
+				{Kind: rbaccontrollerv1.Group, Name: "sre"},
+				{Kind: rbaccontrollerv1.ServiceAccount, Name: "deployer", Namespaces: []string{"team-x-dev"}},
+			},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		names := []string{}
+		for _, s := range p.ClusterRoleBindings[0].Subjects {
+			names = append(names, s.Name)
+		}
+		Expect(names).To(Equal([]string{"oidc:alice", "oidc:bob", "ldap:sre", "deployer"}))
+	})
+
+	It("renders the names of Users with the username template", func() {
+		t, err := template.New("username").Parse(`{{ .name }}@corp.example.com`)
+		Expect(err).NotTo(HaveOccurred())
+		p.UsernameTemplate = t
+		p.Prefixes = map[string]string{"User": "oidc:"}
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-x",
+			Subjects: []rbaccontrollerv1.Subject{
+				{Kind: rbaccontrollerv1.User, Name: "alice"},
+				{Kind: rbaccontrollerv1.Group, Name: "sre"},
+			},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(ConsistOf(
+			HaveField("Name", "oidc:alice@corp.example.com"),
+			HaveField("Name", "sre"),
+		))
+	})
+
+	It("reports the field and kind of errors", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:     "team-x",
+			Subjects: []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.User, Name: "alice"}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{
+				{ClusterRole: "view", Namespaces: []string{"other"}},
+				{ClusterRole: "edit", NameSpaceSelector: metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Near"}},
+				}},
+			},
+		}
+
+		err := p.Parse(ctx, b, nil, nil, rule)
+		Expect(KindOf(err)).To(Equal(InvalidSelector))
+		Expect(err).To(MatchError(HavePrefix("roleBindings[1].nameSpaceSelector: ")))
+
+		p.Client = fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				return errors.New("unavailable")
+			},
+		}).Build()
+		b.RoleBindings[1].NameSpaceSelector = metav1.LabelSelector{MatchLabels: team}
+		err = p.Parse(ctx, b, nil, nil, rule)
+		Expect(KindOf(err)).To(Equal(ListFailed))
+		Expect(KindOf(errors.New("unavailable"))).To(BeEmpty())
+	})
+
+	It("records the selections matching no namespace", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-y",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:              rbaccontrollerv1.ServiceAccount,
+				Name:              "deployer",
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "y"}},
+			}},
+			RoleBindings: []rbaccontrollerv1.RoleBinding{{ClusterRole: "view", Namespaces: []string{"other"}}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Unmatched).To(HaveLen(1))
+		Expect(p.Unmatched[0].Kind).To(Equal(EmptyResult))
+		Expect(p.Unmatched[0].Field).To(Equal("subjects[0]"))
+	})
+
+	It("grants ClusterRoles in the selected namespaces", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name:     "team-x",
+			Subjects: []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.Group, Name: "sre"}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{
+				ClusterRole:       "admin",
+				Scope:             rbaccontrollerv1.ClusterRoleBindingScopeSelectedNamespaces,
+				NameSpaceSelector: &metav1.LabelSelector{MatchLabels: team},
+			}, {
+				ClusterRole: "view",
+			}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.ClusterRoleBindings).To(HaveLen(1))
+		Expect(p.ClusterRoleBindings[0].RoleRef.Name).To(Equal("view"))
+		Expect(namespacesOf(p.RoleBindings)).To(Equal([]string{"team-x-dev", "team-x-prod", "team-x-staging"}))
+		for _, rb := range p.RoleBindings {
+			Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: RBACApiGroup, Kind: CRB, Name: "admin"}))
+		}
+	})
+
+	It("binds the ClusterRoles generated from role templates", func() {
+		p.Templates = map[string]string{"log-reader": "rule-template-ClusterRole-log-reader-0a1b2c3d"}
+		b := &rbaccontrollerv1.Binding{
+			Name:                "logs",
+			Subjects:            []rbaccontrollerv1.Subject{{Kind: rbaccontrollerv1.Group, Name: "sre"}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{RoleTemplate: "log-reader"}},
+			RoleBindings:        []rbaccontrollerv1.RoleBinding{{RoleTemplate: "log-reader", Namespaces: []string{"other"}}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.ClusterRoleBindings[0].RoleRef.Name).To(Equal("rule-template-ClusterRole-log-reader-0a1b2c3d"))
+		Expect(p.RoleBindings[0].RoleRef).To(Equal(rbacv1.RoleRef{
+			APIGroup: RBACApiGroup, Kind: CRB, Name: "rule-template-ClusterRole-log-reader-0a1b2c3d",
+		}))
+
+		b.RoleBindings[0].RoleTemplate = "deployer"
+		Expect((&Parser{Client: p.Client, Templates: p.Templates}).Parse(ctx, b, nil, nil, rule)).To(MatchError("role template deployer is not defined"))
+	})
+
+	It("binds the existing ServiceAccounts matched by the selector", func() {
+		operator := map[string]string{"app.kubernetes.io/managed-by": "operator"}
+		p.Client = fake.NewClientBuilder().WithObjects(
+			namespace("team-x-dev", team),
+			namespace("team-x-prod", map[string]string{"team": "x", "env": "prod"}),
+			namespace("other", nil),
+			serviceAccount("team-x-dev", "worker", operator),
+			serviceAccount("team-x-dev", "default", nil),
+			serviceAccount("team-x-prod", "worker", operator),
+			serviceAccount("other", "agent", operator),
+		).Build()
+		b := &rbaccontrollerv1.Binding{
+			Name: "operator",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:                     rbaccontrollerv1.ServiceAccount,
+				ServiceAccountSelector:   &metav1.LabelSelector{MatchLabels: operator},
+				ExcludeNamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(Equal([]rbacv1.Subject{
+			{Kind: "ServiceAccount", Name: "agent", Namespace: "other"},
+			{Kind: "ServiceAccount", Name: "worker", Namespace: "team-x-dev"},
+		}))
+		Expect(p.ServiceAccounts).To(HaveLen(2))
+		Expect(p.ServiceAccounts[0].Subject).To(BeIdenticalTo(&b.Subjects[0]))
+
+		// the selection is limited to the namespaces of the subject.
+		p = &Parser{Client: p.Client}
+		b.Subjects[0].NameSpaceSelector = metav1.LabelSelector{MatchLabels: team}
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(Equal([]rbacv1.Subject{{Kind: "ServiceAccount", Name: "worker", Namespace: "team-x-dev"}}))
+	})
+
+	It("fails on an invalid ServiceAccount selector", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name: "operator",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind: rbaccontrollerv1.ServiceAccount,
+				ServiceAccountSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Near"}},
+				},
+			}},
+		}
+
+		err := p.Parse(ctx, b, nil, nil, rule)
+		Expect(KindOf(err)).To(Equal(InvalidSelector))
+		Expect(err).To(MatchError(HavePrefix("subjects[0].serviceAccountSelector: ")))
+	})
+
+	It("binds every ServiceAccount of the namespaces through their group", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name: "ci",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:              rbaccontrollerv1.AllServiceAccounts,
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+				ExcludeNamespaces: []string{"team-x-prod"},
+			}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "registry-puller"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(Equal([]rbacv1.Subject{
+			{APIGroup: RBACApiGroup, Kind: "Group", Name: "system:serviceaccounts:team-x-dev"},
+			{APIGroup: RBACApiGroup, Kind: "Group", Name: "system:serviceaccounts:team-x-staging"},
+		}))
+		Expect(p.ServiceAccounts).To(BeEmpty())
+	})
+
+	It("enumerates every ServiceAccount of the namespaces", func() {
+		p.Client = fake.NewClientBuilder().WithObjects(
+			namespace("team-x-dev", team),
+			namespace("other", nil),
+			serviceAccount("team-x-dev", "default", nil),
+			serviceAccount("team-x-dev", "builder", nil),
+			serviceAccount("other", "default", nil),
+		).Build()
+		b := &rbaccontrollerv1.Binding{
+			Name: "ci",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:              rbaccontrollerv1.AllServiceAccounts,
+				NameSpaceSelector: metav1.LabelSelector{MatchLabels: team},
+				Rendering:         rbaccontrollerv1.SubjectRenderingEnumerate,
+			}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "registry-puller"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(Equal([]rbacv1.Subject{
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "team-x-dev"},
+			{Kind: "ServiceAccount", Name: "default", Namespace: "team-x-dev"},
+		}))
+		for _, sa := range p.ServiceAccounts {
+			Expect(sa.Listed).To(BeTrue())
+		}
+	})
+
+	It("expands the names of subjects", func() {
+		b := &rbaccontrollerv1.Binding{
+			Name: "team-x",
+			Subjects: []rbaccontrollerv1.Subject{
+				{Kind: rbaccontrollerv1.User, Names: []string{"alice", "bob"}},
+				{Kind: rbaccontrollerv1.ServiceAccount, Names: []string{"deployer", "builder"}, Namespaces: []string{"team-x-dev", "other"}},
+			},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.Subjects).To(Equal([]rbacv1.Subject{
+			{APIGroup: RBACApiGroup, Kind: "User", Name: "alice"},
+			{APIGroup: RBACApiGroup, Kind: "User", Name: "bob"},
+			{Kind: "ServiceAccount", Name: "deployer", Namespace: "other"},
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "other"},
+			{Kind: "ServiceAccount", Name: "deployer", Namespace: "team-x-dev"},
+			{Kind: "ServiceAccount", Name: "builder", Namespace: "team-x-dev"},
+		}))
+		Expect(p.ServiceAccounts).To(HaveLen(4))
+		Expect(SubjectCount(b.Subjects)).To(Equal(4))
+	})
+
+	It("binds the existing ServiceAccounts matching name patterns", func() {
+		p.Client = fake.NewClientBuilder().WithObjects(
+			namespace("team-x-dev", team),
+			namespace("other", nil),
+			serviceAccount("team-x-dev", "ci-runner-1", nil),
+			serviceAccount("team-x-dev", "ci-runner-2", nil),
+			serviceAccount("team-x-dev", "default", nil),
+			serviceAccount("other", "ci-runner-1", nil),
+		).Build()
+		b := &rbaccontrollerv1.Binding{
+			Name: "ci",
+			Subjects: []rbaccontrollerv1.Subject{{
+				Kind:       rbaccontrollerv1.ServiceAccount,
+				Names:      []string{"ci-runner-*", "deployer"},
+				Namespaces: []string{"team-x-dev"},
+			}},
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		}
+
+		Expect(p.Parse(ctx, b, nil, nil, rule)).To(Succeed())
+		Expect(p.ServiceAccounts).To(Equal([]ServiceAccount{
+			{Name: "deployer", Namespace: "team-x-dev", Subject: &b.Subjects[0]},
+			{Name: "ci-runner-1", Namespace: "team-x-dev", Subject: &b.Subjects[0], Listed: true},
+			{Name: "ci-runner-2", Namespace: "team-x-dev", Subject: &b.Subjects[0], Listed: true},
+		}))
+
+		b.Subjects[0].Names = []string{"ci-runner-["}
+		err := (&Parser{Client: p.Client}).Parse(ctx, b, nil, nil, rule)
+		Expect(KindOf(err)).To(Equal(InvalidSelector))
+		Expect(err).To(MatchError(HavePrefix("subjects[0].names: ")))
+		Expect(IsGlob("deployer")).To(BeFalse())
+	})
+})
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy publishes ValidatingAdmissionPolicies enforcing the checks of
+// the RBACRule webhook that can be expressed in CEL , for clusters that can't
+// run webhooks.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/config"
+)
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch;create;update
+
+// Publisher creates or updates a ValidatingAdmissionPolicy , and its binding ,
+// validating RBACRules. Checks relying on the current time (e.g start times in
+// the past) or on other objects are left to the webhook.
+type Publisher struct {
+	Client client.Client
+	Log    logr.Logger
+	Config *config.Config
+	// Name of the policy and of its binding.
+	Name string
+
+	// How long to wait before publishing again after a failure.
+	RetryInterval time.Duration
+}
+
+// Start implements manager.Runnable , the policy is published , retrying until
+// it succeeds , and published again each time the config changes.
+func (p *Publisher) Start(ctx context.Context) error {
+	changed := make(chan struct{}, 1)
+	p.Config.OnChange(func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+			// a publication is already pending.
+		}
+	})
+	ticker := time.NewTicker(p.RetryInterval)
+	defer ticker.Stop()
+	for {
+		if err := p.Publish(ctx); err != nil {
+			p.Log.Error(err, "Failed to publish the admission policy", "policy", p.Name)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			case <-changed:
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+// Publish creates or updates the policy and its binding.
+func (p *Publisher) Publish(ctx context.Context) error {
+	vap := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: p.Name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, vap, func() error {
+		vap.Spec = p.PolicySpec()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to publish ValidatingAdmissionPolicy %s: %w", p.Name, err)
+	}
+
+	binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: p.Name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, p.Client, binding, func() error {
+		binding.Spec = admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName:        p.Name,
+			ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to publish ValidatingAdmissionPolicyBinding %s: %w", p.Name, err)
+	}
+	p.Log.Info("Published the admission policy", "policy", p.Name)
+	return nil
+}
+
+// PolicySpec returns the spec of the policy.
+func (p *Publisher) PolicySpec() admissionregistrationv1.ValidatingAdmissionPolicySpec {
+	fail := admissionregistrationv1.Fail
+	validations := []admissionregistrationv1.Validation{{
+		Expression: "!has(object.spec.startTime) || !has(object.spec.endTime) || " +
+			"timestamp(object.spec.startTime) <= timestamp(object.spec.endTime)",
+		Message: "start time should not be higher than end time",
+		Reason:  ptr(metav1.StatusReasonInvalid),
+	}}
+	if protected := p.Config.GetProtectedNamespaces(); len(protected) > 0 {
+		quoted := make([]string, 0, len(protected))
+		for _, ns := range protected {
+			quoted = append(quoted, strconv.Quote(ns))
+		}
+		notProtected := fmt.Sprintf("!has(%%[1]s.namespaces) || %%[1]s.namespaces.all(n, !(n in [%s]))", strings.Join(quoted, ", "))
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: fmt.Sprintf("object.spec.bindings.all(b, b.subjects.all(s, %s) && "+
+				"(!has(b.roleBindings) || b.roleBindings.all(rb, %s)))",
+				fmt.Sprintf(notProtected, "s"), fmt.Sprintf(notProtected, "rb")),
+			Message: "namespaces " + strings.Join(protected, ", ") + " are protected",
+			Reason:  ptr(metav1.StatusReasonForbidden),
+		})
+	}
+	if allowedRoles := p.Config.GetAllowedClusterRoles(); len(allowedRoles) > 0 {
+		var allowed []string
+		for _, r := range allowedRoles {
+			if prefix, found := strings.CutSuffix(r, "*"); found {
+				allowed = append(allowed, fmt.Sprintf("%%[1]s.startsWith(%s)", strconv.Quote(prefix)))
+			} else {
+				allowed = append(allowed, fmt.Sprintf("%%[1]s == %s", strconv.Quote(r)))
+			}
+		}
+		// the roles the old version of a rule binds aren't checked again ,
+		// like the webhook does.
+		bound := "oldObject != null && oldObject.spec.bindings.exists(ob, " +
+			"(has(ob.clusterRoleBindings) && ob.clusterRoleBindings.exists(o, has(o.clusterRole) && o.clusterRole == %[1]s)) || " +
+			"(has(ob.roleBindings) && ob.roleBindings.exists(o, has(o.clusterRole) && o.clusterRole == %[1]s)))"
+		check := "!has(%[1]s) || " + strings.Join(append(allowed, bound), " || ")
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: fmt.Sprintf("object.spec.bindings.all(b, "+
+				"(!has(b.clusterRoleBindings) || b.clusterRoleBindings.all(crb, %s)) && "+
+				"(!has(b.roleBindings) || b.roleBindings.all(rb, %s)))",
+				fmt.Sprintf(check, "crb.clusterRole"), fmt.Sprintf(check, "rb.clusterRole")),
+			Message: "rules can only bind the ClusterRoles " + strings.Join(allowedRoles, ", "),
+			Reason:  ptr(metav1.StatusReasonForbidden),
+		})
+	}
+	if p.Config.RequiresJustification() {
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: `has(object.spec.justification) && object.spec.justification.matches('\\S')`,
+			Message:    "a justification is required",
+			Reason:     ptr(metav1.StatusReasonInvalid),
+		})
+	}
+	if pattern := p.Config.GetTicketURLPattern(); pattern != nil {
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: fmt.Sprintf("has(object.spec.ticketURL) && object.spec.ticketURL.matches(%s)", strconv.Quote(pattern.String())),
+			Message:    "a ticket URL matching " + pattern.String() + " is required",
+			Reason:     ptr(metav1.StatusReasonInvalid),
+		})
+	}
+
+	return admissionregistrationv1.ValidatingAdmissionPolicySpec{
+		FailurePolicy: &fail,
+		MatchConstraints: &admissionregistrationv1.MatchResources{
+			ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+				RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+					Operations: []admissionregistrationv1.OperationType{
+						admissionregistrationv1.Create,
+						admissionregistrationv1.Update,
+					},
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{rbaccontrollerv1.GroupVersion.Group},
+						APIVersions: []string{rbaccontrollerv1.GroupVersion.Version},
+						Resources:   []string{"rbacrules"},
+					},
+				},
+			}},
+		},
+		Validations: validations,
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GGh41th/rbac-controller/internal/config"
+)
+
+var _ = Describe("Publisher", func() {
+	ctx := context.Background()
+
+	It("validates the time ordering of rules", func() {
+		p := &Publisher{Name: "rbacrules"}
+
+		spec := p.PolicySpec()
+		Expect(spec.Validations).To(HaveLen(1))
+		Expect(spec.Validations[0].Expression).To(ContainSubstring("timestamp(object.spec.startTime) <= timestamp(object.spec.endTime)"))
+		Expect(spec.MatchConstraints.ResourceRules[0].Resources).To(Equal([]string{"rbacrules"}))
+	})
+
+	It("rejects rules targeting protected namespaces", func() {
+		p := &Publisher{Name: "rbacrules", Config: &config.Config{ProtectedNamespaces: []string{"kube-system", "kube-public"}}}
+
+		spec := p.PolicySpec()
+		Expect(spec.Validations).To(HaveLen(2))
+		Expect(spec.Validations[1].Expression).To(Equal(
+			`object.spec.bindings.all(b, b.subjects.all(s, !has(s.namespaces) || s.namespaces.all(n, !(n in ["kube-system", "kube-public"]))) && ` +
+				`(!has(b.roleBindings) || b.roleBindings.all(rb, !has(rb.namespaces) || rb.namespaces.all(n, !(n in ["kube-system", "kube-public"])))))`))
+	})
+
+	It("only allows the listed ClusterRoles , unless the old rule bound them", func() {
+		p := &Publisher{Name: "rbacrules", Config: &config.Config{AllowedClusterRoles: []string{"view", "team:*"}}}
+
+		spec := p.PolicySpec()
+		Expect(spec.Validations).To(HaveLen(2))
+		Expect(spec.Validations[1].Expression).To(ContainSubstring(
+			`b.clusterRoleBindings.all(crb, !has(crb.clusterRole) || crb.clusterRole == "view" || crb.clusterRole.startsWith("team:") || oldObject != null && `))
+		Expect(spec.Validations[1].Expression).To(ContainSubstring(
+			`b.roleBindings.all(rb, !has(rb.clusterRole) || rb.clusterRole == "view" || rb.clusterRole.startsWith("team:") || oldObject != null && `))
+	})
+
+	It("requires a justification and a ticket URL matching the pattern", func() {
+		p := &Publisher{Name: "rbacrules", Config: &config.Config{
+			RequireJustification: true,
+			TicketURLPattern:     regexp.MustCompile(`^(?:https://jira\.example\.com/browse/OPS-[0-9]+)$`),
+		}}
+
+		spec := p.PolicySpec()
+		Expect(spec.Validations).To(HaveLen(3))
+		Expect(spec.Validations[1].Expression).To(Equal(`has(object.spec.justification) && object.spec.justification.matches('\\S')`))
+		Expect(spec.Validations[2].Expression).To(Equal(
+			`has(object.spec.ticketURL) && object.spec.ticketURL.matches("^(?:https://jira\\.example\\.com/browse/OPS-[0-9]+)$")`))
+	})
+
+	It("creates and updates the policy and its binding", func() {
+		c := fake.NewClientBuilder().Build()
+		p := &Publisher{Client: c, Log: logr.Discard(), Name: "rbacrules"}
+
+		Expect(p.Publish(ctx)).To(Succeed())
+		p.Config = &config.Config{ProtectedNamespaces: []string{"kube-system"}}
+		Expect(p.Publish(ctx)).To(Succeed())
+
+		vap := &admissionregistrationv1.ValidatingAdmissionPolicy{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "rbacrules"}, vap)).To(Succeed())
+		Expect(vap.Spec.Validations).To(HaveLen(2))
+
+		binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: "rbacrules"}, binding)).To(Succeed())
+		Expect(binding.Spec.PolicyName).To(Equal("rbacrules"))
+		Expect(binding.Spec.ValidationActions).To(ConsistOf(admissionregistrationv1.Deny))
+	})
+})
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policyhook asks an external service whether the bindings rendered
+// for a rule may be applied , so organizations can plug their existing policy
+// or approval services into the controller.
+package policyhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Request is posted to the hook , as JSON , for each binding of a rule before
+// its resources are applied.
+type Request struct {
+	// Name of the rule.
+	Rule string `json:"rule"`
+	// Name of the binding within the rule.
+	Binding string `json:"binding"`
+	// Username of the rule's creator , as recorded at admission.
+	Creator       string `json:"creator,omitempty"`
+	Justification string `json:"justification,omitempty"`
+	TicketURL     string `json:"ticketURL,omitempty"`
+	// The ServiceAccounts the binding creates , as namespace/name.
+	ServiceAccounts     []string                    `json:"serviceAccounts,omitempty"`
+	RoleBindings        []rbacv1.RoleBinding        `json:"roleBindings,omitempty"`
+	ClusterRoleBindings []rbacv1.ClusterRoleBinding `json:"clusterRoleBindings,omitempty"`
+}
+
+// Response is the decision of the hook.
+type Response struct {
+	Allowed bool `json:"allowed"`
+	// Why the binding isn't allowed , it is reported in the rule status.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Hook reviews the bindings rendered for rules.
+type Hook interface {
+	Review(ctx context.Context, req Request) (Response, error)
+}
+
+// HTTPHook posts the requests to a URL and decodes its responses. Responses
+// with a non 2xx status are errors , not denials.
+type HTTPHook struct {
+	URL string
+	// Defaults to a client with a 10 seconds timeout.
+	HTTPClient *http.Client
+}
+
+var defaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+func (h *HTTPHook) Review(ctx context.Context, req Request) (Response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return Response{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	c := h.HTTPClient
+	if c == nil {
+		c = defaultHTTPClient
+	}
+	resp, err := c.Do(httpReq)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Response{}, fmt.Errorf("policy hook %s returned %s", httpReq.URL.Host, resp.Status)
+	}
+	decision := Response{}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return Response{}, fmt.Errorf("invalid response from policy hook %s: %w", httpReq.URL.Host, err)
+	}
+	return decision, nil
+}
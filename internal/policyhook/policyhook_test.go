@@ -0,0 +1,85 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("HTTPHook", func() {
+	ctx := context.Background()
+	req := Request{
+		Rule:    "oncall",
+		Binding: "sre",
+		Creator: "alice",
+		ClusterRoleBindings: []rbacv1.ClusterRoleBinding{{
+			ObjectMeta: metav1.ObjectMeta{Name: "oncall-sre"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "sre"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+		}},
+	}
+
+	var (
+		server   *httptest.Server
+		received []Request
+		status   int
+		decision Response
+	)
+
+	BeforeEach(func() {
+		received, status, decision = nil, http.StatusOK, Response{Allowed: true}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := Request{}
+			Expect(json.NewDecoder(r.Body).Decode(&got)).To(Succeed())
+			received = append(received, got)
+			w.WriteHeader(status)
+			Expect(json.NewEncoder(w).Encode(decision)).To(Succeed())
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post the rendered bindings and return the decision", func() {
+		decision = Response{Allowed: false, Reason: "cluster-admin requires an approval"}
+		h := &HTTPHook{URL: server.URL}
+
+		resp, err := h.Review(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).To(Equal(decision))
+		Expect(received).To(HaveLen(1))
+		Expect(received[0].Rule).To(Equal("oncall"))
+		Expect(received[0].ClusterRoleBindings[0].RoleRef.Name).To(Equal("cluster-admin"))
+	})
+
+	It("should fail on a non 2xx status", func() {
+		status = http.StatusInternalServerError
+		h := &HTTPHook{URL: server.URL}
+
+		_, err := h.Review(ctx, req)
+		Expect(err).To(MatchError(ContainSubstring("500")))
+	})
+})
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rego evaluates organization specific Rego policies against
+// RBACRules. The policies are served by an Open Policy Agent instance , e.g a
+// sidecar of the controller loading them from a ConfigMap , which is queried
+// through its data API.
+package rego
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+// Input is the input document the policies are evaluated against.
+type Input struct {
+	// CREATE or UPDATE.
+	Operation string                     `json:"operation"`
+	Object    *rbaccontrollerv1.RBACRule `json:"object"`
+	// The rule before the update.
+	OldObject *rbaccontrollerv1.RBACRule `json:"oldObject,omitempty"`
+	// The requester.
+	UserInfo authenticationv1.UserInfo `json:"userInfo"`
+}
+
+// Evaluator queries a rule of the policies , e.g
+// http://127.0.0.1:8181/v1/data/rbaccontroller/deny , whose value is the set
+// of violations: messages , or objects holding a msg field. The rule being
+// undefined means there is no violation.
+type Evaluator struct {
+	URL string
+	// Defaults to a client with a 5 seconds timeout.
+	HTTPClient *http.Client
+}
+
+var defaultHTTPClient = &http.Client{Timeout: 5 * time.Second}
+
+type request struct {
+	Input Input `json:"input"`
+}
+
+type response struct {
+	Result []json.RawMessage `json:"result"`
+}
+
+type violation struct {
+	Msg string `json:"msg"`
+}
+
+// Violations evaluates the policies against the input and returns the
+// violations , sorted.
+func (e *Evaluator) Violations(ctx context.Context, input Input) ([]string, error) {
+	body, err := json.Marshal(request{Input: input})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	c := e.HTTPClient
+	if c == nil {
+		c = defaultHTTPClient
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("policy evaluation at %s returned %s", req.URL.Host, resp.Status)
+	}
+	result := response{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid policy evaluation result: %w", err)
+	}
+
+	var violations []string
+	for _, raw := range result.Result {
+		var msg string
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			v := violation{}
+			if err := json.Unmarshal(raw, &v); err != nil || v.Msg == "" {
+				return nil, fmt.Errorf("invalid violation %s , expected a message or an object with a msg field", raw)
+			}
+			msg = v.Msg
+		}
+		violations = append(violations, msg)
+	}
+	slices.Sort(violations)
+	return violations, nil
+}
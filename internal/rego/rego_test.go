@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rego
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+var _ = Describe("Evaluator", func() {
+	ctx := context.Background()
+	input := Input{
+		Operation: "CREATE",
+		Object:    &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "oncall"}},
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+	}
+
+	var (
+		server   *httptest.Server
+		received map[string]map[string]any
+		result   string
+	)
+
+	BeforeEach(func() {
+		received = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(json.NewDecoder(r.Body).Decode(&received)).To(Succeed())
+			_, _ = w.Write([]byte(result))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should post the input and return the violations", func() {
+		result = `{"result": ["rules must be named after a team", {"msg": "cluster-admin can't be bound in prod"}]}`
+		e := &Evaluator{URL: server.URL}
+
+		violations, err := e.Violations(ctx, input)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(violations).To(Equal([]string{"cluster-admin can't be bound in prod", "rules must be named after a team"}))
+		Expect(received["input"]).To(HaveKeyWithValue("operation", "CREATE"))
+		Expect(received["input"]["userInfo"]).To(HaveKeyWithValue("username", "alice"))
+	})
+
+	It("should not report violations when the rule is undefined", func() {
+		result = `{}`
+		e := &Evaluator{URL: server.URL}
+
+		Expect(e.Violations(ctx, input)).To(BeEmpty())
+	})
+
+	It("should fail on invalid violations", func() {
+		result = `{"result": [42]}`
+		e := &Evaluator{URL: server.URL}
+
+		_, err := e.Violations(ctx, input)
+		Expect(err).To(MatchError(ContainSubstring("invalid violation 42")))
+	})
+})
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report publishes an RBACReport per subject , summarizing the roles
+// it holds through the rules , so auditors don't have to reverse-engineer the
+// bindings.
+package report
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Publisher periodically aggregates the RoleBindings and ClusterRoleBindings
+// carrying the rule label by subject , and publishes the reports. Reports of
+// subjects that don't hold any role anymore are deleted.
+type Publisher struct {
+	Client client.Client
+	// Reader lists the bindings uncached , e.g the manager's API reader so
+	// every binding of the cluster isn't cached. Client is used when nil.
+	Reader   client.Reader
+	Log      logr.Logger
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=rbac-controller.ggh41th.io,resources=rbacreports,verbs=get;list;watch;create;update;delete
+
+// Start implements manager.Runnable.
+func (p *Publisher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Report(ctx); err != nil {
+			p.Log.Error(err, "Failed to publish the RBAC reports")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report publishes the reports of the subjects holding roles through the
+// rules.
+func (p *Publisher) Report(ctx context.Context) error {
+	reports, err := p.aggregate(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := &rbaccontrollerv1.RBACReportList{}
+	if err := p.Client.List(ctx, existing); err != nil {
+		return err
+	}
+	for i := range existing.Items {
+		report := &existing.Items[i]
+		desired, ok := reports[report.Name]
+		if !ok {
+			if err := p.Client.Delete(ctx, report); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+		delete(reports, report.Name)
+		if equality.Semantic.DeepEqual(report.Subject, desired.Subject) && equality.Semantic.DeepEqual(report.Grants, desired.Grants) {
+			continue
+		}
+		report.Subject, report.Grants = desired.Subject, desired.Grants
+		if err := p.Client.Update(ctx, report); err != nil {
+			return err
+		}
+	}
+	for _, report := range reports {
+		if err := p.Client.Create(ctx, report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregate returns the desired reports , keyed by name.
+func (p *Publisher) aggregate(ctx context.Context) (map[string]*rbaccontrollerv1.RBACReport, error) {
+	labeled, err := labels.NewRequirement(constants.RBACRuleLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	opts := client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*labeled)}
+
+	reports := map[string]*rbaccontrollerv1.RBACReport{}
+	add := func(s rbacv1.Subject, rule string, ref rbacv1.RoleRef, namespace string) {
+		subject := rbaccontrollerv1.ReportSubject{
+			Kind:      rbaccontrollerv1.SubjectType(s.Kind),
+			Name:      s.Name,
+			Namespace: s.Namespace,
+		}
+		name := reportName(subject)
+		report, ok := reports[name]
+		if !ok {
+			report = &rbaccontrollerv1.RBACReport{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Subject:    subject,
+			}
+			reports[name] = report
+		}
+		i := slices.IndexFunc(report.Grants, func(g rbaccontrollerv1.Grant) bool {
+			return g.Rule == rule && g.Kind == ref.Kind && g.Role == ref.Name
+		})
+		if i < 0 {
+			report.Grants = append(report.Grants, rbaccontrollerv1.Grant{Rule: rule, Kind: ref.Kind, Role: ref.Name})
+			i = len(report.Grants) - 1
+		}
+		if namespace == "" {
+			report.Grants[i].ClusterWide = true
+		} else if !slices.Contains(report.Grants[i].Namespaces, namespace) {
+			report.Grants[i].Namespaces = append(report.Grants[i].Namespaces, namespace)
+		}
+	}
+
+	crbs := &rbacv1.ClusterRoleBindingList{}
+	if err := p.reader().List(ctx, crbs, opts); err != nil {
+		return nil, err
+	}
+	for _, crb := range crbs.Items {
+		for _, s := range crb.Subjects {
+			add(s, crb.Labels[constants.RBACRuleLabel], crb.RoleRef, "")
+		}
+	}
+	rbs := &rbacv1.RoleBindingList{}
+	if err := p.reader().List(ctx, rbs, opts); err != nil {
+		return nil, err
+	}
+	for _, rb := range rbs.Items {
+		for _, s := range rb.Subjects {
+			add(s, rb.Labels[constants.RBACRuleLabel], rb.RoleRef, rb.Namespace)
+		}
+	}
+
+	// the grants are sorted so unchanged reports aren't updated.
+	for _, report := range reports {
+		slices.SortFunc(report.Grants, func(a, b rbaccontrollerv1.Grant) int {
+			return cmp.Or(strings.Compare(a.Rule, b.Rule), strings.Compare(a.Kind, b.Kind), strings.Compare(a.Role, b.Role))
+		})
+		for i := range report.Grants {
+			slices.Sort(report.Grants[i].Namespaces)
+		}
+	}
+	return reports, nil
+}
+
+var invalidChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// reportName returns the name of the subject's report: its kind and name ,
+// made valid , followed by a hash telling apart the subjects with the same
+// valid name.
+func reportName(s rbaccontrollerv1.ReportSubject) string {
+	readable := strings.ToLower(string(s.Kind)) + "-"
+	if s.Namespace != "" {
+		readable += s.Namespace + "-"
+	}
+	readable = invalidChars.ReplaceAllString(strings.ToLower(readable+s.Name), "-")
+	if len(readable) > 240 {
+		readable = readable[:240]
+	}
+	sum := sha256.Sum256([]byte(string(s.Kind) + "\x00" + s.Namespace + "\x00" + s.Name))
+	return strings.Trim(readable, ".-") + "-" + hex.EncodeToString(sum[:])[:10]
+}
+
+// reader returns the reader the bindings are listed through.
+func (p *Publisher) reader() client.Reader {
+	if p.Reader != nil {
+		return p.Reader
+	}
+	return p.Client
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Reporter", func() {
+	ctx := context.Background()
+	var c client.Client
+	var r *Publisher
+	alice := rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "alice"}
+	pager := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: "pager", Namespace: "ops"}
+	aliceReport := reportName(rbaccontrollerv1.ReportSubject{Kind: rbaccontrollerv1.User, Name: "alice"})
+
+	rb := func(name, namespace, rule string, subjects ...rbacv1.Subject) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{constants.RBACRuleLabel: rule}},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "oncall-view", Labels: map[string]string{constants.RBACRuleLabel: "oncall"}},
+				Subjects:   []rbacv1.Subject{alice},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			},
+			rb("oncall-edit", "prod", "oncall", alice, pager),
+			rb("oncall-edit", "dev", "oncall", alice),
+			// bindings the controller doesn't manage aren't reported.
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "admin"},
+				Subjects:   []rbacv1.Subject{alice},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+			},
+			&rbaccontrollerv1.RBACReport{ObjectMeta: metav1.ObjectMeta{Name: "user-bob-0123456789"}},
+		).Build()
+		r = &Publisher{Client: c, Reader: c, Log: logr.Discard()}
+	})
+
+	It("publishes a report per subject", func() {
+		Expect(r.Report(ctx)).To(Succeed())
+
+		reports := &rbaccontrollerv1.RBACReportList{}
+		Expect(c.List(ctx, reports)).To(Succeed())
+		Expect(reports.Items).To(HaveLen(2))
+
+		report := &rbaccontrollerv1.RBACReport{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: aliceReport}, report)).To(Succeed())
+		Expect(report.Subject).To(Equal(rbaccontrollerv1.ReportSubject{Kind: rbaccontrollerv1.User, Name: "alice"}))
+		Expect(report.Grants).To(Equal([]rbaccontrollerv1.Grant{
+			{Rule: "oncall", Kind: "ClusterRole", Role: "edit", Namespaces: []string{"dev", "prod"}},
+			{Rule: "oncall", Kind: "ClusterRole", Role: "view", ClusterWide: true},
+		}))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: reportName(rbaccontrollerv1.ReportSubject{
+			Kind: rbaccontrollerv1.ServiceAccount, Name: "pager", Namespace: "ops",
+		})}, report)).To(Succeed())
+		Expect(report.Grants).To(HaveLen(1))
+	})
+
+	It("updates the reports as the bindings change", func() {
+		Expect(r.Report(ctx)).To(Succeed())
+		Expect(c.Delete(ctx, rb("oncall-edit", "prod", "oncall"))).To(Succeed())
+		Expect(r.Report(ctx)).To(Succeed())
+
+		report := &rbaccontrollerv1.RBACReport{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: aliceReport}, report)).To(Succeed())
+		Expect(report.Grants[0].Namespaces).To(Equal([]string{"dev"}))
+
+		reports := &rbaccontrollerv1.RBACReportList{}
+		Expect(c.List(ctx, reports)).To(Succeed())
+		Expect(reports.Items).To(HaveLen(1))
+	})
+
+	It("names reports after their subject", func() {
+		Expect(aliceReport).To(MatchRegexp(`^user-alice-[0-9a-f]{10}$`))
+		Expect(reportName(rbaccontrollerv1.ReportSubject{Kind: rbaccontrollerv1.Group, Name: "system:masters"})).
+			To(HavePrefix("group-system-masters-"))
+	})
+})
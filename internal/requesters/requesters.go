@@ -0,0 +1,193 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requesters evaluates the RBACRequesterPolicies , which map the users
+// and groups creating rules to the roles and namespaces their rules may grant ,
+// so delegating access doesn't have to be all or nothing.
+package requesters
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=rbac-controller.ggh41th.io,resources=rbacrequesterpolicies,verbs=get;list;watch
+
+// AllNamespaces is the namespace pattern allowing requesters to grant access
+// in any namespace.
+const AllNamespaces = "*"
+
+// Policies are the policies applying to a requester , the requester may grant
+// what any of them allows.
+type Policies []rbaccontrollerv1.RBACRequesterPolicySpec
+
+// Load returns the policies applying to the requester.
+func Load(ctx context.Context, reader client.Reader, user authenticationv1.UserInfo) (Policies, error) {
+	list := &rbaccontrollerv1.RBACRequesterPolicyList{}
+	if err := reader.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list the requester policies: %w", err)
+	}
+	var policies Policies
+	for _, p := range list.Items {
+		if Applies(p.Spec.Requesters, user) {
+			policies = append(policies, p.Spec)
+		}
+	}
+	return policies, nil
+}
+
+// Applies returns whether the requesters include the user , by name or through
+// one of their groups.
+func Applies(r rbaccontrollerv1.Requesters, user authenticationv1.UserInfo) bool {
+	if matches(r.Users, user.Username) {
+		return true
+	}
+	return slices.ContainsFunc(user.Groups, func(g string) bool { return matches(r.Groups, g) })
+}
+
+// access is something a rule grants , e.g a ClusterRole or a namespace.
+type access struct {
+	kind string
+	name string
+}
+
+const (
+	kindClusterRole   = "ClusterRole"
+	kindRole          = "Role"
+	kindBundle        = "bundle"
+	kindRoleTemplate  = "role template"
+	kindNamespace     = "namespace"
+	kindAllNamespaces = "namespaces selected by labels or expressions"
+	kindClusterWide   = "access cluster wide"
+)
+
+// Check returns an error when the rule grants something the policies don't
+// allow. Only what the old version of the rule , if any , doesn't grant is
+// checked , so rules can still be edited once the policies changed.
+func (p Policies) Check(old, rule *rbaccontrollerv1.RBACRule) error {
+	var previous []access
+	if old != nil {
+		for _, a := range grants(old) {
+			previous = append(previous, a.access)
+		}
+	}
+	for _, g := range grants(rule) {
+		if slices.Contains(previous, g.access) || p.allows(g.access) {
+			continue
+		}
+		if g.kind == kindAllNamespaces || g.kind == kindClusterWide {
+			return fmt.Errorf("%s: the requester isn't allowed to grant %s", g.field, g.kind)
+		}
+		return fmt.Errorf("%s: the requester isn't allowed to grant %s %s", g.field, g.kind, g.name)
+	}
+	return nil
+}
+
+// allows returns whether one of the policies allows the access.
+func (p Policies) allows(a access) bool {
+	return slices.ContainsFunc(p, func(s rbaccontrollerv1.RBACRequesterPolicySpec) bool {
+		switch a.kind {
+		case kindClusterRole:
+			return matches(s.ClusterRoles, a.name)
+		case kindRole:
+			return matches(s.Roles, a.name)
+		case kindBundle:
+			return matches(s.Bundles, a.name)
+		case kindRoleTemplate:
+			return matches(s.RoleTemplates, a.name)
+		case kindNamespace:
+			return matches(s.Namespaces, a.name)
+		case kindAllNamespaces:
+			return slices.Contains(s.Namespaces, AllNamespaces)
+		case kindClusterWide:
+			return s.ClusterWide
+		}
+		return false
+	})
+}
+
+// fieldAccess is an access , with the field of the rule granting it.
+type fieldAccess struct {
+	access
+	field string
+}
+
+// grants returns what the bindings of the rule grant.
+func grants(rule *rbaccontrollerv1.RBACRule) []fieldAccess {
+	var accesses []fieldAccess
+	add := func(field, kind, name string) {
+		accesses = append(accesses, fieldAccess{access: access{kind: kind, name: name}, field: field})
+	}
+	roles := func(field, clusterRole, bundle, template string) {
+		switch {
+		case clusterRole != "":
+			add(field, kindClusterRole, clusterRole)
+		case bundle != "":
+			add(field, kindBundle, bundle)
+		case template != "":
+			add(field, kindRoleTemplate, template)
+		}
+	}
+	for i, b := range rule.Spec.Bindings {
+		for j, rb := range b.RoleBindings {
+			field := fmt.Sprintf("bindings[%d].roleBindings[%d]", i, j)
+			if rb.Role != "" {
+				add(field, kindRole, rb.Role)
+			}
+			roles(field, rb.ClusterRole, rb.Bundle, rb.RoleTemplate)
+			for _, ns := range rb.Namespaces {
+				add(field, kindNamespace, ns)
+			}
+			if selects(&rb.NameSpaceSelector) || rb.NamespaceMatchExpression != "" {
+				add(field, kindAllNamespaces, "")
+			}
+		}
+		for j, crb := range b.ClusterRoleBindings {
+			field := fmt.Sprintf("bindings[%d].clusterRoleBindings[%d]", i, j)
+			roles(field, crb.ClusterRole, crb.Bundle, crb.RoleTemplate)
+			if crb.Scope == rbaccontrollerv1.ClusterRoleBindingScopeSelectedNamespaces {
+				add(field, kindAllNamespaces, "")
+			} else {
+				add(field, kindClusterWide, "")
+			}
+		}
+	}
+	return accesses
+}
+
+// selects returns whether the selector selects anything.
+func selects(ls *metav1.LabelSelector) bool {
+	return ls != nil && (len(ls.MatchLabels) > 0 || len(ls.MatchExpressions) > 0)
+}
+
+// matches returns whether the name is one of the patterns , a trailing *
+// matching any suffix.
+func matches(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(name, prefix) || p == name {
+			return true
+		}
+	}
+	return false
+}
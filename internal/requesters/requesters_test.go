@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requesters
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+var _ = Describe("Load", func() {
+	It("returns the policies applying to the requester", func() {
+		scheme := runtime.NewScheme()
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+		policy := func(name string, requesters rbaccontrollerv1.Requesters) *rbaccontrollerv1.RBACRequesterPolicy {
+			return &rbaccontrollerv1.RBACRequesterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       rbaccontrollerv1.RBACRequesterPolicySpec{Requesters: requesters, Roles: []string{name}},
+			}
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			policy("alice", rbaccontrollerv1.Requesters{Users: []string{"alice"}}),
+			policy("sre", rbaccontrollerv1.Requesters{Groups: []string{"sre"}}),
+			policy("ci", rbaccontrollerv1.Requesters{Users: []string{"system:serviceaccount:ci:*"}}),
+		).Build()
+
+		policies, err := Load(context.Background(), c, authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(2))
+
+		policies, err = Load(context.Background(), c, authenticationv1.UserInfo{Username: "system:serviceaccount:ci:deployer"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(HaveLen(1))
+		Expect(policies[0].Roles).To(Equal([]string{"ci"}))
+	})
+})
+
+var _ = Describe("Policies", func() {
+	policies := Policies{
+		{ClusterRoles: []string{"view"}, Namespaces: []string{"team-a"}},
+		{ClusterRoles: []string{"team:*"}, Roles: []string{"deployer"}, Namespaces: []string{"team-b-*"}},
+	}
+	rule := func(bindings ...rbaccontrollerv1.Binding) *rbaccontrollerv1.RBACRule {
+		return &rbaccontrollerv1.RBACRule{Spec: rbaccontrollerv1.RBACRuleSpec{Bindings: bindings}}
+	}
+	roleBinding := func(rb rbaccontrollerv1.RoleBinding) rbaccontrollerv1.Binding {
+		return rbaccontrollerv1.Binding{Name: "b", RoleBindings: []rbaccontrollerv1.RoleBinding{rb}}
+	}
+
+	It("allows what any of the policies allows", func() {
+		Expect(policies.Check(nil, rule(
+			roleBinding(rbaccontrollerv1.RoleBinding{ClusterRole: "view", Namespaces: []string{"team-a"}}),
+			roleBinding(rbaccontrollerv1.RoleBinding{ClusterRole: "team:deployer", Namespaces: []string{"team-a", "team-b-dev"}}),
+			roleBinding(rbaccontrollerv1.RoleBinding{Role: "deployer", Namespaces: []string{"team-b-prod"}}),
+		))).To(Succeed())
+	})
+
+	It("rejects roles and namespaces no policy allows", func() {
+		err := policies.Check(nil, rule(roleBinding(rbaccontrollerv1.RoleBinding{ClusterRole: "edit", Namespaces: []string{"team-a"}})))
+		Expect(err).To(MatchError("bindings[0].roleBindings[0]: the requester isn't allowed to grant ClusterRole edit"))
+
+		err = policies.Check(nil, rule(roleBinding(rbaccontrollerv1.RoleBinding{ClusterRole: "view", Namespaces: []string{"kube-system"}})))
+		Expect(err).To(MatchError("bindings[0].roleBindings[0]: the requester isn't allowed to grant namespace kube-system"))
+
+		err = policies.Check(nil, rule(roleBinding(rbaccontrollerv1.RoleBinding{Bundle: "debug", Namespaces: []string{"team-a"}})))
+		Expect(err).To(MatchError("bindings[0].roleBindings[0]: the requester isn't allowed to grant bundle debug"))
+	})
+
+	It("only allows selectors to requesters allowed in all namespaces", func() {
+		selected := rule(roleBinding(rbaccontrollerv1.RoleBinding{
+			ClusterRole:       "view",
+			NameSpaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+		}))
+		Expect(policies.Check(nil, selected)).To(MatchError(ContainSubstring("namespaces selected by labels or expressions")))
+		Expect(Policies{{ClusterRoles: []string{"view"}, Namespaces: []string{AllNamespaces}}}.Check(nil, selected)).To(Succeed())
+	})
+
+	It("only allows ClusterRoleBindings to requesters allowed cluster wide", func() {
+		clusterWide := rule(rbaccontrollerv1.Binding{
+			Name:                "b",
+			ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+		})
+		Expect(policies.Check(nil, clusterWide)).To(MatchError("bindings[0].clusterRoleBindings[0]: the requester isn't allowed to grant access cluster wide"))
+		Expect(Policies{{ClusterRoles: []string{"view"}, ClusterWide: true}}.Check(nil, clusterWide)).To(Succeed())
+	})
+
+	It("doesn't check what the old version of the rule grants", func() {
+		old := rule(roleBinding(rbaccontrollerv1.RoleBinding{ClusterRole: "edit", Namespaces: []string{"team-a"}}))
+		updated := rule(roleBinding(rbaccontrollerv1.RoleBinding{ClusterRole: "edit", Namespaces: []string{"team-a", "team-b-dev"}}))
+		Expect(policies.Check(old, updated)).To(Succeed())
+		Expect(Policies{}.Check(nil, updated)).To(HaveOccurred())
+	})
+})
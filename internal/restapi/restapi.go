@@ -0,0 +1,300 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package restapi serves a read-only JSON API over the rules , so portals can
+// show who holds temporary access without talking to the API server.
+package restapi
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// Prefix is the path the API is served under.
+const Prefix = "/api/v1"
+
+// maxEvents is the number of recent events returned with a rule.
+const maxEvents = 20
+
+// Server serves the API over TLS. It serves a self-signed certificate unless
+// TLSOpts provide one.
+type Server struct {
+	// reads the rules , e.g from the manager's cache.
+	Client client.Reader
+	// reads the events of the rules , they aren't cached.
+	APIReader   client.Reader
+	Log         logr.Logger
+	BindAddress string
+	// authenticates and authorizes the requests , e.g against the API server.
+	// Every request is served when nil.
+	Filter  metricsserver.Filter
+	TLSOpts []func(*tls.Config)
+}
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
+
+// Rule is what the API returns about a rule.
+type Rule struct {
+	Name       string                         `json:"name"`
+	Team       string                         `json:"team,omitempty"`
+	CreatedBy  string                         `json:"createdBy,omitempty"`
+	Phase      rbaccontrollerv1.RBACRulePhase `json:"phase,omitempty"`
+	BreakGlass bool                           `json:"breakGlass,omitempty"`
+	StartTime  *metav1.Time                   `json:"startTime,omitempty"`
+	EndTime    *metav1.Time                   `json:"endTime,omitempty"`
+	// Whether the rule's end time is within its expiring window.
+	Expiring bool      `json:"expiring"`
+	Subjects []Subject `json:"subjects"`
+	// The objects generated for the rule , in the form of namespace/name for
+	// namespaced ones.
+	RoleBindings        []string `json:"roleBindings"`
+	ClusterRoleBindings []string `json:"clusterRoleBindings"`
+	ServiceAccounts     []string `json:"serviceAccounts"`
+	// The recent events of the rule , most recent first. They are only
+	// returned for a single rule.
+	Events []Event `json:"events,omitempty"`
+}
+
+// Subject is a subject of a rule.
+type Subject struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// Event is an event recorded for a rule.
+type Event struct {
+	Type          string      `json:"type"`
+	Reason        string      `json:"reason"`
+	Message       string      `json:"message"`
+	Count         int32       `json:"count,omitempty"`
+	LastTimestamp metav1.Time `json:"lastTimestamp"`
+}
+
+// Handler returns the handler serving the API , without authentication.
+//
+//	GET /api/v1/rules[?phase=Active]  the rules , optionally in a phase
+//	GET /api/v1/rules/{name}          a rule , along with its recent events
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+Prefix+"/rules", s.listRules)
+	mux.HandleFunc("GET "+Prefix+"/rules/{name}", s.getRule)
+	return mux
+}
+
+func (s *Server) listRules(w http.ResponseWriter, req *http.Request) {
+	rules := &rbaccontrollerv1.RBACRuleList{}
+	if err := s.Client.List(req.Context(), rules); err != nil {
+		s.fail(w, err)
+		return
+	}
+	phase := rbaccontrollerv1.RBACRulePhase(req.URL.Query().Get("phase"))
+	out := []Rule{}
+	for i := range rules.Items {
+		if phase != "" && rules.Items[i].Status.Phase != phase {
+			continue
+		}
+		out = append(out, toRule(&rules.Items[i]))
+	}
+	slices.SortFunc(out, func(a, b Rule) int { return cmp.Compare(a.Name, b.Name) })
+	s.write(w, out)
+}
+
+func (s *Server) getRule(w http.ResponseWriter, req *http.Request) {
+	rule := &rbaccontrollerv1.RBACRule{}
+	if err := s.Client.Get(req.Context(), client.ObjectKey{Name: req.PathValue("name")}, rule); err != nil {
+		s.fail(w, err)
+		return
+	}
+	out := toRule(rule)
+	events, err := s.events(req.Context(), rule)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	out.Events = events
+	s.write(w, out)
+}
+
+// events returns the most recent events of the rule , most recent first.
+func (s *Server) events(ctx context.Context, rule *rbaccontrollerv1.RBACRule) ([]Event, error) {
+	list := &corev1.EventList{}
+	if err := s.APIReader.List(ctx, list, client.MatchingFields{
+		"involvedObject.kind": "RBACRule",
+		"involvedObject.name": rule.Name,
+		"involvedObject.uid":  string(rule.UID),
+	}); err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(list.Items))
+	for _, e := range list.Items {
+		last := e.LastTimestamp
+		if last.IsZero() {
+			last = metav1.NewTime(e.EventTime.Time)
+		}
+		events = append(events, Event{Type: e.Type, Reason: e.Reason, Message: e.Message, Count: e.Count, LastTimestamp: last})
+	}
+	slices.SortStableFunc(events, func(a, b Event) int { return b.LastTimestamp.Compare(a.LastTimestamp.Time) })
+	if len(events) > maxEvents {
+		events = events[:maxEvents]
+	}
+	return events, nil
+}
+
+// toRule summarizes the rule , from its spec and status.
+func toRule(rule *rbaccontrollerv1.RBACRule) Rule {
+	out := Rule{
+		Name:                rule.Name,
+		Team:                rule.Labels[constants.TeamLabel],
+		CreatedBy:           rule.Annotations[constants.CreatedByAnnotation],
+		Phase:               rule.Status.Phase,
+		BreakGlass:          rule.Spec.BreakGlass,
+		Expiring:            meta.IsStatusConditionTrue(rule.Status.Conditions, rbaccontrollerv1.ConditionExpiring),
+		Subjects:            []Subject{},
+		RoleBindings:        []string{},
+		ClusterRoleBindings: []string{},
+		ServiceAccounts:     []string{},
+	}
+	if !rule.Spec.StartTime.IsZero() {
+		out.StartTime = &rule.Spec.StartTime
+	}
+	if !rule.Spec.EndTime.IsZero() {
+		out.EndTime = &rule.Spec.EndTime
+	}
+	for _, b := range rule.Spec.Bindings {
+		for _, subject := range b.Subjects {
+			// subjects selecting ServiceAccounts are listed without a name.
+			names := parser.SubjectNames(&subject)
+			if len(names) == 0 {
+				names = []string{""}
+			}
+			for _, name := range names {
+				s := Subject{Kind: string(subject.Kind), Name: name}
+				if !slices.Contains(out.Subjects, s) {
+					out.Subjects = append(out.Subjects, s)
+				}
+			}
+		}
+	}
+	for _, b := range rule.Status.Bindings {
+		out.RoleBindings = append(out.RoleBindings, b.RoleBindings...)
+		out.ClusterRoleBindings = append(out.ClusterRoleBindings, b.ClusterRoleBindings...)
+		out.ServiceAccounts = append(out.ServiceAccounts, b.ServiceAccounts...)
+	}
+	return out
+}
+
+func (s *Server) write(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.Log.Error(err, "Failed to write response")
+	}
+}
+
+func (s *Server) fail(w http.ResponseWriter, err error) {
+	if apierrors.IsNotFound(err) {
+		http.Error(w, "rule not found", http.StatusNotFound)
+		return
+	}
+	s.Log.Error(err, "Failed to serve request")
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+// Start implements manager.Runnable , it serves the API until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	handler := s.Handler()
+	if s.Filter != nil {
+		filtered, err := s.Filter(s.Log, handler)
+		if err != nil {
+			return fmt.Errorf("failed to setup API authentication: %w", err)
+		}
+		handler = filtered
+	}
+	cfg, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	listener, err := tls.Listen("tcp", s.BindAddress, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.BindAddress, err)
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			s.Log.Error(err, "Failed to stop the API server")
+		}
+	}()
+	s.Log.Info("Serving the API", "address", s.BindAddress)
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable , every
+// replica serves the API.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// tlsConfig returns the TLS configuration of the server , serving a
+// self-signed certificate when TLSOpts don't provide one.
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	for _, opt := range s.TLSOpts {
+		opt(cfg)
+	}
+	if cfg.GetCertificate != nil || len(cfg.Certificates) > 0 {
+		return cfg, nil
+	}
+	host, _, err := net.SplitHostPort(s.BindAddress)
+	if err != nil || host == "" {
+		host = "localhost"
+	}
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey(host, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the API certificate: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Certificates = []tls.Certificate{cert}
+	return cfg, nil
+}
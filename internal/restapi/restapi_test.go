@@ -0,0 +1,165 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Server", func() {
+	var handler http.Handler
+
+	end := metav1.NewTime(time.Date(2026, 1, 31, 18, 0, 0, 0, time.UTC))
+
+	oncall := &rbaccontrollerv1.RBACRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "oncall",
+			UID:         "oncall-uid",
+			Labels:      map[string]string{constants.TeamLabel: "sre"},
+			Annotations: map[string]string{constants.CreatedByAnnotation: "alice"},
+		},
+		Spec: rbaccontrollerv1.RBACRuleSpec{
+			EndTime: end,
+			Bindings: []rbaccontrollerv1.Binding{
+				{Name: "view", Subjects: []rbaccontrollerv1.Subject{{Kind: "User", Name: "bob"}}},
+				{Name: "edit", Subjects: []rbaccontrollerv1.Subject{{Kind: "User", Name: "bob"}, {Kind: "Group", Name: "sre"}}},
+			},
+		},
+		Status: rbaccontrollerv1.RBACRuleStatus{
+			Phase: rbaccontrollerv1.RBACRulePhaseExpiring,
+			Conditions: []metav1.Condition{{
+				Type:   rbaccontrollerv1.ConditionExpiring,
+				Status: metav1.ConditionTrue,
+				Reason: rbaccontrollerv1.ReasonEndTimeApproaching,
+			}},
+			Bindings: []rbaccontrollerv1.BindingStatus{
+				{Name: "view", RoleBindings: []string{"team-a/oncall-view"}},
+				{Name: "edit", ClusterRoleBindings: []string{"oncall-edit"}},
+			},
+		},
+	}
+	pending := &rbaccontrollerv1.RBACRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "audit"},
+		Status:     rbaccontrollerv1.RBACRuleStatus{Phase: rbaccontrollerv1.RBACRulePhasePending},
+	}
+
+	event := func(name, reason, uid string, at time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "RBACRule", Name: "oncall", UID: types.UID(uid)},
+			Type:           corev1.EventTypeNormal,
+			Reason:         reason,
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(oncall.DeepCopy(), pending.DeepCopy(),
+				event("applied", "Applied", "oncall-uid", end.Add(-2*time.Hour)),
+				event("expiring", "Expiring", "oncall-uid", end.Add(-time.Hour)),
+				event("previous", "Expired", "previous-uid", end.Add(-48*time.Hour))).
+			WithIndex(&corev1.Event{}, "involvedObject.kind", func(o client.Object) []string {
+				return []string{o.(*corev1.Event).InvolvedObject.Kind}
+			}).
+			WithIndex(&corev1.Event{}, "involvedObject.name", func(o client.Object) []string {
+				return []string{o.(*corev1.Event).InvolvedObject.Name}
+			}).
+			WithIndex(&corev1.Event{}, "involvedObject.uid", func(o client.Object) []string {
+				return []string{string(o.(*corev1.Event).InvolvedObject.UID)}
+			}).
+			Build()
+		handler = (&Server{Client: c, APIReader: c, Log: logr.Discard()}).Handler()
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	It("should list the rules", func() {
+		rec := get(Prefix + "/rules")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var rules []Rule
+		Expect(json.Unmarshal(rec.Body.Bytes(), &rules)).To(Succeed())
+		Expect(rules).To(HaveLen(2))
+		Expect(rules[0].Name).To(Equal("audit"))
+
+		rule := rules[1]
+		Expect(rule.Name).To(Equal("oncall"))
+		Expect(rule.Team).To(Equal("sre"))
+		Expect(rule.CreatedBy).To(Equal("alice"))
+		Expect(rule.Phase).To(Equal(rbaccontrollerv1.RBACRulePhaseExpiring))
+		Expect(rule.Expiring).To(BeTrue())
+		Expect(rule.EndTime.Time).To(BeTemporally("==", end.Time))
+		Expect(rule.StartTime).To(BeNil())
+		Expect(rule.Subjects).To(Equal([]Subject{{Kind: "User", Name: "bob"}, {Kind: "Group", Name: "sre"}}))
+		Expect(rule.RoleBindings).To(Equal([]string{"team-a/oncall-view"}))
+		Expect(rule.ClusterRoleBindings).To(Equal([]string{"oncall-edit"}))
+		Expect(rule.Events).To(BeEmpty())
+	})
+
+	It("should filter the rules by phase", func() {
+		var rules []Rule
+		Expect(json.Unmarshal(get(Prefix+"/rules?phase=Pending").Body.Bytes(), &rules)).To(Succeed())
+		Expect(rules).To(HaveLen(1))
+		Expect(rules[0].Name).To(Equal("audit"))
+	})
+
+	It("should return a rule along with its recent events", func() {
+		rec := get(Prefix + "/rules/oncall")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var rule Rule
+		Expect(json.Unmarshal(rec.Body.Bytes(), &rule)).To(Succeed())
+		Expect(rule.Name).To(Equal("oncall"))
+		// the events of a previous rule with the same name aren't returned.
+		Expect(rule.Events).To(HaveLen(2))
+		Expect(rule.Events[0].Reason).To(Equal("Expiring"))
+		Expect(rule.Events[1].Reason).To(Equal("Applied"))
+	})
+
+	It("should report missing rules", func() {
+		Expect(get(Prefix + "/rules/missing").Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should only serve reads", func() {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, Prefix+"/rules/oncall", nil))
+		Expect(rec.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -0,0 +1,376 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package risk scores how much access rules grant , from the roles they
+// bind , the scope of their bindings and how long they last. The webhook
+// stamps the assessment on rules so it can be alerted on.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/roletemplates"
+)
+
+// Level is the risk level of a rule.
+type Level string
+
+const (
+	Low    Level = "Low"
+	Medium Level = "Medium"
+	High   Level = "High"
+)
+
+// levels are the levels , from the lowest.
+var levels = []Level{Low, Medium, High}
+
+// ParseLevel returns the level named s.
+func ParseLevel(s string) (Level, error) {
+	if !slices.Contains(levels, Level(s)) {
+		return "", fmt.Errorf("invalid risk level %q , it is one of Low , Medium or High", s)
+	}
+	return Level(s), nil
+}
+
+// AtLeast reports whether l is as high as other , unknown levels are lower
+// than the known ones.
+func (l Level) AtLeast(other Level) bool {
+	return slices.Index(levels, l) >= slices.Index(levels, other)
+}
+
+// The scores from which rules are of a Medium and a High level , scores are
+// capped at MaxScore.
+const (
+	MediumScore = 40
+	HighScore   = 70
+	MaxScore    = 100
+)
+
+// The score of the roles , depending on the most dangerous permission they
+// grant.
+const (
+	wildcardScore       = 50
+	escalationScore     = 45
+	sensitiveWriteScore = 40
+	secretsReadScore    = 30
+	writeScore          = 20
+	readScore           = 5
+	clusterWideScore    = 25
+	selectedScore       = 10
+	noExpiryScore       = 25
+	longLivedScore      = 15
+	weekLongScore       = 10
+	longLivedDuration   = 30 * 24 * time.Hour
+	weekLongDuration    = 7 * 24 * time.Hour
+)
+
+var (
+	writeVerbs      = []string{"create", "update", "patch", "delete", "deletecollection"}
+	escalationVerbs = []string{"escalate", "bind", "impersonate"}
+	// resources writing to which grants more access , or access to the
+	// workloads running on the cluster.
+	sensitiveResources = []string{
+		"secrets", "roles", "rolebindings", "clusterroles", "clusterrolebindings",
+		"serviceaccounts/token", "pods/exec", "pods/attach", "nodes/proxy",
+		"mutatingwebhookconfigurations", "validatingwebhookconfigurations",
+	}
+)
+
+// Assessment is the risk of a rule , with the factors contributing to it.
+type Assessment struct {
+	Score   int
+	Level   Level
+	Factors []string
+}
+
+// LevelOf returns the level of the score.
+func LevelOf(score int) Level {
+	switch {
+	case score >= HighScore:
+		return High
+	case score >= MediumScore:
+		return Medium
+	default:
+		return Low
+	}
+}
+
+// Scorer scores the risk of rules.
+type Scorer struct {
+	// Reads the roles bound by rules , their permissions aren't scored when
+	// nil.
+	Reader client.Reader
+	// The catalog the bundles bound by rules are resolved from , and the
+	// source of their role templates. They aren't scored when nil.
+	Bundles   *bundles.ConfigMapCatalog
+	Templates *roletemplates.Source
+	// Whether ClusterRoles can't be read , e.g in namespace-restricted mode.
+	// The ClusterRoles are then reported as unscored.
+	SkipClusterRoles bool
+}
+
+// Score assesses the risk of the rule at now. The roles , bundles and role
+// templates which don't exist yet aren't scored. The ones that can't be read
+// are reported as unscored factors rather than failing the assessment.
+func (s *Scorer) Score(ctx context.Context, rule *rbaccontrollerv1.RBACRule, now time.Time) Assessment {
+	var factors []string
+	roles, clusterWide, selected := 0, false, false
+	add := func(score int, factor string) {
+		if score > roles {
+			roles = score
+		}
+		if factor != "" {
+			factors = append(factors, factor)
+		}
+	}
+	// the catalog is only loaded when a binding references a bundle.
+	var catalog bundles.Catalog
+	var catalogErr error
+	bundle := func(name string) ([]string, string) {
+		if s.Bundles == nil || s.Reader == nil {
+			return nil, ""
+		}
+		if catalog == nil && catalogErr == nil {
+			catalog, catalogErr = s.Bundles.Load(ctx)
+		}
+		if catalogErr != nil {
+			return nil, fmt.Sprintf("bundle %s unscored", name)
+		}
+		// bundles which aren't defined are rejected by the validator.
+		roles, _ := catalog.Resolve(name)
+		return roles, ""
+	}
+	clusterRoles := func(clusterRole, bundleName, template string) {
+		switch {
+		case clusterRole != "":
+			add(s.clusterRole(ctx, clusterRole))
+		case bundleName != "":
+			roles, factor := bundle(bundleName)
+			add(0, factor)
+			for _, role := range roles {
+				add(s.clusterRole(ctx, role))
+			}
+		case template != "":
+			add(s.template(ctx, template))
+		}
+	}
+	for _, b := range rule.Spec.Bindings {
+		for _, crb := range b.ClusterRoleBindings {
+			if crb.Scope == rbaccontrollerv1.ClusterRoleBindingScopeSelectedNamespaces {
+				selected = true
+			} else {
+				clusterWide = true
+			}
+			clusterRoles(crb.ClusterRole, crb.Bundle, crb.RoleTemplate)
+		}
+		for _, rb := range b.RoleBindings {
+			if rb.NamespaceMatchExpression != "" || len(rb.NameSpaceSelector.MatchLabels) > 0 || len(rb.NameSpaceSelector.MatchExpressions) > 0 {
+				selected = true
+			}
+			if rb.Role != "" {
+				for _, ns := range rb.Namespaces {
+					add(s.role(ctx, ns, rb.Role))
+				}
+				continue
+			}
+			clusterRoles(rb.ClusterRole, rb.Bundle, rb.RoleTemplate)
+		}
+	}
+
+	score := roles
+	if clusterWide {
+		score += clusterWideScore
+		factors = append(factors, "cluster wide binding")
+	} else if selected {
+		score += selectedScore
+		factors = append(factors, "namespaces matched by selectors")
+	}
+	score += duration(rule, now, &factors)
+
+	score = min(score, MaxScore)
+	slices.Sort(factors)
+	return Assessment{Score: score, Level: LevelOf(score), Factors: slices.Compact(factors)}
+}
+
+// duration scores how long the rule lasts from now.
+func duration(rule *rbaccontrollerv1.RBACRule, now time.Time, factors *[]string) int {
+	if rule.Spec.EndTime.IsZero() {
+		*factors = append(*factors, "no expiry")
+		return noExpiryScore
+	}
+	start := now
+	if rule.Spec.StartTime.After(start) {
+		start = rule.Spec.StartTime.Time
+	}
+	switch lifetime := rule.Spec.EndTime.Sub(start); {
+	case lifetime > longLivedDuration:
+		*factors = append(*factors, "lasts more than 30 days")
+		return longLivedScore
+	case lifetime > weekLongDuration:
+		*factors = append(*factors, "lasts more than 7 days")
+		return weekLongScore
+	}
+	return 0
+}
+
+func (s *Scorer) clusterRole(ctx context.Context, name string) (int, string) {
+	if s.Reader == nil {
+		return 0, ""
+	}
+	if s.SkipClusterRoles {
+		return 0, fmt.Sprintf("ClusterRole %s unscored", name)
+	}
+	role := &rbacv1.ClusterRole{}
+	if err := s.Reader.Get(ctx, client.ObjectKey{Name: name}, role); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, ""
+		}
+		return 0, fmt.Sprintf("ClusterRole %s unscored", name)
+	}
+	score, factor := Rules(role.Rules)
+	if factor == "" {
+		return score, ""
+	}
+	return score, fmt.Sprintf("%s in ClusterRole %s", factor, name)
+}
+
+func (s *Scorer) role(ctx context.Context, namespace, name string) (int, string) {
+	if s.Reader == nil {
+		return 0, ""
+	}
+	role := &rbacv1.Role{}
+	if err := s.Reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, role); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, ""
+		}
+		return 0, fmt.Sprintf("Role %s/%s unscored", namespace, name)
+	}
+	score, factor := Rules(role.Rules)
+	if factor == "" {
+		return score, ""
+	}
+	return score, fmt.Sprintf("%s in Role %s/%s", factor, namespace, name)
+}
+
+func (s *Scorer) template(ctx context.Context, name string) (int, string) {
+	if s.Templates == nil {
+		return 0, ""
+	}
+	rules, found, err := s.Templates.Load(ctx, name)
+	if err != nil {
+		return 0, fmt.Sprintf("role template %s unscored", name)
+	}
+	if !found {
+		return 0, ""
+	}
+	score, factor := Rules(rules)
+	if factor == "" {
+		return score, ""
+	}
+	return score, fmt.Sprintf("%s in role template %s", factor, name)
+}
+
+// Rules scores the most dangerous permission granted by the rules , with
+// what it is. Read only access isn't reported as a factor.
+func Rules(rules []rbacv1.PolicyRule) (int, string) {
+	score, factor := 0, ""
+	raise := func(s int, f string) {
+		if s > score {
+			score, factor = s, f
+		}
+	}
+	for _, r := range rules {
+		if len(r.Verbs) == 0 {
+			continue
+		}
+		if slices.Contains(r.Verbs, rbacv1.VerbAll) || slices.Contains(r.Resources, rbacv1.ResourceAll) ||
+			slices.Contains(r.APIGroups, rbacv1.APIGroupAll) {
+			raise(wildcardScore, "wildcard permissions")
+			continue
+		}
+		if verb, found := containsAny(r.Verbs, escalationVerbs); found {
+			raise(escalationScore, verb+" permission")
+			continue
+		}
+		write := slices.ContainsFunc(r.Verbs, func(v string) bool { return slices.Contains(writeVerbs, v) })
+		if resource, found := containsAny(r.Resources, sensitiveResources); found && write {
+			raise(sensitiveWriteScore, "write access to "+resource)
+			continue
+		}
+		if slices.Contains(r.Resources, "secrets") {
+			raise(secretsReadScore, "read access to secrets")
+			continue
+		}
+		if write {
+			raise(writeScore, "write access")
+			continue
+		}
+		raise(readScore, "")
+	}
+	return score, factor
+}
+
+func containsAny(values, candidates []string) (string, bool) {
+	for _, v := range values {
+		if slices.Contains(candidates, v) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Annotate records the assessment in the annotations of obj.
+func Annotate(obj client.Object, a Assessment) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.RiskScoreAnnotation] = strconv.Itoa(a.Score)
+	annotations[constants.RiskLevelAnnotation] = string(a.Level)
+	if len(a.Factors) > 0 {
+		annotations[constants.RiskFactorsAnnotation] = strings.Join(a.Factors, ", ")
+	} else {
+		delete(annotations, constants.RiskFactorsAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// FromAnnotations returns the score and the level recorded in the
+// annotations of obj , if any.
+func FromAnnotations(obj client.Object) (int, Level, bool) {
+	v, found := obj.GetAnnotations()[constants.RiskScoreAnnotation]
+	if !found {
+		return 0, "", false
+	}
+	score, err := strconv.Atoi(v)
+	if err != nil || score < 0 {
+		return 0, "", false
+	}
+	score = min(score, MaxScore)
+	return score, LevelOf(score), true
+}
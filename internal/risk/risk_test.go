@@ -0,0 +1,188 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package risk
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/roletemplates"
+)
+
+var _ = Describe("Risk", func() {
+	var (
+		ctx    = context.Background()
+		now    = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		scorer *Scorer
+	)
+
+	rule := func(end time.Time, crbs []rbaccontrollerv1.ClusterRoleBinding, rbs []rbaccontrollerv1.RoleBinding) *rbaccontrollerv1.RBACRule {
+		r := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "rule"}}
+		r.Spec.EndTime = metav1.NewTime(end)
+		r.Spec.Bindings = []rbaccontrollerv1.Binding{{Name: "b", ClusterRoleBindings: crbs, RoleBindings: rbs}}
+		return r
+	}
+
+	BeforeEach(func() {
+		scorer = &Scorer{Reader: fake.NewClientBuilder().WithObjects(
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			},
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "view"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}}},
+			},
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "secrets", Namespace: "team-a"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}}},
+			},
+		).Build()}
+	})
+
+	It("scores permanent cluster wide wildcards as high", func() {
+		a := scorer.Score(ctx, rule(time.Time{}, []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "cluster-admin"}}, nil), now)
+		Expect(a.Score).To(Equal(MaxScore))
+		Expect(a.Level).To(Equal(High))
+		Expect(a.Factors).To(ConsistOf("wildcard permissions in ClusterRole cluster-admin", "cluster wide binding", "no expiry"))
+	})
+
+	It("scores short lived namespaced reads as low", func() {
+		a := scorer.Score(ctx, rule(now.Add(time.Hour), nil, []rbaccontrollerv1.RoleBinding{{ClusterRole: "view", Namespaces: []string{"team-a"}}}), now)
+		Expect(a).To(Equal(Assessment{Score: readScore, Level: Low}))
+	})
+
+	It("scores the Roles in each namespace", func() {
+		a := scorer.Score(ctx, rule(now.Add(8*24*time.Hour), nil, []rbaccontrollerv1.RoleBinding{{Role: "secrets", Namespaces: []string{"team-a", "team-b"}}}), now)
+		Expect(a.Score).To(Equal(secretsReadScore + weekLongScore))
+		Expect(a.Level).To(Equal(Medium))
+		Expect(a.Factors).To(ConsistOf("read access to secrets in Role team-a/secrets", "lasts more than 7 days"))
+	})
+
+	It("scores the scope without a reader", func() {
+		a := (&Scorer{}).Score(ctx, rule(now.Add(40*24*time.Hour), []rbaccontrollerv1.ClusterRoleBinding{{
+			ClusterRole: "cluster-admin",
+			Scope:       rbaccontrollerv1.ClusterRoleBindingScopeSelectedNamespaces,
+		}}, nil), now)
+		Expect(a.Score).To(Equal(selectedScore + longLivedScore))
+	})
+
+	It("scores the ClusterRoles of bundles and the rules of role templates", func() {
+		c := fake.NewClientBuilder().WithObjects(
+			&rbacv1.ClusterRole{
+				ObjectMeta: metav1.ObjectMeta{Name: "edit"},
+				Rules:      []rbacv1.PolicyRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"update"}}},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "rbac-controller-system", Name: "bundles"},
+				Data:       map[string]string{"deployer": "view,edit"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "role-templates", Name: "debug"},
+				Data:       map[string]string{roletemplates.RulesKey: `[{"apiGroups": [""], "resources": ["pods/exec"], "verbs": ["create"]}]`},
+			},
+		).Build()
+		scorer := &Scorer{
+			Reader:    c,
+			Bundles:   &bundles.ConfigMapCatalog{Reader: c, ConfigMap: types.NamespacedName{Namespace: "rbac-controller-system", Name: "bundles"}},
+			Templates: &roletemplates.Source{Reader: c, Namespace: "role-templates"},
+		}
+
+		a := scorer.Score(ctx, rule(now.Add(time.Hour), nil, []rbaccontrollerv1.RoleBinding{{Bundle: "deployer", Namespaces: []string{"team-a"}}}), now)
+		Expect(a.Score).To(Equal(writeScore))
+		Expect(a.Factors).To(ConsistOf("write access in ClusterRole edit"))
+
+		a = scorer.Score(ctx, rule(now.Add(time.Hour), nil, []rbaccontrollerv1.RoleBinding{{RoleTemplate: "debug", Namespaces: []string{"team-a"}}}), now)
+		Expect(a.Score).To(Equal(sensitiveWriteScore))
+		Expect(a.Factors).To(ConsistOf("write access to pods/exec in role template debug"))
+	})
+
+	It("reports the roles it can't read as unscored", func() {
+		scorer.SkipClusterRoles = true
+		a := scorer.Score(ctx, rule(now.Add(time.Hour), nil, []rbaccontrollerv1.RoleBinding{
+			{ClusterRole: "cluster-admin", Namespaces: []string{"team-a"}},
+			{Role: "secrets", Namespaces: []string{"team-a"}},
+		}), now)
+		Expect(a.Score).To(Equal(secretsReadScore))
+		Expect(a.Factors).To(ConsistOf("ClusterRole cluster-admin unscored", "read access to secrets in Role team-a/secrets"))
+
+		failing := interceptor.Funcs{Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+			return errors.New("connection refused")
+		}}
+		scorer = &Scorer{Reader: fake.NewClientBuilder().WithInterceptorFuncs(failing).Build()}
+		a = scorer.Score(ctx, rule(now.Add(time.Hour), nil, []rbaccontrollerv1.RoleBinding{{ClusterRole: "view", Namespaces: []string{"team-a"}}}), now)
+		Expect(a.Factors).To(ConsistOf("ClusterRole view unscored"))
+	})
+
+	It("scores the most dangerous permission", func() {
+		score, factor := Rules([]rbacv1.PolicyRule{
+			{Resources: []string{"pods"}, Verbs: []string{"get"}},
+			{Resources: []string{"clusterroles"}, Verbs: []string{"bind"}},
+			{Resources: []string{"secrets"}, Verbs: []string{"create"}},
+		})
+		Expect(score).To(Equal(escalationScore))
+		Expect(factor).To(Equal("bind permission"))
+		_, factor = Rules([]rbacv1.PolicyRule{{Resources: []string{"pods/exec"}, Verbs: []string{"create"}}})
+		Expect(factor).To(Equal("write access to pods/exec"))
+	})
+
+	It("records the assessment in annotations", func() {
+		r := rule(time.Time{}, nil, nil)
+		Annotate(r, Assessment{Score: 75, Level: High, Factors: []string{"a", "b"}})
+		Expect(r.Annotations).To(HaveKeyWithValue(constants.RiskLevelAnnotation, "High"))
+		Expect(r.Annotations).To(HaveKeyWithValue(constants.RiskFactorsAnnotation, "a, b"))
+
+		score, level, found := FromAnnotations(r)
+		Expect(found).To(BeTrue())
+		Expect(score).To(Equal(75))
+		Expect(level).To(Equal(High))
+
+		r.Annotations[constants.RiskScoreAnnotation] = "high"
+		_, _, found = FromAnnotations(r)
+		Expect(found).To(BeFalse())
+	})
+
+	It("orders the levels", func() {
+		Expect(High.AtLeast(Medium)).To(BeTrue())
+		Expect(Low.AtLeast(Medium)).To(BeFalse())
+		Expect(Level("").AtLeast(Low)).To(BeFalse())
+		Expect(ParseLevel("High")).To(Equal(High))
+		_, err := ParseLevel("high")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("levels",
+		func(score int, level Level) { Expect(LevelOf(score)).To(Equal(level)) },
+		Entry("low", 39, Low),
+		Entry("medium", 40, Medium),
+		Entry("high", 70, High),
+	)
+})
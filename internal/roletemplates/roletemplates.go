@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package roletemplates reads role templates , ConfigMaps holding the
+// PolicyRules of the ClusterRoles the controller generates for the bindings
+// referencing them. Templates are maintained apart from rules , e.g by a
+// security team , in a namespace of their own.
+package roletemplates
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// RulesKey is the key of the ConfigMaps holding the PolicyRules , as a YAML or
+// JSON list:
+//
+//	rules: |
+//	  - apiGroups: [""]
+//	    resources: ["pods", "pods/log"]
+//	    verbs: ["get", "list", "watch"]
+const RulesKey = "rules"
+
+// Source reads the templates from the ConfigMaps of a namespace , each
+// ConfigMap is a template named after it. The ConfigMaps are read on each
+// load , so templates can be changed without restarting the controller.
+type Source struct {
+	Reader    client.Reader
+	Namespace string
+}
+
+// Load returns the PolicyRules of the template , found is false when it isn't
+// defined.
+func (s *Source) Load(ctx context.Context, name string) (rules []rbacv1.PolicyRule, found bool, err error) {
+	cm := &corev1.ConfigMap{}
+	if err := s.Reader.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	data, ok := cm.Data[RulesKey]
+	if !ok {
+		return nil, false, fmt.Errorf("role template %s has no %s key", name, RulesKey)
+	}
+	if err := yaml.UnmarshalStrict([]byte(data), &rules); err != nil {
+		return nil, false, fmt.Errorf("invalid rules in role template %s: %w", name, err)
+	}
+	return rules, true, nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roletemplates
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Source", func() {
+	ctx := context.Background()
+	template := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "role-templates", Name: name},
+			Data:       data,
+		}
+	}
+	var s *Source
+
+	BeforeEach(func() {
+		s = &Source{
+			Reader: fake.NewClientBuilder().WithObjects(
+				template("log-reader", map[string]string{RulesKey: `
+- apiGroups: [""]
+  resources: ["pods", "pods/log"]
+  verbs: ["get", "list"]
+`}),
+				template("empty", map[string]string{}),
+				template("typo", map[string]string{RulesKey: `[{"verb": ["get"]}]`}),
+			).Build(),
+			Namespace: "role-templates",
+		}
+	})
+
+	It("reads the rules of the template", func() {
+		rules, found, err := s.Load(ctx, "log-reader")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(rules).To(Equal([]rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods", "pods/log"},
+			Verbs:     []string{"get", "list"},
+		}}))
+	})
+
+	It("isn't found when the ConfigMap is missing", func() {
+		_, found, err := s.Load(ctx, "deployer")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("fails on templates without rules or with invalid ones", func() {
+		_, _, err := s.Load(ctx, "empty")
+		Expect(err).To(MatchError("role template empty has no rules key"))
+		_, _, err = s.Load(ctx, "typo")
+		Expect(err).To(MatchError(ContainSubstring("invalid rules in role template typo")))
+	})
+})
@@ -0,0 +1,239 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scheduler enqueues objects at the times their state changes , e.g
+// when rules are activated or expire , independently of the requeues of their
+// reconciliations.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// Scheduler enqueues each object once , at the last time it was scheduled at.
+// The times are kept in a min-heap , rebuilt through Times when the scheduler
+// starts , so they survive restarts of the controller.
+type Scheduler struct {
+	// Times returns , when the scheduler starts , the time each object has to
+	// be enqueued at.
+	Times func(ctx context.Context) (map[types.NamespacedName]time.Time, error)
+	// Whether the scheduler only runs on the leader , along with the
+	// controller its events are sent to.
+	LeaderElection bool
+	// Objects are delayed by up to Jitter past the time they're scheduled at ,
+	// so the objects scheduled at the same time aren't all enqueued at once.
+	Jitter time.Duration
+	// the source of the current time , the real clock when nil.
+	Clock clock.Clock
+
+	once   sync.Once
+	mu     sync.Mutex
+	queue  queue
+	items  map[types.NamespacedName]*item
+	wake   chan struct{}
+	events chan event.GenericEvent
+}
+
+// item is an object scheduled at a time.
+type item struct {
+	key   types.NamespacedName
+	at    time.Time
+	index int
+}
+
+// queue is a min-heap of items by time.
+type queue []*item
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+func (q queue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+func (q *queue) Push(x any) {
+	it := x.(*item)
+	it.index = len(*q)
+	*q = append(*q, it)
+}
+
+func (q *queue) Pop() any {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+func (s *Scheduler) init() {
+	s.once.Do(func() {
+		s.items = map[types.NamespacedName]*item{}
+		s.wake = make(chan struct{}, 1)
+		s.events = make(chan event.GenericEvent)
+	})
+}
+
+// Events returns the channel the objects are sent to when they are due , as
+// objects only holding their name and namespace.
+func (s *Scheduler) Events() <-chan event.GenericEvent {
+	s.init()
+	return s.events
+}
+
+// Schedule enqueues the object at the given time , delayed by its jitter ,
+// replacing the time it was scheduled at before.
+func (s *Scheduler) Schedule(key types.NamespacedName, at time.Time) {
+	s.init()
+	at = at.Add(Jitter(key, s.Jitter))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if it, found := s.items[key]; found {
+		it.at = at
+		heap.Fix(&s.queue, it.index)
+	} else {
+		it := &item{key: key, at: at}
+		heap.Push(&s.queue, it)
+		s.items[key] = it
+	}
+	s.notify()
+}
+
+// Forget unschedules the object.
+func (s *Scheduler) Forget(key types.NamespacedName) {
+	s.init()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if it, found := s.items[key]; found {
+		heap.Remove(&s.queue, it.index)
+		delete(s.items, key)
+		s.notify()
+	}
+}
+
+// Scheduled returns the time the object is scheduled at , and whether it is.
+func (s *Scheduler) Scheduled(key types.NamespacedName) (time.Time, bool) {
+	s.init()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if it, found := s.items[key]; found {
+		return it.at, true
+	}
+	return time.Time{}, false
+}
+
+// notify wakes the scheduler up , the earliest time may have changed.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start implements manager.Runnable.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.init()
+	if s.Times != nil {
+		times, err := s.Times(ctx)
+		if err != nil {
+			return err
+		}
+		for key, at := range times {
+			s.Schedule(key, at)
+		}
+	}
+	for {
+		due, next := s.pop()
+		for _, key := range due {
+			obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+			select {
+			case s.events <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		if len(due) > 0 {
+			continue
+		}
+
+		var timer clock.Timer
+		var fired <-chan time.Time
+		if next != (time.Time{}) {
+			timer = s.clock().NewTimer(next.Sub(s.clock().Now()))
+			fired = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return nil
+		case <-fired:
+		case <-s.wake:
+			if timer != nil {
+				timer.Stop()
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (s *Scheduler) NeedLeaderElection() bool {
+	return s.LeaderElection
+}
+
+// pop removes the due objects from the queue and returns them , along with the
+// time the next object is due at.
+func (s *Scheduler) pop() ([]types.NamespacedName, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock().Now()
+	var due []types.NamespacedName
+	for s.queue.Len() > 0 && !s.queue[0].at.After(now) {
+		it := heap.Pop(&s.queue).(*item)
+		delete(s.items, it.key)
+		due = append(due, it.key)
+	}
+	if s.queue.Len() == 0 {
+		return due, time.Time{}
+	}
+	return due, s.queue[0].at
+}
+
+// Jitter returns the delay , up to max , of the object. An object is always
+// delayed by the same time so rescheduling it doesn't push it back further.
+func Jitter(key types.NamespacedName, max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key.String()))
+	return time.Duration(h.Sum64() % uint64(max))
+}
+
+func (s *Scheduler) clock() clock.Clock {
+	if s.Clock == nil {
+		return clock.RealClock{}
+	}
+	return s.Clock
+}
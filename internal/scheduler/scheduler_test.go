@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+var _ = Describe("Scheduler", func() {
+	var (
+		clock  *clocktesting.FakeClock
+		s      *Scheduler
+		cancel context.CancelFunc
+		done   chan error
+	)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	oncall := types.NamespacedName{Name: "oncall"}
+	audit := types.NamespacedName{Name: "audit"}
+
+	start := func() {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		done = make(chan error, 1)
+		go func() { done <- s.Start(ctx) }()
+	}
+
+	// received returns the name of the next object sent by the scheduler.
+	received := func() string {
+		var e event.GenericEvent
+		Eventually(s.Events()).Should(Receive(&e))
+		return e.Object.GetName()
+	}
+
+	BeforeEach(func() {
+		clock = clocktesting.NewFakeClock(now)
+		s = &Scheduler{Clock: clock}
+	})
+
+	AfterEach(func() {
+		if cancel != nil {
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+			cancel = nil
+		}
+	})
+
+	It("should replace the time an object was scheduled at", func() {
+		s.Schedule(oncall, now.Add(time.Hour))
+		s.Schedule(oncall, now.Add(time.Minute))
+		at, scheduled := s.Scheduled(oncall)
+		Expect(scheduled).To(BeTrue())
+		Expect(at).To(Equal(now.Add(time.Minute)))
+
+		s.Forget(oncall)
+		_, scheduled = s.Scheduled(oncall)
+		Expect(scheduled).To(BeFalse())
+	})
+
+	It("should send the objects once they are due , earliest first", func() {
+		s.Schedule(oncall, now.Add(time.Hour))
+		s.Schedule(audit, now.Add(time.Minute))
+		start()
+
+		Eventually(clock.HasWaiters).Should(BeTrue())
+		Consistently(s.Events()).ShouldNot(Receive())
+		clock.Step(time.Minute)
+		Expect(received()).To(Equal("audit"))
+
+		Eventually(clock.HasWaiters).Should(BeTrue())
+		clock.Step(time.Hour)
+		Expect(received()).To(Equal("oncall"))
+		Consistently(s.Events()).ShouldNot(Receive())
+	})
+
+	It("should wake up when an earlier time is scheduled", func() {
+		s.Schedule(oncall, now.Add(time.Hour))
+		start()
+
+		Eventually(clock.HasWaiters).Should(BeTrue())
+		s.Schedule(audit, now)
+		Expect(received()).To(Equal("audit"))
+	})
+
+	It("should not send forgotten objects", func() {
+		s.Schedule(oncall, now.Add(time.Minute))
+		start()
+
+		Eventually(clock.HasWaiters).Should(BeTrue())
+		s.Forget(oncall)
+		clock.Step(time.Minute)
+		Consistently(s.Events()).ShouldNot(Receive())
+	})
+
+	It("should delay the objects by their jitter", func() {
+		s.Jitter = time.Minute
+		s.Schedule(oncall, now)
+		at, _ := s.Scheduled(oncall)
+		Expect(at).To(Equal(now.Add(Jitter(oncall, time.Minute))))
+		Expect(at).To(BeTemporally(">=", now))
+		Expect(at).To(BeTemporally("<", now.Add(time.Minute)))
+
+		// rescheduling an object doesn't delay it further.
+		s.Schedule(oncall, now)
+		rescheduled, _ := s.Scheduled(oncall)
+		Expect(rescheduled).To(Equal(at))
+	})
+
+	It("should spread the objects scheduled at the same time", func() {
+		delays := map[time.Duration]bool{}
+		for i := range 20 {
+			delays[Jitter(types.NamespacedName{Name: fmt.Sprintf("rule-%d", i)}, time.Hour)] = true
+		}
+		Expect(len(delays)).To(BeNumerically(">", 1))
+		Expect(Jitter(oncall, 0)).To(BeZero())
+	})
+
+	It("should rebuild the schedule when it starts", func() {
+		s.Times = func(context.Context) (map[types.NamespacedName]time.Time, error) {
+			return map[types.NamespacedName]time.Time{
+				oncall: now.Add(-time.Minute),
+				audit:  now.Add(time.Hour),
+			}, nil
+		}
+		start()
+
+		Expect(received()).To(Equal("oncall"))
+		Eventually(func() bool {
+			_, scheduled := s.Scheduled(audit)
+			return scheduled
+		}).Should(BeTrue())
+	})
+})
@@ -0,0 +1,208 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding partitions the rules across the replicas of the
+// controller , so large installations aren't bottlenecked on a single one.
+package sharding
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var (
+	membersGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "rbac_controller_shard_members",
+		Help: "Number of replicas sharing the rules , as seen by this replica.",
+	})
+	indexGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "rbac_controller_shard_index",
+		Help: "Index of this replica among the replicas sharing the rules , -1 until it joined them.",
+	})
+	rebalancesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "rbac_controller_shard_rebalances_total",
+		Help: "Number of times the rules were rebalanced because replicas joined or left.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(membersGauge, indexGauge, rebalancesTotal)
+	indexGauge.Set(-1)
+}
+
+// Key returns the key rules are sharded by , the value of their shard label or
+// their name.
+func Key(obj client.Object) string {
+	if shard, found := obj.GetLabels()[constants.ShardLabel]; found {
+		return shard
+	}
+	return obj.GetName()
+}
+
+// Membership keeps track of the replicas sharing the rules. Each replica
+// renews a Lease labeled with ShardMemberLabel , the replicas whose Lease
+// didn't expire are the members. A rule is owned by the member whose index ,
+// in the sorted members , is the hash of its key modulo the number of members.
+type Membership struct {
+	Client client.Client
+	Log    logr.Logger
+	// The namespace of the Leases.
+	Namespace string
+	// The identity of the replica , the name of its Lease.
+	Identity string
+	// How long a Lease that isn't renewed is valid , Leases are renewed every
+	// third of it.
+	LeaseDuration time.Duration
+	// Called when the members changed , the rules have to be rebalanced.
+	OnChange func()
+	// the source of the current time , the real clock when nil.
+	Clock clock.PassiveClock
+
+	mu      sync.RWMutex
+	members []string
+}
+
+// Start implements manager.Runnable , the Lease of the replica is deleted when
+// it stops so the others take over its rules right away.
+func (m *Membership) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.LeaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		if err := m.Sync(ctx); err != nil {
+			m.Log.Error(err, "Failed to sync the shard members")
+		}
+		select {
+		case <-ctx.Done():
+			lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: m.Namespace, Name: m.Identity}}
+			// the manager's context is done.
+			if err := m.Client.Delete(context.Background(), lease); client.IgnoreNotFound(err) != nil {
+				m.Log.Error(err, "Failed to delete the shard Lease")
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable , every replica
+// is a member.
+func (m *Membership) NeedLeaderElection() bool {
+	return false
+}
+
+// Sync renews the Lease of the replica and updates the members.
+func (m *Membership) Sync(ctx context.Context) error {
+	if err := m.renew(ctx); err != nil {
+		return err
+	}
+	leases := &coordinationv1.LeaseList{}
+	if err := m.Client.List(ctx, leases, client.InNamespace(m.Namespace), client.HasLabels{constants.ShardMemberLabel}); err != nil {
+		return err
+	}
+	now := m.now()
+	var members []string
+	for _, l := range leases.Items {
+		if l.Spec.RenewTime == nil || l.Spec.LeaseDurationSeconds == nil {
+			continue
+		}
+		expiry := l.Spec.RenewTime.Add(time.Duration(*l.Spec.LeaseDurationSeconds) * time.Second)
+		if expiry.After(now) {
+			members = append(members, l.Name)
+		}
+	}
+	slices.Sort(members)
+
+	m.mu.Lock()
+	changed := !slices.Equal(m.members, members)
+	m.members = members
+	m.mu.Unlock()
+	membersGauge.Set(float64(len(members)))
+	indexGauge.Set(float64(slices.Index(members, m.Identity)))
+	if changed {
+		m.Log.Info("The shard members changed , rebalancing the rules", "members", members)
+		rebalancesTotal.Inc()
+		if m.OnChange != nil {
+			m.OnChange()
+		}
+	}
+	return nil
+}
+
+// renew creates or renews the Lease of the replica.
+func (m *Membership) renew(ctx context.Context) error {
+	now := metav1.NewMicroTime(m.now())
+	lease := &coordinationv1.Lease{}
+	err := m.Client.Get(ctx, client.ObjectKey{Namespace: m.Namespace, Name: m.Identity}, lease)
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: m.Namespace,
+				Name:      m.Identity,
+				Labels:    map[string]string{constants.ShardMemberLabel: "true"},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(m.Identity),
+				LeaseDurationSeconds: ptr.To(int32(m.LeaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		return m.Client.Create(ctx, lease)
+	}
+	if err != nil {
+		return err
+	}
+	base := lease.DeepCopy()
+	lease.Spec.RenewTime = &now
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(m.LeaseDuration.Seconds()))
+	return m.Client.Patch(ctx, lease, client.MergeFrom(base))
+}
+
+// Owns reports whether the replica owns the rules with the given key. It owns
+// none until it joined the members.
+func (m *Membership) Owns(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	index := slices.Index(m.members, m.Identity)
+	if index < 0 {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32()%uint32(len(m.members))) == index
+}
+
+func (m *Membership) now() time.Time {
+	if m.Clock == nil {
+		return time.Now()
+	}
+	return m.Clock.Now()
+}
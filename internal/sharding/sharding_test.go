@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("Membership", func() {
+	ctx := context.Background()
+
+	var (
+		c       client.Client
+		clock   *clocktesting.FakePassiveClock
+		changes map[string]int
+	)
+
+	member := func(identity string) *Membership {
+		return &Membership{
+			Client:        c,
+			Log:           logr.Discard(),
+			Namespace:     "rbac-controller-system",
+			Identity:      identity,
+			LeaseDuration: 15 * time.Second,
+			OnChange:      func() { changes[identity]++ },
+			Clock:         clock,
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(coordinationv1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).Build()
+		clock = clocktesting.NewFakePassiveClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+		changes = map[string]int{}
+	})
+
+	It("should own no rule until it joined the members", func() {
+		Expect(member("replica-a").Owns("oncall")).To(BeFalse())
+	})
+
+	It("should own every rule when it is the only member", func() {
+		a := member("replica-a")
+		Expect(a.Sync(ctx)).To(Succeed())
+		Expect(a.Owns("oncall")).To(BeTrue())
+		Expect(changes["replica-a"]).To(Equal(1))
+	})
+
+	It("should split the rules across the members", func() {
+		a, b := member("replica-a"), member("replica-b")
+		Expect(a.Sync(ctx)).To(Succeed())
+		Expect(b.Sync(ctx)).To(Succeed())
+		Expect(a.Sync(ctx)).To(Succeed())
+		Expect(changes["replica-a"]).To(Equal(2))
+
+		owned := map[string]int{}
+		for i := range 100 {
+			key := fmt.Sprintf("rule-%d", i)
+			Expect(a.Owns(key)).NotTo(Equal(b.Owns(key)), key)
+			if a.Owns(key) {
+				owned["replica-a"]++
+			}
+		}
+		Expect(owned["replica-a"]).To(BeNumerically(">", 0))
+		Expect(owned["replica-a"]).To(BeNumerically("<", 100))
+	})
+
+	It("should rebalance once a member's Lease expired", func() {
+		a, b := member("replica-a"), member("replica-b")
+		Expect(a.Sync(ctx)).To(Succeed())
+		Expect(b.Sync(ctx)).To(Succeed())
+		Expect(a.Sync(ctx)).To(Succeed())
+
+		clock.SetTime(clock.Now().Add(20 * time.Second))
+		Expect(a.Sync(ctx)).To(Succeed())
+		Expect(changes["replica-a"]).To(Equal(3))
+		for i := range 10 {
+			Expect(a.Owns(fmt.Sprintf("rule-%d", i))).To(BeTrue())
+		}
+	})
+
+	It("should ignore the Leases of other components", func() {
+		Expect(c.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "rbac-controller-system", Name: "leader"},
+		})).To(Succeed())
+		a := member("replica-a")
+		Expect(a.Sync(ctx)).To(Succeed())
+		Expect(a.Owns("oncall")).To(BeTrue())
+
+		lease := &coordinationv1.Lease{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "rbac-controller-system", Name: "replica-a"}, lease)).To(Succeed())
+		Expect(lease.Labels).To(HaveKey(constants.ShardMemberLabel))
+	})
+})
+
+var _ = Describe("Key", func() {
+	It("should shard rules by their shard label , or by name", func() {
+		rule := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "oncall"}}
+		Expect(Key(rule)).To(Equal("oncall"))
+		rule.Labels = map[string]string{constants.ShardLabel: "payments"}
+		Expect(Key(rule)).To(Equal("payments"))
+	})
+})
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sweeper removes the resources left behind by the controller , e.g
+// by a deletion that crashed midway or by bindings removed from a rule.
+package sweeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+)
+
+// Reasons resources are orphaned , as reported by the metrics.
+const (
+	reasonRuleMissing = "rule_missing"
+	reasonNotDeclared = "not_declared"
+)
+
+var orphanedGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "rbac_controller_orphaned_resources",
+	Help: "Number of resources labeled with a rule found orphaned by the last sweep , by kind and by reason: their rule is missing or doesn't declare them anymore.",
+}, []string{"kind", "reason"})
+
+func init() {
+	metrics.Registry.MustRegister(orphanedGauge)
+}
+
+// Sweeper periodically lists the RoleBindings , ClusterRoleBindings and
+// ServiceAccounts carrying the rule label , and deletes those whose rule
+// doesn't exist anymore or doesn't declare them anymore.
+type Sweeper struct {
+	Client client.Client
+	Log    logr.Logger
+	// The role bundles the rules can reference , bindings can't be matched to
+	// the rules using bundles when nil.
+	Bundles  *bundles.ConfigMapCatalog
+	Interval time.Duration
+	// Only report the orphans , without deleting them.
+	DryRun bool
+}
+
+// Start implements manager.Runnable.
+func (s *Sweeper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := s.Sweep(ctx); err != nil {
+				s.Log.Error(err, "Failed to sweep orphaned resources")
+			}
+		}
+	}
+}
+
+// Sweep deletes , or only reports in dry run mode , the orphaned resources
+// and returns them.
+func (s *Sweeper) Sweep(ctx context.Context) ([]client.Object, error) {
+	labeled, err := labels.NewRequirement(constants.RBACRuleLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	opts := client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*labeled)}
+
+	// the resources are only listed through their metadata , so the manager
+	// doesn't cache every binding and ServiceAccount of the cluster.
+	var objs []client.Object
+	for _, gvk := range []schema.GroupVersionKind{
+		rbacv1.SchemeGroupVersion.WithKind("RoleBinding"),
+		rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+		corev1.SchemeGroupVersion.WithKind("ServiceAccount"),
+	} {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := s.Client.List(ctx, list, opts); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			list.Items[i].SetGroupVersionKind(gvk)
+			objs = append(objs, &list.Items[i])
+		}
+	}
+
+	catalog := bundles.Catalog{}
+	if s.Bundles != nil {
+		if catalog, err = s.Bundles.Load(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	declared := map[string]*resources{}
+	var orphans []client.Object
+	found := map[[2]string]int{}
+	for _, obj := range objs {
+		rule := obj.GetLabels()[constants.RBACRuleLabel]
+		res, ok := declared[rule]
+		if !ok {
+			res, err = s.declared(ctx, rule, catalog)
+			if err != nil {
+				return nil, err
+			}
+			declared[rule] = res
+		}
+		if res.skip || res.has(obj) {
+			continue
+		}
+		orphans = append(orphans, obj)
+		reason := reasonNotDeclared
+		if res.missing {
+			reason = reasonRuleMissing
+		}
+		found[[2]string{kindOf(obj), reason}]++
+	}
+	// the orphans are exported before they are deleted , so the failures of
+	// the controller to clean up are seen whether they are deleted or not.
+	for _, kind := range []string{"RoleBinding", "ClusterRoleBinding", "ServiceAccount"} {
+		for _, reason := range []string{reasonRuleMissing, reasonNotDeclared} {
+			orphanedGauge.WithLabelValues(kind, reason).Set(float64(found[[2]string{kind, reason}]))
+		}
+	}
+
+	for _, obj := range orphans {
+		log := s.Log.WithValues("kind", kindOf(obj), "name", obj.GetName(), "namespace", obj.GetNamespace(), "rule", obj.GetLabels()[constants.RBACRuleLabel])
+		if s.DryRun {
+			log.Info("Found orphaned resource")
+			continue
+		}
+		if err := s.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		log.Info("Deleted orphaned resource")
+	}
+	return orphans, nil
+}
+
+// resources are the resources declared by a rule.
+type resources struct {
+	// the rule's resources can't be told apart , e.g it is being deleted or
+	// one of its bindings can't be parsed.
+	skip bool
+	// the rule doesn't exist anymore , it declares no resource.
+	missing bool
+	// the resources are keyed by kind , namespace and name.
+	keys map[string]bool
+}
+
+func (r *resources) has(obj client.Object) bool {
+	return r.keys[key(kindOf(obj), obj.GetNamespace(), obj.GetName())]
+}
+
+// declared parses the rule to find the resources it declares.
+func (s *Sweeper) declared(ctx context.Context, name string, catalog bundles.Catalog) (*resources, error) {
+	rule := &rbaccontrollerv1.RBACRule{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Name: name}, rule); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &resources{missing: true}, nil
+		}
+		return nil, err
+	}
+	// the rule's finalizer takes care of its resources.
+	if rule.GetDeletionTimestamp() != nil {
+		return &resources{skip: true}, nil
+	}
+
+	res := &resources{keys: map[string]bool{}}
+	for _, b := range rule.Spec.Bindings {
+		p := &parser.Parser{Client: s.Client, Bundles: catalog}
+		if err := p.Parse(ctx, &b, nil, nil, rule); err != nil {
+			s.Log.Info("Skipping rule , a binding can't be parsed", "rule", rule.Name, "binding", b.Name, "error", err.Error())
+			return &resources{skip: true}, nil
+		}
+		for _, rb := range p.RoleBindings {
+			res.keys[key("RoleBinding", rb.Namespace, rb.Name)] = true
+		}
+		for _, crb := range p.ClusterRoleBindings {
+			res.keys[key("ClusterRoleBinding", "", crb.Name)] = true
+		}
+		for _, sa := range p.ServiceAccounts {
+			res.keys[key("ServiceAccount", sa.Namespace, sa.Name)] = true
+		}
+	}
+	return res, nil
+}
+
+func kindOf(obj client.Object) string {
+	return obj.GetObjectKind().GroupVersionKind().Kind
+}
+
+func key(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sweeper
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+	"github.com/GGh41th/rbac-controller/internal/utils"
+)
+
+func ruleLabels(rule string) map[string]string {
+	return map[string]string{constants.RBACRuleLabel: rule}
+}
+
+func names(objs []client.Object) []string {
+	n := []string{}
+	for _, o := range objs {
+		n = append(n, o.GetName())
+	}
+	return n
+}
+
+var _ = Describe("Sweeper", func() {
+	ctx := context.Background()
+	var c client.Client
+	const uid = "2a7d3c4e"
+	view := utils.GenerateName(uid, "oncall", "sre", parser.CRB, "view")
+	edit := utils.GenerateName(uid, "oncall", "sre", parser.CRB, "edit")
+	admin := utils.GenerateName(uid, "oncall", "sre", parser.CRB, "admin")
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+
+		rule := &rbaccontrollerv1.RBACRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "oncall", UID: uid},
+			Spec: rbaccontrollerv1.RBACRuleSpec{
+				Bindings: []rbaccontrollerv1.Binding{{
+					Name: "sre",
+					Subjects: []rbaccontrollerv1.Subject{{
+						Kind:       rbaccontrollerv1.ServiceAccount,
+						Name:       "pager",
+						Namespaces: []string{"ops"},
+					}},
+					ClusterRoleBindings: []rbaccontrollerv1.ClusterRoleBinding{{ClusterRole: "view"}},
+					RoleBindings: []rbaccontrollerv1.RoleBinding{{
+						ClusterRole: "edit",
+						Namespaces:  []string{"ops"},
+					}},
+				}},
+			},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			rule,
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: view, Labels: ruleLabels("oncall")}},
+			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: edit, Namespace: "ops", Labels: ruleLabels("oncall")}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "pager", Namespace: "ops", Labels: ruleLabels("oncall")}},
+			// a binding that was removed from the rule.
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: admin, Labels: ruleLabels("oncall")}},
+			// the leftovers of a deleted rule.
+			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "gone-dev-Role-edit", Namespace: "dev", Labels: ruleLabels("gone")}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "ci", Namespace: "dev", Labels: ruleLabels("gone")}},
+			// not managed by the controller.
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"}},
+		).Build()
+	})
+
+	It("deletes the resources no rule declares", func() {
+		s := &Sweeper{Client: c, Log: logr.Discard(), Interval: time.Hour}
+
+		orphans, err := s.Sweep(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(orphans)).To(ConsistOf(admin, "gone-dev-Role-edit", "ci"))
+
+		crbs := &rbacv1.ClusterRoleBindingList{}
+		Expect(c.List(ctx, crbs)).To(Succeed())
+		Expect(crbs.Items).To(HaveLen(2))
+		rbs := &rbacv1.RoleBindingList{}
+		Expect(c.List(ctx, rbs)).To(Succeed())
+		Expect(rbs.Items).To(HaveLen(1))
+		sas := &corev1.ServiceAccountList{}
+		Expect(c.List(ctx, sas)).To(Succeed())
+		Expect(sas.Items).To(HaveLen(1))
+	})
+
+	It("only reports the orphans in dry run mode", func() {
+		s := &Sweeper{Client: c, Log: logr.Discard(), Interval: time.Hour, DryRun: true}
+
+		orphans, err := s.Sweep(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(HaveLen(3))
+		Expect(testutil.ToFloat64(orphanedGauge.WithLabelValues("ClusterRoleBinding", reasonNotDeclared))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(orphanedGauge.WithLabelValues("RoleBinding", reasonRuleMissing))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(orphanedGauge.WithLabelValues("ServiceAccount", reasonRuleMissing))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(orphanedGauge.WithLabelValues("ServiceAccount", reasonNotDeclared))).To(BeZero())
+
+		crbs := &rbacv1.ClusterRoleBindingList{}
+		Expect(c.List(ctx, crbs)).To(Succeed())
+		Expect(crbs.Items).To(HaveLen(3))
+	})
+
+	It("leaves the resources of rules that can't be parsed", func() {
+		rule := &rbaccontrollerv1.RBACRule{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "oncall"}, rule)).To(Succeed())
+		rule.Spec.Bindings[0].ClusterRoleBindings = []rbaccontrollerv1.ClusterRoleBinding{{Bundle: "undefined"}}
+		Expect(c.Update(ctx, rule)).To(Succeed())
+		s := &Sweeper{Client: c, Log: logr.Discard(), Interval: time.Hour}
+
+		orphans, err := s.Sweep(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(orphans)).To(ConsistOf("gone-dev-Role-edit", "ci"))
+	})
+})
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package teams reads the catalog of teams , the ClusterRoles the rules of each
+// team may bind. A team owns the namespaces labeled with its name , its rules
+// may only grant access in them.
+package teams
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Catalog maps team names to the ClusterRoles their rules may bind , a
+// trailing * matching any suffix.
+type Catalog map[string][]string
+
+// Defined returns whether the team is defined.
+func (c Catalog) Defined(team string) bool {
+	_, ok := c[team]
+	return ok
+}
+
+// AllowsClusterRole returns whether the rules of the team may bind the
+// ClusterRole. Undefined teams can't bind any.
+func (c Catalog) AllowsClusterRole(team, role string) bool {
+	for _, p := range c[team] {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(role, prefix) || p == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Owns returns whether the namespace , given its labels , belongs to the team.
+func Owns(team string, nsLabels map[string]string) bool {
+	owner, found := nsLabels[constants.TeamLabel]
+	return found && owner == team
+}
+
+// ConfigMapCatalog reads the catalog from a ConfigMap , each key is a team and
+// its value lists the ClusterRoles separated by commas or newlines:
+//
+//	payments: view,edit
+//	platform: view,platform:*
+//
+// The ConfigMap is read on each load , so teams can be changed without
+// restarting the controller. A missing ConfigMap is an empty catalog.
+type ConfigMapCatalog struct {
+	Reader    client.Reader
+	ConfigMap types.NamespacedName
+}
+
+// Load reads the catalog.
+func (c *ConfigMapCatalog) Load(ctx context.Context) (Catalog, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Reader.Get(ctx, c.ConfigMap, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return Catalog{}, nil
+		}
+		return nil, err
+	}
+
+	catalog := make(Catalog, len(cm.Data))
+	for team, value := range cm.Data {
+		roles := []string{}
+		for _, r := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == '\n' }) {
+			if r = strings.TrimSpace(r); r != "" {
+				roles = append(roles, r)
+			}
+		}
+		catalog[team] = roles
+	}
+	return catalog, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teams
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("ConfigMapCatalog", func() {
+	ctx := context.Background()
+	name := types.NamespacedName{Namespace: "rbac-controller-system", Name: "teams"}
+
+	It("reads the teams of the ConfigMap", func() {
+		c := &ConfigMapCatalog{
+			Reader: fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: name.Namespace, Name: name.Name},
+				Data: map[string]string{
+					"payments": "view, edit\n",
+					"platform": "view\nplatform:*\n",
+					"auditors": "",
+				},
+			}).Build(),
+			ConfigMap: name,
+		}
+
+		catalog, err := c.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(catalog).To(Equal(Catalog{
+			"payments": {"view", "edit"},
+			"platform": {"view", "platform:*"},
+			"auditors": {},
+		}))
+		Expect(catalog.Defined("auditors")).To(BeTrue())
+	})
+
+	It("is empty when the ConfigMap is missing", func() {
+		c := &ConfigMapCatalog{Reader: fake.NewClientBuilder().Build(), ConfigMap: name}
+
+		catalog, err := c.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(catalog).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Catalog", func() {
+	catalog := Catalog{"platform": {"view", "platform:*"}}
+
+	It("allows the ClusterRoles of the team", func() {
+		Expect(catalog.AllowsClusterRole("platform", "view")).To(BeTrue())
+		Expect(catalog.AllowsClusterRole("platform", "platform:deployer")).To(BeTrue())
+		Expect(catalog.AllowsClusterRole("platform", "edit")).To(BeFalse())
+	})
+
+	It("doesn't allow any ClusterRole to undefined teams", func() {
+		Expect(catalog.Defined("payments")).To(BeFalse())
+		Expect(catalog.AllowsClusterRole("payments", "view")).To(BeFalse())
+	})
+
+	It("matches the namespaces labeled with the team", func() {
+		Expect(Owns("platform", map[string]string{constants.TeamLabel: "platform"})).To(BeTrue())
+		Expect(Owns("platform", map[string]string{constants.TeamLabel: "payments"})).To(BeFalse())
+		Expect(Owns("platform", nil)).To(BeFalse())
+	})
+})
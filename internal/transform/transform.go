@@ -0,0 +1,48 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package transform trims the objects held by the controller's cache , so its
+// memory doesn't grow with fields the controller never reads.
+package transform
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// Strip returns a transform dropping the managed fields of the cached objects ,
+// along with their last-applied-configuration annotation when lastApplied is
+// set. Objects updated from the cache lose the annotation then.
+func Strip(lastApplied bool) toolscache.TransformFunc {
+	return func(obj any) (any, error) {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			// e.g the tombstones of deleted objects , they are left as is.
+			return obj, nil
+		}
+		accessor.SetManagedFields(nil)
+		annotations := accessor.GetAnnotations()
+		if _, found := annotations[corev1.LastAppliedConfigAnnotation]; lastApplied && found {
+			annotations = maps.Clone(annotations)
+			delete(annotations, corev1.LastAppliedConfigAnnotation)
+			accessor.SetAnnotations(annotations)
+		}
+		return obj, nil
+	}
+}
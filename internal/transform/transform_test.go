@@ -0,0 +1,76 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transform
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Strip", func() {
+	binding := func() *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "oncall",
+				Namespace: "team-a",
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: `{"kind":"RoleBinding"}`,
+					"team":                             "a",
+				},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			},
+			RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+		}
+	}
+
+	It("should drop the managed fields", func() {
+		obj, err := Strip(false)(binding())
+		Expect(err).NotTo(HaveOccurred())
+		rb := obj.(*rbacv1.RoleBinding)
+		Expect(rb.ManagedFields).To(BeEmpty())
+		Expect(rb.Annotations).To(HaveKey(corev1.LastAppliedConfigAnnotation))
+		Expect(rb.RoleRef.Name).To(Equal("view"))
+	})
+
+	It("should drop the last applied configuration when asked to", func() {
+		original := binding()
+		annotations := original.Annotations
+		obj, err := Strip(true)(original)
+		Expect(err).NotTo(HaveOccurred())
+		rb := obj.(*rbacv1.RoleBinding)
+		Expect(rb.Annotations).To(Equal(map[string]string{"team": "a"}))
+		// the annotations are copied , not edited in place.
+		Expect(annotations).To(HaveKey(corev1.LastAppliedConfigAnnotation))
+	})
+
+	It("should trim the metadata of objects cached through their metadata", func() {
+		obj, err := Strip(true)(&metav1.PartialObjectMetadata{ObjectMeta: binding().ObjectMeta})
+		Expect(err).NotTo(HaveOccurred())
+		partial := obj.(*metav1.PartialObjectMetadata)
+		Expect(partial.ManagedFields).To(BeEmpty())
+		Expect(partial.Annotations).NotTo(HaveKey(corev1.LastAppliedConfigAnnotation))
+	})
+
+	It("should leave the tombstones of deleted objects as they are", func() {
+		tombstone := toolscache.DeletedFinalStateUnknown{Key: "team-a/oncall", Obj: binding()}
+		Expect(Strip(true)(tombstone)).To(Equal(tombstone))
+	})
+})
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package usage records when the bindings managed by the controller were last
+// used , from the events sent by the API server audit webhook backend.
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// Path is the path the audit events are received on.
+const Path = "/audit"
+
+// Granularity is how much the last use of a binding must move forward to be
+// written , to bound the status updates.
+const Granularity = time.Minute
+
+const (
+	decisionAnnotation = "authorization.k8s.io/decision"
+	reasonAnnotation   = "authorization.k8s.io/reason"
+)
+
+// allowedBy matches the reason given by the RBAC authorizer , e.g
+// RBAC: allowed by RoleBinding "name/namespace" of ClusterRole "view" to User "alice".
+var allowedBy = regexp.MustCompile(`RBAC: allowed by (ClusterRoleBinding|RoleBinding) "([^"]+)"`)
+
+// binding identifies a RoleBinding or ClusterRoleBinding.
+type binding struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// Recorder receives audit event lists , finds the binding that authorized each
+// request and records when the bindings were last used. The uses are written to
+// the status of the rules every FlushInterval.
+type Recorder struct {
+	Client        client.Client
+	Log           logr.Logger
+	FlushInterval time.Duration
+
+	mu      sync.Mutex
+	pending map[binding]time.Time
+}
+
+// SetupWithManager registers the audit endpoint in the manager's webhook
+// server , and the recorder in the manager.
+func (r *Recorder) SetupWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(Path, r)
+	return mgr.Add(r)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable , every replica
+// receives audit events and writes the uses it recorded.
+func (r *Recorder) NeedLeaderElection() bool {
+	return false
+}
+
+// ServeHTTP receives the event lists posted by the audit webhook backend.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	events := &auditv1.EventList{}
+	if err := json.NewDecoder(req.Body).Decode(events); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, e := range events.Items {
+		r.Record(&e)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Record records the use of the binding that authorized the event's request ,
+// if any.
+func (r *Recorder) Record(e *auditv1.Event) {
+	if e.Annotations[decisionAnnotation] != "allow" {
+		return
+	}
+	m := allowedBy.FindStringSubmatch(e.Annotations[reasonAnnotation])
+	if m == nil {
+		return
+	}
+	b := binding{kind: m[1], name: m[2]}
+	if b.kind == "RoleBinding" {
+		// RoleBindings are described as name/namespace.
+		var ok bool
+		if b.name, b.namespace, ok = strings.Cut(m[2], "/"); !ok {
+			return
+		}
+	}
+	at := e.RequestReceivedTimestamp.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r.record(map[binding]time.Time{b: at})
+}
+
+func (r *Recorder) record(uses map[binding]time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == nil {
+		r.pending = map[binding]time.Time{}
+	}
+	for b, at := range uses {
+		if at.After(r.pending[b]) {
+			r.pending[b] = at
+		}
+	}
+}
+
+// Start implements manager.Runnable.
+func (r *Recorder) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.Log.Error(err, "Failed to record the use of bindings")
+			}
+		}
+	}
+}
+
+// Flush writes the recorded uses to the status of the rules managing the
+// bindings. Uses of bindings the controller doesn't manage are dropped , the
+// uses that couldn't be written are kept for the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+
+	// the uses are grouped by rule , to write each rule once.
+	uses := map[string]map[binding]time.Time{}
+	for b, at := range pending {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind(b.kind))
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: b.namespace, Name: b.name}, obj); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				r.record(pending)
+				return err
+			}
+			continue
+		}
+		rule := obj.GetLabels()[constants.RBACRuleLabel]
+		if rule == "" {
+			continue
+		}
+		if uses[rule] == nil {
+			uses[rule] = map[binding]time.Time{}
+		}
+		uses[rule][b] = at
+	}
+
+	var errs []error
+	for name, used := range uses {
+		rule := &rbaccontrollerv1.RBACRule{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: name}, rule); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				r.record(used)
+				errs = append(errs, err)
+			}
+			continue
+		}
+		base := rule.DeepCopy()
+		changed := false
+		for i := range rule.Status.Bindings {
+			bs := &rule.Status.Bindings[i]
+			for b, at := range used {
+				if !owns(bs, b) || (bs.LastUsed != nil && at.Before(bs.LastUsed.Add(Granularity))) {
+					continue
+				}
+				last := metav1.NewTime(at.Truncate(time.Second))
+				bs.LastUsed = &last
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		// the bindings statuses are replaced as a whole , the patch fails
+		// when the rule changed meanwhile.
+		patch := client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})
+		if err := r.Client.Status().Patch(ctx, rule, patch); client.IgnoreNotFound(err) != nil {
+			r.record(used)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// owns reports whether the binding was established for the rule's binding.
+func owns(bs *rbaccontrollerv1.BindingStatus, b binding) bool {
+	if b.kind == "ClusterRoleBinding" {
+		return slices.Contains(bs.ClusterRoleBindings, b.name)
+	}
+	return slices.Contains(bs.RoleBindings, b.namespace+"/"+b.name)
+}
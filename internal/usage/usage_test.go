@@ -0,0 +1,132 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	auditv1 "k8s.io/apiserver/pkg/apis/audit/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+func event(reason string, at time.Time) auditv1.Event {
+	return auditv1.Event{
+		RequestReceivedTimestamp: metav1.NewMicroTime(at),
+		Annotations: map[string]string{
+			decisionAnnotation: "allow",
+			reasonAnnotation:   reason,
+		},
+	}
+}
+
+var _ = Describe("Recorder", func() {
+	ctx := context.Background()
+	var c client.Client
+	var r *Recorder
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	labels := map[string]string{constants.RBACRuleLabel: "oncall"}
+
+	lastUsed := func(binding string) *metav1.Time {
+		rule := &rbaccontrollerv1.RBACRule{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "oncall"}, rule)).To(Succeed())
+		for _, bs := range rule.Status.Bindings {
+			if bs.Name == binding {
+				return bs.LastUsed
+			}
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+
+		rule := &rbaccontrollerv1.RBACRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "oncall"},
+			Status: rbaccontrollerv1.RBACRuleStatus{Bindings: []rbaccontrollerv1.BindingStatus{
+				{Name: "sre", ClusterRoleBindings: []string{"oncall-view"}},
+				{Name: "dev", RoleBindings: []string{"team-a/oncall-edit"}},
+			}},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(rule).WithObjects(
+			rule,
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "oncall-view", Labels: labels}},
+			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "oncall-edit", Namespace: "team-a", Labels: labels}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "admin"}},
+		).Build()
+		r = &Recorder{Client: c, Log: logr.Discard()}
+	})
+
+	It("records the last use of the bindings from the audit events", func() {
+		events := &auditv1.EventList{Items: []auditv1.Event{
+			event(`RBAC: allowed by ClusterRoleBinding "oncall-view" of ClusterRole "view" to User "alice"`, at),
+			event(`RBAC: allowed by ClusterRoleBinding "oncall-view" of ClusterRole "view" to User "alice"`, at.Add(-time.Hour)),
+			event(`RBAC: allowed by RoleBinding "oncall-edit/team-a" of ClusterRole "edit" to Group "sre"`, at),
+			event(`RBAC: allowed by ClusterRoleBinding "admin" of ClusterRole "cluster-admin" to User "bob"`, at),
+		}}
+		body, err := json.Marshal(events)
+		Expect(err).NotTo(HaveOccurred())
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+		Expect(w.Code).To(Equal(http.StatusOK))
+
+		Expect(r.Flush(ctx)).To(Succeed())
+		Expect(lastUsed("sre").Time).To(BeTemporally("==", at))
+		Expect(lastUsed("dev").Time).To(BeTemporally("==", at))
+	})
+
+	It("only writes uses moving forward by the granularity", func() {
+		e := event(`RBAC: allowed by ClusterRoleBinding "oncall-view" of ClusterRole "view" to User "alice"`, at)
+		r.Record(&e)
+		Expect(r.Flush(ctx)).To(Succeed())
+
+		e = event(`RBAC: allowed by ClusterRoleBinding "oncall-view" of ClusterRole "view" to User "alice"`, at.Add(10*time.Second))
+		r.Record(&e)
+		Expect(r.Flush(ctx)).To(Succeed())
+		Expect(lastUsed("sre").Time).To(BeTemporally("==", at))
+
+		e = event(`RBAC: allowed by ClusterRoleBinding "oncall-view" of ClusterRole "view" to User "alice"`, at.Add(Granularity))
+		r.Record(&e)
+		Expect(r.Flush(ctx)).To(Succeed())
+		Expect(lastUsed("sre").Time).To(BeTemporally("==", at.Add(Granularity)))
+	})
+
+	It("ignores denied requests", func() {
+		e := event(`RBAC: allowed by ClusterRoleBinding "oncall-view" of ClusterRole "view" to User "alice"`, at)
+		e.Annotations[decisionAnnotation] = "forbid"
+		r.Record(&e)
+		Expect(r.Flush(ctx)).To(Succeed())
+		Expect(lastUsed("sre")).To(BeNil())
+	})
+})
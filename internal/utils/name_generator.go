@@ -1,7 +1,30 @@
 package utils
 
-import "strings"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 
-func GenerateName(RBACRuleName, BN, Kind, RN string) string {
-	return strings.Join([]string{RBACRuleName, BN, Kind, RN}, "-")
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// maxNameLength is the longest name of a generated object.
+	maxNameLength = 253
+	// hashLength is the length of the hash suffixing generated names.
+	hashLength = 10
+)
+
+// GenerateName returns the name of the object generated for the binding BN of
+// the rule , binding the role RN of the given kind. The readable part of the
+// name is truncated to fit the name length limit , and suffixed with a hash of
+// the rule UID , the binding and the role , so names don't collide across rules
+// or across bindings whose names join the same way.
+func GenerateName(RBACRuleUID types.UID, RBACRuleName, BN, Kind, RN string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{string(RBACRuleUID), BN, Kind, RN}, "\x00")))
+	name := strings.Join([]string{RBACRuleName, BN, Kind, RN}, "-")
+	if max := maxNameLength - hashLength - 1; len(name) > max {
+		name = name[:max]
+	}
+	return name + "-" + hex.EncodeToString(sum[:])[:hashLength]
 }
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bindings holds the webhook protecting the RoleBindings and
+// ClusterRoleBindings managed by the controller from manual edits.
+package bindings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+)
+
+// Path is the path the protection webhook is served on.
+const Path = "/validate-rbac-bindings"
+
+var protectionlog = logf.Log.WithName("binding-protection")
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=selfsubjectreviews,verbs=create
+// +kubebuilder:webhook:path=/validate-rbac-bindings,mutating=false,failurePolicy=ignore,sideEffects=None,groups=rbac.authorization.k8s.io,resources=rolebindings;clusterrolebindings,verbs=update;delete,versions=v1,name=vbinding-protection.kb.io,admissionReviewVersions=v1
+
+// Protection rejects updates and deletions of the bindings carrying the rule
+// label , unless they come from the controller , one of the exempt users , or ,
+// when impersonating , the creator of the rule.
+type Protection struct {
+	// Enforce is false when every change is allowed.
+	Enforce bool
+	// Reader reads the rules , to find their creator.
+	Reader client.Reader
+	// Users are the controller's own user and the exempt users.
+	Users []string
+	// Impersonation is set when bindings are written on behalf of the rules
+	// creators.
+	Impersonation bool
+}
+
+var _ admission.Handler = &Protection{}
+
+// SetupWithManager registers the webhook in the manager.
+func (p *Protection) SetupWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register(Path, &webhook.Admission{Handler: p})
+}
+
+// Handle implements admission.Handler.
+func (p *Protection) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if !p.Enforce || (req.Operation != admissionv1.Update && req.Operation != admissionv1.Delete) {
+		return admission.Allowed("")
+	}
+	rule, err := ruleOf(req)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if rule == "" || slices.Contains(p.Users, req.UserInfo.Username) {
+		return admission.Allowed("")
+	}
+	if p.Impersonation {
+		creator, err := p.creator(ctx, rule)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if creator != nil && creator.Username == req.UserInfo.Username {
+			return admission.Allowed("")
+		}
+	}
+	protectionlog.Info("Rejecting manual change of a managed binding", "kind", req.Kind.Kind, "name", req.Name,
+		"namespace", req.Namespace, "user", req.UserInfo.Username, "operation", req.Operation)
+	return admission.Denied(fmt.Sprintf("%s %s is managed by RBACRule %s , update the rule instead", req.Kind.Kind, req.Name, rule))
+}
+
+// ruleOf returns the rule managing the binding , before or after the change ,
+// empty when the binding isn't managed.
+func ruleOf(req admission.Request) (string, error) {
+	for _, raw := range [][]byte{req.OldObject.Raw, req.Object.Raw} {
+		if len(raw) == 0 {
+			continue
+		}
+		obj := &metav1.PartialObjectMetadata{}
+		if err := json.Unmarshal(raw, obj); err != nil {
+			return "", err
+		}
+		if rule := obj.Labels[constants.RBACRuleLabel]; rule != "" {
+			return rule, nil
+		}
+	}
+	return "", nil
+}
+
+// creator returns the recorded creator of the rule , nil when the rule or its
+// creator is unknown.
+func (p *Protection) creator(ctx context.Context, name string) (*impersonation.User, error) {
+	rule := &rbaccontrollerv1.RBACRule{}
+	if err := p.Reader.Get(ctx, types.NamespacedName{Name: name}, rule); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	u, found, err := impersonation.Creator(rule)
+	if err != nil || !found {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+// WhoAmI returns the username the client authenticates as.
+func WhoAmI(ctx context.Context, c client.Client) (string, error) {
+	review := &authenticationv1.SelfSubjectReview{}
+	if err := c.Create(ctx, review); err != nil {
+		return "", fmt.Errorf("failed to review the controller's own user: %w", err)
+	}
+	return review.Status.UserInfo.Username, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bindings
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+)
+
+const controllerUser = "system:serviceaccount:rbac-controller-system:rbac-controller"
+
+func request(op admissionv1.Operation, user string, labels map[string]string) admission.Request {
+	rb := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "rule-b-Role-view", Namespace: "dev", Labels: labels}}
+	raw, err := json.Marshal(rb)
+	Expect(err).NotTo(HaveOccurred())
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: op,
+		Name:      rb.Name,
+		Namespace: rb.Namespace,
+		Kind:      metav1.GroupVersionKind{Group: rbacv1.GroupName, Version: "v1", Kind: "RoleBinding"},
+		UserInfo:  authenticationv1.UserInfo{Username: user},
+		OldObject: runtime.RawExtension{Raw: raw},
+	}}
+	if op == admissionv1.Update {
+		req.Object = runtime.RawExtension{Raw: raw}
+	}
+	return req
+}
+
+var _ = Describe("Protection", func() {
+	ctx := context.Background()
+	managed := map[string]string{constants.RBACRuleLabel: "rule"}
+	var p *Protection
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(rbaccontrollerv1.AddToScheme(scheme)).To(Succeed())
+		rule := &rbaccontrollerv1.RBACRule{ObjectMeta: metav1.ObjectMeta{Name: "rule"}}
+		Expect(impersonation.SetCreator(rule, impersonation.User{Username: "alice"})).To(Succeed())
+		p = &Protection{
+			Enforce: true,
+			Reader:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(rule).Build(),
+			Users:   []string{controllerUser},
+		}
+	})
+
+	It("rejects manual changes of managed bindings", func() {
+		for _, op := range []admissionv1.Operation{admissionv1.Update, admissionv1.Delete} {
+			resp := p.Handle(ctx, request(op, "bob", managed))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("managed by RBACRule rule"))
+		}
+	})
+
+	It("allows the controller and exempt users", func() {
+		Expect(p.Handle(ctx, request(admissionv1.Delete, controllerUser, managed)).Allowed).To(BeTrue())
+	})
+
+	It("allows changes of unmanaged bindings", func() {
+		Expect(p.Handle(ctx, request(admissionv1.Update, "bob", nil)).Allowed).To(BeTrue())
+	})
+
+	It("allows every change when not enforced", func() {
+		p.Enforce = false
+		Expect(p.Handle(ctx, request(admissionv1.Delete, "bob", managed)).Allowed).To(BeTrue())
+	})
+
+	It("allows the rule's creator when impersonating", func() {
+		Expect(p.Handle(ctx, request(admissionv1.Update, "alice", managed)).Allowed).To(BeFalse())
+		p.Impersonation = true
+		Expect(p.Handle(ctx, request(admissionv1.Update, "alice", managed)).Allowed).To(BeTrue())
+		Expect(p.Handle(ctx, request(admissionv1.Update, "bob", managed)).Allowed).To(BeFalse())
+	})
+})
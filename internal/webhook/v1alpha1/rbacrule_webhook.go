@@ -18,74 +18,200 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	rbaccontrollerv1alpha1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
-)
-
-const (
-	DEFAULT_NAMESPACE = "default"
+	"github.com/GGh41th/rbac-controller/internal/approvals"
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/config"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+	"github.com/GGh41th/rbac-controller/internal/impersonation"
+	"github.com/GGh41th/rbac-controller/internal/parser"
+	"github.com/GGh41th/rbac-controller/internal/rego"
+	"github.com/GGh41th/rbac-controller/internal/requesters"
+	"github.com/GGh41th/rbac-controller/internal/risk"
+	"github.com/GGh41th/rbac-controller/internal/roletemplates"
+	"github.com/GGh41th/rbac-controller/internal/teams"
+	"github.com/GGh41th/rbac-controller/internal/tracing"
 )
 
 // nolint:unused
 // log is for logging in this package.
 var rbacrulelog = logf.Log.WithName("rbacrule-resource")
 
-// SetupRBACRuleWebhookWithManager registers the webhook for RBACRule in the manager.
-func SetupRBACRuleWebhookWithManager(mgr ctrl.Manager) error {
+// SetupRBACRuleWebhookWithManager registers the webhook for RBACRule in the
+// manager. Rules are also validated against the Rego policies when an
+// evaluator is provided , and against their team when a catalog of teams is.
+// The roles of role bundles and templates are scored when their catalog and
+// source are provided.
+func SetupRBACRuleWebhookWithManager(mgr ctrl.Manager, cfg *config.Config, policies *rego.Evaluator, catalog *teams.ConfigMapCatalog, roleBundles *bundles.ConfigMapCatalog, roleTemplates *roletemplates.Source) error {
+	// roles are scored from the API server , the manager only caches the
+	// metadata of roles.
+	scorer := &risk.Scorer{
+		Reader:           mgr.GetAPIReader(),
+		Bundles:          roleBundles,
+		Templates:        roleTemplates,
+		SkipClusterRoles: cfg.IsNamespaceRestricted(),
+	}
 	return ctrl.NewWebhookManagedBy(mgr).For(&rbaccontrollerv1alpha1.RBACRule{}).
-		WithValidator(&RBACRuleCustomValidator{}).
-		WithDefaulter(&RBACRuleCustomDefaulter{}).
+		WithValidator(&RBACRuleCustomValidator{Config: cfg, Client: mgr.GetClient(), Policies: policies, Teams: catalog, Bundles: roleBundles}).
+		WithDefaulter(&RBACRuleCustomDefaulter{Config: cfg, Risk: scorer}).
 		Complete()
 }
 
 // +kubebuilder:webhook:path=/mutate-rbac-controller-ggh41th-io-v1alpha1-rbacrule,mutating=true,failurePolicy=fail,sideEffects=None,groups=rbac-controller.ggh41th.io,resources=rbacrules,verbs=create;update,versions=v1alpha1,name=mrbacrule-v1alpha1.kb.io,admissionReviewVersions=v1
 
 type RBACRuleCustomDefaulter struct {
+	Config *config.Config
+	// Scores the risk of rules , it isn't recorded when nil.
+	Risk *risk.Scorer
+	// The source of the current time , the real clock when nil.
+	Clock clock.PassiveClock
 }
 
 var _ webhook.CustomDefaulter = &RBACRuleCustomDefaulter{}
 
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind RBACRule.
-func (d *RBACRuleCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+func (d *RBACRuleCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
 	rbacrule, ok := obj.(*rbaccontrollerv1alpha1.RBACRule)
 
 	if !ok {
 		return fmt.Errorf("expected an RBACRule object but got %T", obj)
 	}
+	_, span := tracing.Tracer().Start(ctx, "Default", trace.WithAttributes(attribute.String("rbacrule", rbacrule.GetName())))
+	defer span.End()
 	rbacrulelog.Info("Defaulting for RBACRule", "name", rbacrule.GetName())
 
 	if rbacrule.Spec.Bindings != nil {
 		// we need to change the actual Bindings struct , we should do it this
 		// way , ignore the linter.
+		ns := d.Config.GetDefaultNamespace()
 		for i, _ := range rbacrule.Spec.Bindings {
-			defaultSubjectsNs(rbacrule.Spec.Bindings[i].Subjects)
-			defaultRolesNS(rbacrule.Spec.Bindings[i].RoleBindings)
+			defaultSubjectsNs(rbacrule.Spec.Bindings[i].Subjects, ns)
+			defaultRolesNS(rbacrule.Spec.Bindings[i].RoleBindings, ns)
+		}
+	}
+
+	// the creator is recorded so the controller can impersonate them , any
+	// value set by the requester is overwritten.
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == admissionv1.Create {
+		if err := impersonation.SetCreator(rbacrule, impersonation.User{
+			Username: req.UserInfo.Username,
+			UID:      req.UserInfo.UID,
+			Groups:   req.UserInfo.Groups,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// temporary access by default , rules without an EndTime expire after
+	// the default TTL , or the break-glass one.
+	ttl := d.Config.GetDefaultTTL()
+	if rbacrule.Spec.BreakGlass && d.Config.GetBreakGlassMaxTTL() > 0 {
+		ttl = d.Config.GetBreakGlassMaxTTL()
+	}
+	if ttl > 0 && rbacrule.Spec.EndTime.IsZero() {
+		start := rbacrule.Spec.StartTime.Time
+		if start.IsZero() {
+			start = now(d.Clock)
 		}
+		rbacrule.Spec.EndTime = metav1.NewTime(start.Add(ttl))
+	}
+
+	// approvals are recorded with the identity of the approver , once the
+	// rule is defaulted so they are reset only when the spec changes.
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		if err := recordApproval(req, rbacrule); err != nil {
+			return err
+		}
+	}
+
+	// the risk is scored once the rule is defaulted , any value set by the
+	// requester is overwritten.
+	if d.Risk != nil {
+		risk.Annotate(rbacrule, d.Risk.Score(ctx, rbacrule, now(d.Clock)))
 	}
 
 	return nil
 }
-func defaultSubjectsNs(subjs []rbaccontrollerv1alpha1.Subject) {
+
+// recordApproval replaces the approve annotation set by a requester with
+// their identity in the approvals of the rule. Rules are created without
+// approvals , and they are reset when the spec changes.
+func recordApproval(req admission.Request, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	_, approve := rbacrule.Annotations[constants.ApproveAnnotation]
+	delete(rbacrule.Annotations, constants.ApproveAnnotation)
+	switch req.Operation {
+	case admissionv1.Create:
+		approvals.Reset(rbacrule)
+	case admissionv1.Update:
+		old := &rbaccontrollerv1alpha1.RBACRule{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return fmt.Errorf("failed to decode the old rule: %w", err)
+		}
+		if !equality.Semantic.DeepEqual(old.Spec, rbacrule.Spec) {
+			approvals.Reset(rbacrule)
+			return nil
+		}
+		if approve {
+			return approvals.Approve(rbacrule, req.UserInfo.Username)
+		}
+	}
+	return nil
+}
+
+// now returns the current time of the clock , of the real clock when nil.
+func now(c clock.PassiveClock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+func defaultSubjectsNs(subjs []rbaccontrollerv1alpha1.Subject, ns string) {
 	for i, _ := range subjs {
-		if subjs[i].Kind == rbaccontrollerv1alpha1.ServiceAccount && len(subjs[i].Namespaces) == 0 && len(subjs[i].NamespaceMatchExpression) == 0 && reflect.ValueOf(subjs[i].NameSpaceSelector).IsZero() {
-			subjs[i].Namespaces = []string{DEFAULT_NAMESPACE}
+		// the ServiceAccounts selected by label are looked up in every namespace
+		// when the subject selects none.
+		if subjs[i].ServiceAccountSelector != nil {
+			continue
+		}
+		namespaced := subjs[i].Kind == rbaccontrollerv1alpha1.ServiceAccount || subjs[i].Kind == rbaccontrollerv1alpha1.AllServiceAccounts
+		if namespaced && len(subjs[i].Namespaces) == 0 && len(subjs[i].NamespaceMatchExpression) == 0 && reflect.ValueOf(subjs[i].NameSpaceSelector).IsZero() {
+			subjs[i].Namespaces = []string{ns}
 		}
 	}
 }
 
-func defaultRolesNS(rbs []rbaccontrollerv1alpha1.RoleBinding) {
+func defaultRolesNS(rbs []rbaccontrollerv1alpha1.RoleBinding, ns string) {
 	for i, _ := range rbs {
 		if rbs[i].Role != "" && len(rbs[i].Namespaces) == 0 && len(rbs[i].NamespaceMatchExpression) == 0 && reflect.ValueOf(rbs[i].NameSpaceSelector).IsZero() {
-			rbs[i].Namespaces = []string{DEFAULT_NAMESPACE}
+			rbs[i].Namespaces = []string{ns}
 		}
 	}
 }
@@ -100,28 +226,44 @@ func defaultRolesNS(rbs []rbaccontrollerv1alpha1.RoleBinding) {
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type RBACRuleCustomValidator struct {
-	// TODO(user): Add more fields as needed for validation
+	Config *config.Config
+	// Reviews the access of requesters binding blocked ClusterRoles.
+	Client client.Client
+	// Evaluates the Rego policies , they aren't evaluated when nil.
+	Policies *rego.Evaluator
+	// The teams rules can belong to , rules aren't scoped to a team when nil.
+	Teams *teams.ConfigMapCatalog
+	// The role bundles , whose ClusterRoles are checked as the ones bound by
+	// name. Bindings can't reference bundles when nil.
+	Bundles *bundles.ConfigMapCatalog
+	// The source of the current time , the real clock when nil.
+	Clock clock.PassiveClock
 }
 
 var _ webhook.CustomValidator = &RBACRuleCustomValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RBACRule.
-func (v *RBACRuleCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *RBACRuleCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (_ admission.Warnings, err error) {
 	rbacrule, ok := obj.(*rbaccontrollerv1alpha1.RBACRule)
 	if !ok {
 		return nil, fmt.Errorf("expected a RBACRule object but got %T", obj)
 	}
+	_, span := tracing.Tracer().Start(ctx, "ValidateCreate", trace.WithAttributes(attribute.String("rbacrule", rbacrule.GetName())))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 	rbacrulelog.Info("Validation for RBACRule upon creation", "name", rbacrule.GetName())
 
 	start := rbacrule.Spec.StartTime.Time
 	end := rbacrule.Spec.EndTime.Time
-	if start != (time.Time{}) && time.Now().After(start) {
+	if start != (time.Time{}) && now(v.Clock).After(start) {
 		return nil, fmt.Errorf("start time should not be earlier than now")
 	}
 
 	if end != (time.Time{}) {
 
-		if end.Before(time.Now()) {
+		if end.Before(now(v.Clock)) {
 			return nil, fmt.Errorf("end time should not be earlier than now")
 		}
 
@@ -130,19 +272,941 @@ func (v *RBACRuleCustomValidator) ValidateCreate(_ context.Context, obj runtime.
 		}
 	}
 
-	return nil, nil
+	if err := validateUniqueness(rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := validatePatterns(rbacrule); err != nil {
+		return nil, err
+	}
+
+	if approvers, err := approvals.Approvers(rbacrule); err != nil || len(approvers) > 0 {
+		return nil, fmt.Errorf("rules can't be created with approvals , they are approved through the %s annotation", constants.ApproveAnnotation)
+	}
+
+	if err := v.validateNamespaces(rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateTTL(ctx, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.Config.ValidateJustification(rbacrule.Spec.Justification, rbacrule.Spec.TicketURL); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateAllowedRoles(ctx, nil, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateQuota(ctx, nil, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateTeam(ctx, nil, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateRequester(ctx, nil, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateBlockedRoles(ctx, nil, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validatePolicies(ctx, admissionv1.Create, nil, rbacrule); err != nil {
+		return nil, err
+	}
+
+	return v.warnings(ctx, rbacrule), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RBACRule.
-func (v *RBACRuleCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *RBACRuleCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (_ admission.Warnings, err error) {
 	rbacrule, ok := newObj.(*rbaccontrollerv1alpha1.RBACRule)
 	if !ok {
 		return nil, fmt.Errorf("expected a RBACRule object for the newObj but got %T", newObj)
 	}
+	_, span := tracing.Tracer().Start(ctx, "ValidateUpdate", trace.WithAttributes(attribute.String("rbacrule", rbacrule.GetName())))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 	rbacrulelog.Info("Validation for RBACRule upon update", "name", rbacrule.GetName())
 
-	// TODO(user): fill in your validation logic upon object update.
-	return nil, nil
+	old, ok := oldObj.(*rbaccontrollerv1alpha1.RBACRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a RBACRule object for the oldObj but got %T", oldObj)
+	}
+	if err := validateImmutableBindings(old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	// the creator is impersonated by the controller , it can't be forged.
+	if old.Annotations[constants.CreatedByAnnotation] != rbacrule.Annotations[constants.CreatedByAnnotation] {
+		return nil, fmt.Errorf("annotation %s can't be changed", constants.CreatedByAnnotation)
+	}
+
+	if err := validateApprovals(ctx, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	// the times of a rule can be edited , e.g to reactivate it , its window
+	// has to stay consistent.
+	if err := v.validateSchedule(old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	// rules admitted before duplicates were rejected can still be updated ,
+	// e.g to remove their finalizer.
+	if err := validateUniqueness(rbacrule); err != nil && validateUniqueness(old) == nil {
+		return nil, err
+	}
+
+	if err := validatePatterns(rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateNamespaces(rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateTTL(ctx, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.Config.ValidateJustification(rbacrule.Spec.Justification, rbacrule.Spec.TicketURL); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateAllowedRoles(ctx, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateQuota(ctx, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateTeam(ctx, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateRequester(ctx, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateBlockedRoles(ctx, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	if err := v.validatePolicies(ctx, admissionv1.Update, old, rbacrule); err != nil {
+		return nil, err
+	}
+
+	return v.warnings(ctx, rbacrule), nil
+}
+
+// validateApprovals makes sure approvals are only recorded through the
+// webhook: requesters can only add their own approval , not to the rules they
+// created , and approvals can't be withdrawn. Approvals of a rule whose spec
+// changed are reset.
+func validateApprovals(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	after, err := approvals.Approvers(rbacrule)
+	if err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(old.Spec, rbacrule.Spec) {
+		if len(after) > 0 {
+			return fmt.Errorf("approvals are reset when the spec of the rule changes")
+		}
+		return nil
+	}
+	// approvals recorded before can't be withdrawn , an invalid record is
+	// replaced.
+	before, _ := approvals.Approvers(old)
+	for _, a := range before {
+		if !slices.Contains(after, a) {
+			return fmt.Errorf("the approval of %s can't be withdrawn", a)
+		}
+	}
+	added := slices.DeleteFunc(slices.Clone(after), func(a string) bool { return slices.Contains(before, a) })
+	if len(added) == 0 {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if len(added) > 1 || added[0] != req.UserInfo.Username {
+		return fmt.Errorf("requesters can only record their own approval , through the %s annotation", constants.ApproveAnnotation)
+	}
+	if creator, found, _ := impersonation.Creator(rbacrule); found && creator.Username == req.UserInfo.Username {
+		return fmt.Errorf("%s created the rule and can't approve it", req.UserInfo.Username)
+	}
+	return nil
+}
+
+// validateSchedule rejects edited times leaving the rule with a window ending
+// before it starts , or ending in the past. Bindings share the window of
+// their rule , there are no per-binding times or recurring schedules to
+// check. Times that weren't edited aren't checked again , so rules past their
+// window can still be updated.
+func (v *RBACRuleCustomValidator) validateSchedule(old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	start, end := rbacrule.Spec.StartTime, rbacrule.Spec.EndTime
+	if old.Spec.StartTime.Equal(&start) && old.Spec.EndTime.Equal(&end) {
+		return nil
+	}
+	if !start.IsZero() && !end.IsZero() && start.After(end.Time) {
+		return fmt.Errorf("start time %s should not be higher than end time %s",
+			start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	}
+	if !old.Spec.EndTime.Equal(&end) && !end.IsZero() && end.Time.Before(now(v.Clock)) {
+		return fmt.Errorf("end time %s should not be earlier than now", end.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateUniqueness rejects rules holding bindings with the same name , and
+// bindings listing a subject twice , which would generate colliding names
+// and repeated subjects. ServiceAccounts are the same subject when listed in
+// the same namespace.
+func validateUniqueness(rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	names := map[string]int{}
+	for i, b := range rbacrule.Spec.Bindings {
+		if j, found := names[b.Name]; found {
+			return fmt.Errorf("bindings[%d]: name %s is already used by bindings[%d]", i, b.Name, j)
+		}
+		names[b.Name] = i
+
+		subjects := map[string]int{}
+		for j, s := range b.Subjects {
+			// the ServiceAccounts selected by label or bound by
+			// AllServiceAccounts are deduplicated by the parser.
+			if s.ServiceAccountSelector != nil || s.Kind == rbaccontrollerv1alpha1.AllServiceAccounts {
+				continue
+			}
+			for _, name := range parser.SubjectNames(&s) {
+				keys := []string{string(s.Kind) + "/" + name}
+				if s.Kind == rbaccontrollerv1alpha1.ServiceAccount {
+					keys = keys[:0]
+					for _, ns := range s.Namespaces {
+						keys = append(keys, string(s.Kind)+"/"+ns+"/"+name)
+					}
+				}
+				for _, key := range keys {
+					if k, found := subjects[key]; found && k != j {
+						return fmt.Errorf("bindings[%d].subjects[%d]: %s %s is already listed by subjects[%d]", i, j, s.Kind, name, k)
+					}
+					subjects[key] = j
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePatterns rejects the invalid shell patterns among the names of
+// ServiceAccount subjects , and patterns on subjects creating their
+// ServiceAccounts or requesting tokens , the matched ServiceAccounts exist
+// already and are only bound.
+func validatePatterns(rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	for i, b := range rbacrule.Spec.Bindings {
+		for j, s := range b.Subjects {
+			if s.Kind != rbaccontrollerv1alpha1.ServiceAccount {
+				continue
+			}
+			for _, name := range parser.SubjectNames(&s) {
+				if !parser.IsGlob(name) {
+					continue
+				}
+				if err := parser.CompileGlob(name); err != nil {
+					return fmt.Errorf("bindings[%d].subjects[%d]: %w", i, j, err)
+				}
+				if s.CreateSA || s.GenerateToken {
+					return fmt.Errorf("bindings[%d].subjects[%d]: the ServiceAccounts matched by pattern %s can't be created nor get a token", i, j, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateImmutableBindings rejects renaming a binding or changing the role an
+// entry binds in place , the bindings generated for the old name or role would
+// be left behind (the roleRef of native bindings is immutable anyway). Bindings
+// and entries can still be added or removed.
+func validateImmutableBindings(old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	oldBindings := map[string]*rbaccontrollerv1alpha1.Binding{}
+	for i := range old.Spec.Bindings {
+		oldBindings[old.Spec.Bindings[i].Name] = &old.Spec.Bindings[i]
+	}
+	newNames := map[string]bool{}
+	for _, b := range rbacrule.Spec.Bindings {
+		newNames[b.Name] = true
+	}
+
+	for i, b := range rbacrule.Spec.Bindings {
+		ob, found := oldBindings[b.Name]
+		if !found {
+			// a binding replacing , at the same position , one that is gone
+			// was renamed.
+			if i < len(old.Spec.Bindings) && !newNames[old.Spec.Bindings[i].Name] {
+				return fmt.Errorf("bindings[%d]: name can't be changed from %s to %s , add a new binding instead", i, old.Spec.Bindings[i].Name, b.Name)
+			}
+			continue
+		}
+		if j := replacedInPlace(ob.RoleBindings, b.RoleBindings, roleBindingRef); j >= 0 {
+			return fmt.Errorf("bindings[%d].roleBindings[%d]: the bound role can't be changed , add a new entry instead", i, j)
+		}
+		if j := replacedInPlace(ob.ClusterRoleBindings, b.ClusterRoleBindings, clusterRoleBindingRef); j >= 0 {
+			return fmt.Errorf("bindings[%d].clusterRoleBindings[%d]: the bound role can't be changed , add a new entry instead", i, j)
+		}
+	}
+	return nil
+}
+
+// replacedInPlace returns the index of the first entry whose role was changed ,
+// i.e whose role isn't bound by any old entry while the old entry at the same
+// position is gone , -1 if there is none.
+func replacedInPlace[T any](old, entries []T, ref func(T) string) int {
+	oldRefs := map[string]bool{}
+	for _, e := range old {
+		oldRefs[ref(e)] = true
+	}
+	refs := map[string]bool{}
+	for _, e := range entries {
+		refs[ref(e)] = true
+	}
+	for j, e := range entries {
+		if j < len(old) && !oldRefs[ref(e)] && !refs[ref(old[j])] {
+			return j
+		}
+	}
+	return -1
+}
+
+func roleBindingRef(rb rbaccontrollerv1alpha1.RoleBinding) string {
+	return rb.Role + "/" + rb.ClusterRole + "/" + rb.Bundle + "/" + rb.RoleTemplate
+}
+
+func clusterRoleBindingRef(crb rbaccontrollerv1alpha1.ClusterRoleBinding) string {
+	return crb.ClusterRole + "/" + crb.Bundle + "/" + crb.RoleTemplate
+}
+
+// validateTTL rejects rules living longer than the maximum TTL of the roles
+// they bind , or than the break-glass one. Rules start at their StartTime , or when they were created.
+// Bundles are expanded to their ClusterRoles , the bundles themselves still
+// count as roles so the rules binding undefined ones are limited too.
+func (v *RBACRuleCustomValidator) validateTTL(ctx context.Context, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	catalog, err := v.loadBundles(ctx)
+	if err != nil {
+		return err
+	}
+	var roles []string
+	for _, b := range rbacrule.Spec.Bindings {
+		for _, crb := range b.ClusterRoleBindings {
+			roles = append(roles, crb.ClusterRole, crb.Bundle)
+			roles = append(roles, catalog[crb.Bundle]...)
+		}
+		for _, rb := range b.RoleBindings {
+			roles = append(roles, rb.Role, rb.ClusterRole, rb.Bundle)
+			roles = append(roles, catalog[rb.Bundle]...)
+		}
+	}
+	roles = slices.DeleteFunc(roles, func(r string) bool { return r == "" })
+	maxTTL, limited := v.Config.MaxTTLFor(roles)
+	// break-glass rules are capped whatever roles they bind.
+	if limit := v.Config.GetBreakGlassMaxTTL(); rbacrule.Spec.BreakGlass && limit > 0 && (!limited || limit < maxTTL) {
+		maxTTL, limited = limit, true
+	}
+	if !limited {
+		return nil
+	}
+
+	if rbacrule.Spec.EndTime.IsZero() {
+		return fmt.Errorf("an end time is required , rules can't be granted for more than %s", maxTTL)
+	}
+	start := rbacrule.Spec.StartTime.Time
+	if start.IsZero() {
+		start = rbacrule.CreationTimestamp.Time
+	}
+	if start.IsZero() {
+		start = now(v.Clock)
+	}
+	if ttl := rbacrule.Spec.EndTime.Sub(start); ttl > maxTTL {
+		return fmt.Errorf("rules can't be granted for more than %s , this one lasts %s", maxTTL, ttl.Round(time.Second))
+	}
+	return nil
+}
+
+// validateBlockedRoles rejects rules binding blocked ClusterRoles , unless
+// they are annotated to allow it and the requester may bind those roles
+// themselves. The roles the old version of the rule binds aren't checked
+// again , so updates by whoever can't bind them (e.g the controller) go
+// through.
+func (v *RBACRuleCustomValidator) validateBlockedRoles(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	added, err := v.addedClusterRoles(ctx, old, rbacrule)
+	if err != nil {
+		return err
+	}
+	blocked := slices.DeleteFunc(added, func(r string) bool {
+		return !v.Config.IsBlockedClusterRole(r)
+	})
+	if len(blocked) == 0 {
+		return nil
+	}
+	if rbacrule.Annotations[constants.AllowBlockedRolesAnnotation] != "true" {
+		return fmt.Errorf("ClusterRoles %s are blocked , the %s=true annotation is required to bind them",
+			strings.Join(blocked, ", "), constants.AllowBlockedRolesAnnotation)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	for _, role := range blocked {
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   req.UserInfo.Username,
+				UID:    req.UserInfo.UID,
+				Groups: req.UserInfo.Groups,
+				Extra:  extra,
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Group:    rbacv1.GroupName,
+					Resource: "clusterroles",
+					Verb:     "bind",
+					Name:     role,
+				},
+			},
+		}
+		if err := v.Client.Create(ctx, review); err != nil {
+			return fmt.Errorf("failed to review the access of %s: %w", req.UserInfo.Username, err)
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf("%s isn't allowed to bind the blocked ClusterRole %s", req.UserInfo.Username, role)
+		}
+	}
+	return nil
+}
+
+// validateQuota rejects rules exceeding the size limits , counting the
+// namespaces they list explicitly , the ones matched by selectors are counted
+// by the reconciler. Rules joining a team that reached its limit are rejected
+// too.
+func (v *RBACRuleCustomValidator) validateQuota(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	subjects := 0
+	for _, b := range rbacrule.Spec.Bindings {
+		subjects += parser.SubjectCount(b.Subjects)
+	}
+	if err := v.Config.CheckRuleSize(len(rbacrule.Spec.Bindings), subjects, len(explicitNamespaces(rbacrule))); err != nil {
+		return err
+	}
+
+	limit := v.Config.GetMaxRulesPerTeam()
+	team, found := rbacrule.Labels[constants.TeamLabel]
+	if limit == 0 || !found || (old != nil && old.Labels[constants.TeamLabel] == team) {
+		return nil
+	}
+	rules := &rbaccontrollerv1alpha1.RBACRuleList{}
+	if err := v.Client.List(ctx, rules, client.MatchingLabels{constants.TeamLabel: team}); err != nil {
+		return fmt.Errorf("failed to list the rules of team %s: %w", team, err)
+	}
+	// expired rules that are retained don't grant anything anymore.
+	active := slices.DeleteFunc(rules.Items, func(rule rbaccontrollerv1alpha1.RBACRule) bool {
+		return meta.IsStatusConditionTrue(rule.Status.Conditions, rbaccontrollerv1alpha1.ConditionExpired)
+	})
+	if len(active) >= limit {
+		return fmt.Errorf("team %s can't have more than %d rules", team, limit)
+	}
+	return nil
+}
+
+// validateTeam rejects the rules of a team binding ClusterRoles outside of
+// the team's allowlist , binding ClusterRoles cluster wide or explicitly
+// targeting namespaces the team doesn't own. Like allowed ClusterRoles , what
+// the old version of the rule grants isn't checked again. Namespaces matched
+// by selectors are filtered out by the reconciler. The team of a rule can't be
+// changed.
+func (v *RBACRuleCustomValidator) validateTeam(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	if v.Teams == nil {
+		return nil
+	}
+	team, found := rbacrule.Labels[constants.TeamLabel]
+	if old != nil {
+		if oldTeam, oldFound := old.Labels[constants.TeamLabel]; oldFound != found || oldTeam != team {
+			return fmt.Errorf("label %s can't be changed", constants.TeamLabel)
+		}
+	}
+	if !found {
+		return nil
+	}
+	catalog, err := v.Teams.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load the teams: %w", err)
+	}
+	if !catalog.Defined(team) {
+		return fmt.Errorf("team %s is not defined", team)
+	}
+
+	added, err := v.addedClusterRoles(ctx, old, rbacrule)
+	if err != nil {
+		return err
+	}
+	denied := slices.DeleteFunc(added, func(role string) bool {
+		return catalog.AllowsClusterRole(team, role)
+	})
+	if len(denied) > 0 {
+		return fmt.Errorf("ClusterRoles %s aren't allowed for team %s", strings.Join(denied, ", "), team)
+	}
+
+	var previousCRBs, previousNamespaces []string
+	if old != nil {
+		previousNamespaces = explicitNamespaces(old)
+		for _, b := range old.Spec.Bindings {
+			for _, crb := range b.ClusterRoleBindings {
+				if crb.Scope != rbaccontrollerv1alpha1.ClusterRoleBindingScopeSelectedNamespaces {
+					previousCRBs = append(previousCRBs, clusterRoleBindingRef(crb))
+				}
+			}
+		}
+	}
+	// ClusterRoles granted in selected namespaces are limited to the
+	// namespaces of the team by the reconciler.
+	for i, b := range rbacrule.Spec.Bindings {
+		for j, crb := range b.ClusterRoleBindings {
+			if crb.Scope == rbaccontrollerv1alpha1.ClusterRoleBindingScopeSelectedNamespaces {
+				continue
+			}
+			if !slices.Contains(previousCRBs, clusterRoleBindingRef(crb)) {
+				return fmt.Errorf("bindings[%d].clusterRoleBindings[%d]: the rules of team %s can't grant access cluster wide", i, j, team)
+			}
+		}
+	}
+	for _, ns := range explicitNamespaces(rbacrule) {
+		if slices.Contains(previousNamespaces, ns) {
+			continue
+		}
+		nsMeta := &metav1.PartialObjectMetadata{}
+		nsMeta.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+		if err := v.Client.Get(ctx, client.ObjectKey{Name: ns}, nsMeta); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get namespace %s: %w", ns, err)
+		}
+		if !teams.Owns(team, nsMeta.Labels) {
+			return fmt.Errorf("namespace %s doesn't belong to team %s", ns, team)
+		}
+	}
+	return nil
+}
+
+// validateRequester rejects rules granting roles or namespaces that none of the
+// requester policies applying to the requester allows. Like the other checks ,
+// what the old version of the rule grants isn't checked again.
+func (v *RBACRuleCustomValidator) validateRequester(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	if !v.Config.EnforcesRequesterPolicies() {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	policies, err := requesters.Load(ctx, v.Client, req.UserInfo)
+	if err != nil {
+		return err
+	}
+	if err := policies.Check(old, rbacrule); err != nil {
+		return fmt.Errorf("%w , no RBACRequesterPolicy applying to %s allows it", err, req.UserInfo.Username)
+	}
+	return nil
+}
+
+// explicitNamespaces returns the namespaces the rule lists by name.
+func explicitNamespaces(rbacrule *rbaccontrollerv1alpha1.RBACRule) []string {
+	var namespaces []string
+	for _, b := range rbacrule.Spec.Bindings {
+		for _, s := range b.Subjects {
+			namespaces = append(namespaces, s.Namespaces...)
+		}
+		for _, rb := range b.RoleBindings {
+			namespaces = append(namespaces, rb.Namespaces...)
+		}
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+// validateAllowedRoles rejects rules binding ClusterRoles outside of the
+// allowed ones. Like blocked ClusterRoles , the roles the old version of the
+// rule binds aren't checked again.
+func (v *RBACRuleCustomValidator) validateAllowedRoles(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	added, err := v.addedClusterRoles(ctx, old, rbacrule)
+	if err != nil {
+		return err
+	}
+	denied := slices.DeleteFunc(added, v.Config.IsAllowedClusterRole)
+	if len(denied) > 0 {
+		return fmt.Errorf("ClusterRoles %s aren't allowed , rules can only bind %s",
+			strings.Join(denied, ", "), strings.Join(v.Config.GetAllowedClusterRoles(), ", "))
+	}
+	return nil
+}
+
+// addedClusterRoles returns the ClusterRoles the rule binds , by name or
+// through role bundles , that its old version , if any , doesn't bind.
+func (v *RBACRuleCustomValidator) addedClusterRoles(ctx context.Context, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) ([]string, error) {
+	catalog, err := v.loadBundles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var previous []string
+	if old != nil {
+		previous = clusterRoles(old, catalog)
+	}
+	var added []string
+	for _, r := range clusterRoles(rbacrule, catalog) {
+		if !slices.Contains(previous, r) && !slices.Contains(added, r) {
+			added = append(added, r)
+		}
+	}
+	return added, nil
+}
+
+// validatePolicies rejects rules violating the Rego policies. Rules are
+// rejected when the policies can't be evaluated.
+func (v *RBACRuleCustomValidator) validatePolicies(ctx context.Context, op admissionv1.Operation, old, rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	if v.Policies == nil {
+		return nil
+	}
+	input := rego.Input{Operation: string(op), Object: rbacrule, OldObject: old}
+	if req, err := admission.RequestFromContext(ctx); err == nil {
+		input.UserInfo = req.UserInfo
+	}
+	violations, err := v.Policies.Violations(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate the policies: %w", err)
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("the rule violates the policies: %s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// loadBundles returns the catalog of role bundles , empty when there is none.
+func (v *RBACRuleCustomValidator) loadBundles(ctx context.Context) (bundles.Catalog, error) {
+	if v.Bundles == nil {
+		return bundles.Catalog{}, nil
+	}
+	catalog, err := v.Bundles.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the role bundles: %w", err)
+	}
+	return catalog, nil
+}
+
+// clusterRoles returns the ClusterRoles the rule binds by name or through
+// the bundles of the catalog. The bundles the catalog doesn't define are
+// reported by the reconciler.
+func clusterRoles(rbacrule *rbaccontrollerv1alpha1.RBACRule, catalog bundles.Catalog) []string {
+	var roles []string
+	for _, b := range rbacrule.Spec.Bindings {
+		for _, crb := range b.ClusterRoleBindings {
+			if crb.ClusterRole != "" {
+				roles = append(roles, crb.ClusterRole)
+			}
+			roles = append(roles, catalog[crb.Bundle]...)
+		}
+		for _, rb := range b.RoleBindings {
+			if rb.ClusterRole != "" {
+				roles = append(roles, rb.ClusterRole)
+			}
+			roles = append(roles, catalog[rb.Bundle]...)
+		}
+	}
+	return roles
+}
+
+// validateNamespaces rejects rules explicitly targeting a protected namespace
+// or one the controller doesn't manage , or holding an invalid
+// namespaceMatchExpression. Namespaces matched by selectors are filtered out
+// by the reconciler. When the controller only manages some namespaces ,
+// ClusterRoles can't be granted cluster wide.
+func (v *RBACRuleCustomValidator) validateNamespaces(rbacrule *rbaccontrollerv1alpha1.RBACRule) error {
+	for i, b := range rbacrule.Spec.Bindings {
+		for j, s := range b.Subjects {
+			if s.NamespaceMatchExpression != "" {
+				if _, err := parser.CompileMatchExpression(s.NamespaceMatchExpression); err != nil {
+					return fmt.Errorf("bindings[%d].subjects[%d]: %w", i, j, err)
+				}
+			}
+			for _, ns := range s.Namespaces {
+				if v.Config.IsProtectedNamespace(ns) {
+					return fmt.Errorf("bindings[%d].subjects[%d]: namespace %s is protected", i, j, ns)
+				}
+				if !v.Config.IsManagedNamespace(ns) {
+					return fmt.Errorf("bindings[%d].subjects[%d]: namespace %s isn't managed by the controller", i, j, ns)
+				}
+			}
+		}
+		if v.Config.IsNamespaceRestricted() {
+			for j, crb := range b.ClusterRoleBindings {
+				if crb.Scope != rbaccontrollerv1alpha1.ClusterRoleBindingScopeSelectedNamespaces {
+					return fmt.Errorf("bindings[%d].clusterRoleBindings[%d]: ClusterRoles can't be granted cluster wide , the controller only manages some namespaces. Set scope to SelectedNamespaces", i, j)
+				}
+			}
+		}
+		for j, rb := range b.RoleBindings {
+			if rb.NamespaceMatchExpression != "" {
+				if _, err := parser.CompileMatchExpression(rb.NamespaceMatchExpression); err != nil {
+					return fmt.Errorf("bindings[%d].roleBindings[%d]: %w", i, j, err)
+				}
+			}
+			for _, ns := range rb.Namespaces {
+				if v.Config.IsProtectedNamespace(ns) {
+					return fmt.Errorf("bindings[%d].roleBindings[%d]: namespace %s is protected", i, j, ns)
+				}
+				if !v.Config.IsManagedNamespace(ns) {
+					return fmt.Errorf("bindings[%d].roleBindings[%d]: namespace %s isn't managed by the controller", i, j, ns)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// longDurationWarning is the lifetime , from now , past which rules are warned
+// about.
+const longDurationWarning = 30 * 24 * time.Hour
+
+// warnings returns the soft problems of a rule , which are reported to the
+// requester without rejecting it: roles that don't exist yet , selections
+// that don't match any namespace yet , and long or unbounded lifetimes.
+// Failing to look them up is only logged.
+func (v *RBACRuleCustomValidator) warnings(ctx context.Context, rbacrule *rbaccontrollerv1alpha1.RBACRule) admission.Warnings {
+	var warnings admission.Warnings
+	if end := rbacrule.Spec.EndTime; end.IsZero() {
+		warnings = append(warnings, "the rule has no end time , the access it grants never expires")
+	} else if lifetime := end.Sub(now(v.Clock)); lifetime > longDurationWarning {
+		warnings = append(warnings, fmt.Sprintf("the rule grants access for %d days , consider a shorter end time", int(lifetime.Hours()/24)))
+	}
+	if v.Client == nil {
+		return warnings
+	}
+
+	missing, err := v.missingRoles(ctx, rbacrule)
+	if err != nil {
+		rbacrulelog.Error(err, "Failed to look up the roles of the rule", "name", rbacrule.GetName())
+	}
+	warnings = append(warnings, missing...)
+	unmatched, err := v.unmatchedSelections(ctx, rbacrule)
+	if err != nil {
+		rbacrulelog.Error(err, "Failed to look up the namespaces selected by the rule", "name", rbacrule.GetName())
+	}
+	warnings = append(warnings, unmatched...)
+	unselected, err := v.unselectedServiceAccounts(ctx, rbacrule)
+	if err != nil {
+		rbacrulelog.Error(err, "Failed to look up the ServiceAccounts selected by the rule", "name", rbacrule.GetName())
+	}
+	return append(warnings, unselected...)
+}
+
+// unselectedServiceAccounts warns about the serviceAccountSelectors and the
+// name patterns of the rule that don't match any ServiceAccount , the subject
+// binds nothing until one is labeled or named accordingly. ServiceAccounts are
+// only listed when the rule holds patterns.
+func (v *RBACRuleCustomValidator) unselectedServiceAccounts(ctx context.Context, rbacrule *rbaccontrollerv1alpha1.RBACRule) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	var all *metav1.PartialObjectMetadataList
+	for i, b := range rbacrule.Spec.Bindings {
+		for j, s := range b.Subjects {
+			if s.Kind == rbaccontrollerv1alpha1.ServiceAccount && s.ServiceAccountSelector == nil {
+				for _, name := range parser.SubjectNames(&s) {
+					if !parser.IsGlob(name) {
+						continue
+					}
+					if all == nil {
+						all = &metav1.PartialObjectMetadataList{}
+						all.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ServiceAccountList"))
+						if err := v.Client.List(ctx, all); err != nil {
+							return warnings, err
+						}
+					}
+					if !slices.ContainsFunc(all.Items, func(sa metav1.PartialObjectMetadata) bool {
+						matched, _ := path.Match(name, sa.Name)
+						return matched
+					}) {
+						warnings = append(warnings, fmt.Sprintf("bindings[%d].subjects[%d]: pattern %s doesn't match any ServiceAccount yet", i, j, name))
+					}
+				}
+			}
+			if s.ServiceAccountSelector == nil {
+				continue
+			}
+			// invalid selectors are rejected by the validation.
+			selector, err := metav1.LabelSelectorAsSelector(s.ServiceAccountSelector)
+			if err != nil {
+				continue
+			}
+			sas := &metav1.PartialObjectMetadataList{}
+			sas.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ServiceAccountList"))
+			if err := v.Client.List(ctx, sas, client.MatchingLabelsSelector{Selector: selector}, client.Limit(1)); err != nil {
+				return warnings, err
+			}
+			if len(sas.Items) == 0 {
+				warnings = append(warnings, fmt.Sprintf("bindings[%d].subjects[%d]: the serviceAccountSelector doesn't match any ServiceAccount yet", i, j))
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// missingRoles warns about the Roles and ClusterRoles bound by the rule that
+// don't exist , the rule is degraded until they are created. Only the metadata
+// of roles is read , and ClusterRoles aren't looked up when the controller
+// only manages some namespaces.
+func (v *RBACRuleCustomValidator) missingRoles(ctx context.Context, rbacrule *rbaccontrollerv1alpha1.RBACRule) (admission.Warnings, error) {
+	var warnings admission.Warnings
+	exists := func(kind string, key client.ObjectKey) (bool, error) {
+		if kind == "ClusterRole" && v.Config.IsNamespaceRestricted() {
+			return true, nil
+		}
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(rbacv1.SchemeGroupVersion.WithKind(kind))
+		if err := v.Client.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+	for i, b := range rbacrule.Spec.Bindings {
+		for j, crb := range b.ClusterRoleBindings {
+			if crb.ClusterRole == "" {
+				continue
+			}
+			found, err := exists("ClusterRole", client.ObjectKey{Name: crb.ClusterRole})
+			if err != nil {
+				return warnings, err
+			}
+			if !found {
+				warnings = append(warnings, fmt.Sprintf("bindings[%d].clusterRoleBindings[%d]: ClusterRole %s doesn't exist", i, j, crb.ClusterRole))
+			}
+		}
+		for j, rb := range b.RoleBindings {
+			if rb.ClusterRole != "" {
+				found, err := exists("ClusterRole", client.ObjectKey{Name: rb.ClusterRole})
+				if err != nil {
+					return warnings, err
+				}
+				if !found {
+					warnings = append(warnings, fmt.Sprintf("bindings[%d].roleBindings[%d]: ClusterRole %s doesn't exist", i, j, rb.ClusterRole))
+				}
+			}
+			if rb.Role == "" {
+				continue
+			}
+			for _, ns := range rb.Namespaces {
+				found, err := exists("Role", client.ObjectKey{Namespace: ns, Name: rb.Role})
+				if err != nil {
+					return warnings, err
+				}
+				if !found {
+					warnings = append(warnings, fmt.Sprintf("bindings[%d].roleBindings[%d]: Role %s doesn't exist in namespace %s", i, j, rb.Role, ns))
+				}
+			}
+		}
+	}
+	return warnings, nil
+}
+
+// unmatchedSelections warns about the namespace selectors and match
+// expressions of the rule that don't match any namespace the controller
+// manages , the namespaces are only listed when the rule selects some.
+func (v *RBACRuleCustomValidator) unmatchedSelections(ctx context.Context, rbacrule *rbaccontrollerv1alpha1.RBACRule) (admission.Warnings, error) {
+	var namespaces *metav1.PartialObjectMetadataList
+	// matchesAny reports whether the selector or the expression match a
+	// namespace , invalid ones are rejected by the validation.
+	matchesAny := func(selector *metav1.LabelSelector, expr string) (bool, error) {
+		if namespaces == nil {
+			namespaces = &metav1.PartialObjectMetadataList{}
+			namespaces.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NamespaceList"))
+			if err := v.Client.List(ctx, namespaces); err != nil {
+				return true, err
+			}
+		}
+		var sel labels.Selector
+		if selector != nil && (len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0) {
+			var err error
+			if sel, err = metav1.LabelSelectorAsSelector(selector); err != nil {
+				return true, nil
+			}
+		}
+		var re *regexp.Regexp
+		if expr != "" {
+			var err error
+			if re, err = parser.CompileMatchExpression(expr); err != nil {
+				return true, nil
+			}
+		}
+		for _, ns := range namespaces.Items {
+			if v.Config.IsProtectedNamespace(ns.Name) || !v.Config.IsManagedNamespace(ns.Name) {
+				continue
+			}
+			if (sel != nil && sel.Matches(labels.Set(ns.Labels))) || (re != nil && re.MatchString(ns.Name)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	selects := func(selector *metav1.LabelSelector, expr string) bool {
+		return expr != "" || (selector != nil && (len(selector.MatchLabels) > 0 || len(selector.MatchExpressions) > 0))
+	}
+
+	var warnings admission.Warnings
+	for i, b := range rbacrule.Spec.Bindings {
+		for j, s := range b.Subjects {
+			if !selects(&s.NameSpaceSelector, s.NamespaceMatchExpression) {
+				continue
+			}
+			matched, err := matchesAny(&s.NameSpaceSelector, s.NamespaceMatchExpression)
+			if err != nil {
+				return warnings, err
+			}
+			if !matched {
+				warnings = append(warnings, fmt.Sprintf("bindings[%d].subjects[%d]: the namespace selection doesn't match any namespace yet", i, j))
+			}
+		}
+		for j, rb := range b.RoleBindings {
+			if !selects(&rb.NameSpaceSelector, rb.NamespaceMatchExpression) {
+				continue
+			}
+			matched, err := matchesAny(&rb.NameSpaceSelector, rb.NamespaceMatchExpression)
+			if err != nil {
+				return warnings, err
+			}
+			if !matched {
+				warnings = append(warnings, fmt.Sprintf("bindings[%d].roleBindings[%d]: the namespace selection doesn't match any namespace yet", i, j))
+			}
+		}
+		for j, crb := range b.ClusterRoleBindings {
+			if crb.Scope != rbaccontrollerv1alpha1.ClusterRoleBindingScopeSelectedNamespaces || !selects(crb.NameSpaceSelector, "") {
+				continue
+			}
+			matched, err := matchesAny(crb.NameSpaceSelector, "")
+			if err != nil {
+				return warnings, err
+			}
+			if !matched {
+				warnings = append(warnings, fmt.Sprintf("bindings[%d].clusterRoleBindings[%d]: the namespace selector doesn't match any namespace yet", i, j))
+			}
+		}
+	}
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RBACRule.
@@ -151,6 +1215,8 @@ func (v *RBACRuleCustomValidator) ValidateDelete(ctx context.Context, obj runtim
 	if !ok {
 		return nil, fmt.Errorf("expected a RBACRule object but got %T", obj)
 	}
+	_, span := tracing.Tracer().Start(ctx, "ValidateDelete", trace.WithAttributes(attribute.String("rbacrule", rbacrule.GetName())))
+	defer span.End()
 	rbacrulelog.Info("Validation for RBACRule upon deletion", "name", rbacrule.GetName())
 
 	// TODO(user): fill in your validation logic upon object deletion.
@@ -17,71 +17,520 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	rbaccontrollerv1alpha1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
-	// TODO (user): Add any additional imports if needed
+	"github.com/GGh41th/rbac-controller/internal/bundles"
+	"github.com/GGh41th/rbac-controller/internal/config"
+	"github.com/GGh41th/rbac-controller/internal/constants"
 )
 
 var _ = Describe("RBACRule Webhook", func() {
 	var (
-		obj       *rbaccontrollerv1alpha1.RBACRule
-		oldObj    *rbaccontrollerv1alpha1.RBACRule
-		validator RBACRuleCustomValidator
-		defaulter RBACRuleCustomDefaulter
+		ctx   = context.Background()
+		now   = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		clock = clocktesting.NewFakePassiveClock(now)
+	)
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(rbaccontrollerv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+	user := func(name string) rbaccontrollerv1alpha1.Subject {
+		return rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.User, Name: name}
+	}
+	serviceAccount := func(name string, namespaces ...string) rbaccontrollerv1alpha1.Subject {
+		return rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, Name: name, Namespaces: namespaces}
+	}
+	binding := func(name string, subjects ...rbaccontrollerv1alpha1.Subject) rbaccontrollerv1alpha1.Binding {
+		if len(subjects) == 0 {
+			subjects = []rbaccontrollerv1alpha1.Subject{user("alice")}
+		}
+		return rbaccontrollerv1alpha1.Binding{Name: name, Subjects: subjects}
+	}
+	withRoleBindings := func(b rbaccontrollerv1alpha1.Binding, rbs ...rbaccontrollerv1alpha1.RoleBinding) rbaccontrollerv1alpha1.Binding {
+		b.RoleBindings = rbs
+		return b
+	}
+	withClusterRoleBindings := func(b rbaccontrollerv1alpha1.Binding, crbs ...rbaccontrollerv1alpha1.ClusterRoleBinding) rbaccontrollerv1alpha1.Binding {
+		b.ClusterRoleBindings = crbs
+		return b
+	}
+	clusterRoleIn := func(role string, namespaces ...string) rbaccontrollerv1alpha1.RoleBinding {
+		return rbaccontrollerv1alpha1.RoleBinding{ClusterRole: role, Namespaces: namespaces}
+	}
+	rule := func(bindings ...rbaccontrollerv1alpha1.Binding) *rbaccontrollerv1alpha1.RBACRule {
+		return &rbaccontrollerv1alpha1.RBACRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rule"},
+			Spec:       rbaccontrollerv1alpha1.RBACRuleSpec{Bindings: bindings},
+		}
+	}
+
+	ending := func(r *rbaccontrollerv1alpha1.RBACRule, start, end time.Time) *rbaccontrollerv1alpha1.RBACRule {
+		r.Spec.StartTime = metav1.NewTime(start)
+		r.Spec.EndTime = metav1.NewTime(end)
+		return r
+	}
+	// the role bundles , read from the ConfigMap of c.
+	roleBundles := func(c client.Client) *bundles.ConfigMapCatalog {
+		return &bundles.ConfigMapCatalog{Reader: c, ConfigMap: types.NamespacedName{Namespace: "rbac-controller-system", Name: "role-bundles"}}
+	}
+	bundlesConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "rbac-controller-system", Name: "role-bundles"},
+		Data:       map[string]string{"readonly": "view", "ops": "view,cluster-admin", "deployer": "team-deployer,edit"},
+	}
+	bundleIn := func(bundle string, namespaces ...string) rbaccontrollerv1alpha1.RoleBinding {
+		return rbaccontrollerv1alpha1.RoleBinding{Bundle: bundle, Namespaces: namespaces}
+	}
+	as := func(username string) context.Context {
+		return admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: username},
+		}})
+	}
+
+	DescribeTable("validateNamespaces",
+		func(cfg *config.Config, r *rbaccontrollerv1alpha1.RBACRule, rejected string) {
+			err := (&RBACRuleCustomValidator{Config: cfg}).validateNamespaces(r)
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts namespaces that aren't protected",
+			&config.Config{ProtectedNamespaces: []string{"kube-system"}},
+			rule(withRoleBindings(binding("dev", serviceAccount("ci", "team-a")), clusterRoleIn("view", "team-a"))), ""),
+		Entry("rejects a protected namespace of a subject",
+			&config.Config{ProtectedNamespaces: []string{"kube-system"}},
+			rule(binding("dev", serviceAccount("ci", "kube-system"))), "bindings[0].subjects[0]: namespace kube-system is protected"),
+		Entry("rejects a protected namespace of a RoleBinding",
+			&config.Config{ProtectedNamespaces: []string{"kube-system"}},
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a", "kube-system"))), "bindings[0].roleBindings[0]: namespace kube-system is protected"),
+		Entry("rejects an invalid namespaceMatchExpression",
+			&config.Config{},
+			rule(withRoleBindings(binding("dev"), rbaccontrollerv1alpha1.RoleBinding{ClusterRole: "view", NamespaceMatchExpression: "team-("})), "bindings[0].roleBindings[0]"),
+		Entry("rejects a namespace the controller doesn't manage",
+			&config.Config{Namespaces: []string{"team-a"}},
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-b"))), "namespace team-b isn't managed by the controller"),
+		Entry("rejects ClusterRoles granted cluster wide in namespace-restricted mode",
+			&config.Config{Namespaces: []string{"team-a"}},
+			rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{ClusterRole: "view"})), "ClusterRoles can't be granted cluster wide"),
+		Entry("accepts ClusterRoles granted in selected namespaces in namespace-restricted mode",
+			&config.Config{Namespaces: []string{"team-a"}},
+			rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{
+				ClusterRole:       "view",
+				Scope:             rbaccontrollerv1alpha1.ClusterRoleBindingScopeSelectedNamespaces,
+				NameSpaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			})), ""),
+	)
+
+	DescribeTable("validateImmutableBindings",
+		func(old, r *rbaccontrollerv1alpha1.RBACRule, rejected string) {
+			err := validateImmutableBindings(old, r)
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts added bindings",
+			rule(binding("dev")), rule(binding("dev"), binding("ops")), ""),
+		Entry("accepts removed bindings",
+			rule(binding("dev"), binding("ops")), rule(binding("ops")), ""),
+		Entry("accepts reordered bindings",
+			rule(binding("dev"), binding("ops")), rule(binding("ops"), binding("dev")), ""),
+		Entry("rejects a renamed binding",
+			rule(binding("dev"), binding("ops")), rule(binding("dev"), binding("oncall")), "bindings[1]: name can't be changed from ops to oncall"),
+		Entry("accepts entries added next to the existing ones",
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))),
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"), clusterRoleIn("edit", "team-a"))), ""),
+		Entry("accepts entries whose namespaces changed",
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))),
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a", "team-b"))), ""),
+		Entry("rejects a RoleBinding entry whose role was swapped in place",
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))),
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("edit", "team-a"))), "bindings[0].roleBindings[0]: the bound role can't be changed"),
+		Entry("rejects a RoleBinding entry switched from a ClusterRole to a bundle",
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))),
+			rule(withRoleBindings(binding("dev"), rbaccontrollerv1alpha1.RoleBinding{Bundle: "readonly", Namespaces: []string{"team-a"}})), "bindings[0].roleBindings[0]"),
+		Entry("rejects a ClusterRoleBinding entry whose role was swapped in place",
+			rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{ClusterRole: "view"})),
+			rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{ClusterRole: "admin"})), "bindings[0].clusterRoleBindings[0]"),
+		Entry("accepts swapping the roles of two entries",
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"), clusterRoleIn("edit", "team-b"))),
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("edit", "team-b"), clusterRoleIn("view", "team-a"))), ""),
+	)
+
+	DescribeTable("validateTTL",
+		func(cfg *config.Config, r *rbaccontrollerv1alpha1.RBACRule, rejected string) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(bundlesConfigMap).Build()
+			err := (&RBACRuleCustomValidator{Config: cfg, Clock: clock, Bundles: roleBundles(c)}).validateTTL(ctx, r)
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts any lifetime without a maximum TTL",
+			&config.Config{}, rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))), ""),
+		Entry("requires an end time with a maximum TTL",
+			&config.Config{MaxTTL: 24 * time.Hour}, rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))), "an end time is required"),
+		Entry("accepts rules within the maximum TTL",
+			&config.Config{MaxTTL: 24 * time.Hour},
+			ending(rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))), now.Add(time.Hour), now.Add(25*time.Hour)), ""),
+		Entry("rejects rules lasting longer than the maximum TTL",
+			&config.Config{MaxTTL: 24 * time.Hour},
+			ending(rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))), now, now.Add(48*time.Hour)), "can't be granted for more than 24h0m0s"),
+		Entry("applies the shortest override of the bound roles",
+			&config.Config{MaxTTL: 24 * time.Hour, MaxTTLOverrides: map[string]time.Duration{"cluster-admin": time.Hour}},
+			ending(rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{ClusterRole: "cluster-admin"})), now, now.Add(2*time.Hour)), "can't be granted for more than 1h0m0s"),
+		Entry("applies the overrides of the ClusterRoles bound through bundles",
+			&config.Config{MaxTTL: 24 * time.Hour, MaxTTLOverrides: map[string]time.Duration{"cluster-admin": time.Hour}},
+			ending(rule(withRoleBindings(binding("dev"), bundleIn("ops", "team-a"))), now, now.Add(2*time.Hour)), "can't be granted for more than 1h0m0s"),
+		Entry("limits the rules binding undefined bundles",
+			&config.Config{MaxTTL: 24 * time.Hour},
+			ending(rule(withRoleBindings(binding("dev"), bundleIn("unknown", "team-a"))), now, now.Add(48*time.Hour)), "can't be granted for more than 24h0m0s"),
+		Entry("caps break-glass rules whatever roles they bind",
+			&config.Config{BreakGlassMaxTTL: time.Hour},
+			func() *rbaccontrollerv1alpha1.RBACRule {
+				r := ending(rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))), now, now.Add(2*time.Hour))
+				r.Spec.BreakGlass = true
+				return r
+			}(), "can't be granted for more than 1h0m0s"),
+	)
+
+	Describe("validateBlockedRoles", func() {
+		var (
+			v       *RBACRuleCustomValidator
+			reviews []string
+		)
+
+		BeforeEach(func() {
+			reviews = nil
+			// alice may bind cluster-admin , nobody else may.
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(bundlesConfigMap).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					review := obj.(*authorizationv1.SubjectAccessReview)
+					reviews = append(reviews, review.Spec.ResourceAttributes.Name)
+					review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Name == "cluster-admin"
+					return nil
+				},
+			}).Build()
+			v = &RBACRuleCustomValidator{Config: &config.Config{BlockedClusterRoles: []string{"cluster-admin", "system:*"}}, Client: c, Bundles: roleBundles(c)}
+		})
+
+		admin := func(annotated bool) *rbaccontrollerv1alpha1.RBACRule {
+			r := rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{ClusterRole: "cluster-admin"}))
+			if annotated {
+				r.Annotations = map[string]string{constants.AllowBlockedRolesAnnotation: "true"}
+			}
+			return r
+		}
+
+		It("accepts rules binding no blocked ClusterRole", func() {
+			Expect(v.validateBlockedRoles(as("bob"), nil, rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))))).To(Succeed())
+			Expect(reviews).To(BeEmpty())
+		})
+
+		It("rejects blocked ClusterRoles without the annotation", func() {
+			Expect(v.validateBlockedRoles(as("alice"), nil, admin(false))).To(MatchError(ContainSubstring("ClusterRoles cluster-admin are blocked")))
+		})
+
+		It("accepts blocked ClusterRoles the requester may bind", func() {
+			Expect(v.validateBlockedRoles(as("alice"), nil, admin(true))).To(Succeed())
+			Expect(reviews).To(ConsistOf("cluster-admin"))
+		})
+
+		It("rejects blocked ClusterRoles the requester may not bind", func() {
+			Expect(v.validateBlockedRoles(as("bob"), nil, admin(true))).To(MatchError(ContainSubstring("bob isn't allowed to bind the blocked ClusterRole cluster-admin")))
+		})
+
+		It("matches the blocked ClusterRoles by prefix", func() {
+			r := rule(withRoleBindings(binding("dev"), clusterRoleIn("system:node", "team-a")))
+			Expect(v.validateBlockedRoles(as("alice"), nil, r)).To(MatchError(ContainSubstring("system:node")))
+		})
+
+		It("doesn't check the blocked ClusterRoles the rule already binds", func() {
+			Expect(v.validateBlockedRoles(as("bob"), admin(true), admin(false))).To(Succeed())
+			Expect(reviews).To(BeEmpty())
+		})
+
+		It("checks the ClusterRoles of role bundles", func() {
+			r := rule(withRoleBindings(binding("dev"), bundleIn("ops", "team-a")))
+			Expect(v.validateBlockedRoles(as("alice"), nil, r)).To(MatchError(ContainSubstring("ClusterRoles cluster-admin are blocked")))
+
+			r.Annotations = map[string]string{constants.AllowBlockedRolesAnnotation: "true"}
+			Expect(v.validateBlockedRoles(as("bob"), nil, r)).To(MatchError(ContainSubstring("bob isn't allowed to bind the blocked ClusterRole cluster-admin")))
+			Expect(v.validateBlockedRoles(as("alice"), nil, r)).To(Succeed())
+		})
+
+		It("doesn't check the ClusterRoles the rule already binds by name through a bundle", func() {
+			old := admin(true)
+			r := rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{Bundle: "ops"}))
+			Expect(v.validateBlockedRoles(as("bob"), old, r)).To(Succeed())
+		})
+	})
+
+	DescribeTable("validateAllowedRoles",
+		func(old, r *rbaccontrollerv1alpha1.RBACRule, rejected string) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(bundlesConfigMap).Build()
+			v := &RBACRuleCustomValidator{Config: &config.Config{AllowedClusterRoles: []string{"view", "team-*"}}, Bundles: roleBundles(c)}
+			err := v.validateAllowedRoles(ctx, old, r)
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts allowed ClusterRoles",
+			nil, rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a"))), ""),
+		Entry("accepts ClusterRoles allowed by prefix",
+			nil, rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{ClusterRole: "team-deployer"})), ""),
+		Entry("rejects other ClusterRoles",
+			nil, rule(withRoleBindings(binding("dev"), clusterRoleIn("edit", "team-a"))), "ClusterRoles edit aren't allowed , rules can only bind view, team-*"),
+		Entry("accepts Roles",
+			nil, rule(withRoleBindings(binding("dev"), rbaccontrollerv1alpha1.RoleBinding{Role: "deployer", Namespaces: []string{"team-a"}})), ""),
+		Entry("accepts bundles of allowed ClusterRoles",
+			nil, rule(withRoleBindings(binding("dev"), bundleIn("readonly", "team-a"))), ""),
+		Entry("rejects bundles holding other ClusterRoles",
+			nil, rule(withClusterRoleBindings(binding("dev"), rbaccontrollerv1alpha1.ClusterRoleBinding{Bundle: "deployer"})), "ClusterRoles edit aren't allowed"),
+		Entry("leaves undefined bundles to the reconciler",
+			nil, rule(withRoleBindings(binding("dev"), bundleIn("unknown", "team-a"))), ""),
+		Entry("doesn't check the ClusterRoles the rule already binds",
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("edit", "team-a"))),
+			rule(withRoleBindings(binding("dev"), clusterRoleIn("edit", "team-a", "team-b"))), ""),
+	)
+
+	DescribeTable("validateUniqueness",
+		func(r *rbaccontrollerv1alpha1.RBACRule, rejected string) {
+			err := validateUniqueness(r)
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts distinct bindings and subjects",
+			rule(binding("dev", user("alice"), user("bob")), binding("ops", user("alice"))), ""),
+		Entry("rejects bindings with the same name",
+			rule(binding("dev"), binding("dev")), "bindings[1]: name dev is already used by bindings[0]"),
+		Entry("rejects a subject listed twice",
+			rule(binding("dev", user("alice"), user("alice"))), "bindings[0].subjects[1]: User alice is already listed by subjects[0]"),
+		Entry("accepts a User and a Group with the same name",
+			rule(binding("dev", user("sre"), rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.Group, Name: "sre"})), ""),
+		Entry("accepts a ServiceAccount listed in other namespaces",
+			rule(binding("dev", serviceAccount("ci", "team-a"), serviceAccount("ci", "team-b"))), ""),
+		Entry("rejects a ServiceAccount listed twice in a namespace",
+			rule(binding("dev", serviceAccount("ci", "team-a"), serviceAccount("ci", "team-b", "team-a"))), "bindings[0].subjects[1]: ServiceAccount ci is already listed by subjects[0]"),
+		Entry("accepts a name of a Names list listed in another namespace",
+			rule(binding("dev",
+				rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, Names: []string{"ci", "deployer"}, Namespaces: []string{"team-a"}},
+				serviceAccount("ci", "team-b"))), ""),
+		Entry("rejects a name of a Names list listed again in the same namespace",
+			rule(binding("dev",
+				rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, Names: []string{"ci", "deployer"}, Namespaces: []string{"team-a", "team-b"}},
+				serviceAccount("deployer", "team-b"))), "bindings[0].subjects[1]: ServiceAccount deployer is already listed by subjects[0]"),
+		Entry("rejects a name of a Names list listed as a User",
+			rule(binding("dev",
+				rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.User, Names: []string{"alice", "bob"}},
+				user("bob"))), "User bob is already listed"),
+		Entry("skips the ServiceAccounts selected by label",
+			rule(binding("dev",
+				rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, ServiceAccountSelector: &metav1.LabelSelector{}, Namespaces: []string{"team-a"}},
+				rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, ServiceAccountSelector: &metav1.LabelSelector{}, Namespaces: []string{"team-a"}})), ""),
+	)
+
+	DescribeTable("validateSchedule",
+		func(old, r *rbaccontrollerv1alpha1.RBACRule, rejected string) {
+			err := (&RBACRuleCustomValidator{Clock: clock}).validateSchedule(old, r)
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts times that weren't edited , even past ones",
+			ending(rule(), now.Add(-48*time.Hour), now.Add(-24*time.Hour)),
+			ending(rule(), now.Add(-48*time.Hour), now.Add(-24*time.Hour)), ""),
+		Entry("accepts an end time pushed back",
+			ending(rule(), now.Add(-48*time.Hour), now.Add(-24*time.Hour)),
+			ending(rule(), now.Add(-48*time.Hour), now.Add(24*time.Hour)), ""),
+		Entry("rejects an end time moved to the past",
+			ending(rule(), now.Add(-48*time.Hour), now.Add(24*time.Hour)),
+			ending(rule(), now.Add(-48*time.Hour), now.Add(-time.Hour)), "should not be earlier than now"),
+		Entry("rejects a start time moved after the end time",
+			ending(rule(), now.Add(time.Hour), now.Add(2*time.Hour)),
+			ending(rule(), now.Add(3*time.Hour), now.Add(2*time.Hour)), "should not be higher than end time"),
 	)
 
-	BeforeEach(func() {
-		obj = &rbaccontrollerv1alpha1.RBACRule{}
-		oldObj = &rbaccontrollerv1alpha1.RBACRule{}
-		validator = RBACRuleCustomValidator{}
-		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
-		defaulter = RBACRuleCustomDefaulter{}
-		Expect(defaulter).NotTo(BeNil(), "Expected defaulter to be initialized")
-		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
-		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
-		// TODO (user): Add any setup logic common to all tests
+	DescribeTable("validatePatterns",
+		func(s rbaccontrollerv1alpha1.Subject, rejected string) {
+			err := validatePatterns(rule(binding("dev", s)))
+			if rejected == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(rejected)))
+			}
+		},
+		Entry("accepts names without patterns", serviceAccount("ci", "team-a"), ""),
+		Entry("accepts valid patterns", serviceAccount("ci-*", "team-a"), ""),
+		Entry("accepts patterns among names",
+			rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, Names: []string{"ci", "deploy-[ab]"}, Namespaces: []string{"team-a"}}, ""),
+		Entry("rejects invalid patterns", serviceAccount("ci-[", "team-a"), "bindings[0].subjects[0]"),
+		Entry("rejects patterns creating their ServiceAccounts",
+			rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, Name: "ci-*", Namespaces: []string{"team-a"}, CreateSA: true}, "can't be created nor get a token"),
+		Entry("rejects patterns requesting tokens",
+			rbaccontrollerv1alpha1.Subject{Kind: rbaccontrollerv1alpha1.ServiceAccount, Name: "ci-*", Namespaces: []string{"team-a"}, GenerateToken: true}, "can't be created nor get a token"),
+		Entry("ignores the names of other subjects", user("ci-["), ""),
+	)
+
+	Describe("warnings", func() {
+		var v *RBACRuleCustomValidator
+
+		BeforeEach(func() {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "view"}},
+				&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "deployer"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ci-build", Labels: map[string]string{"app": "ci"}}},
+			).Build()
+			v = &RBACRuleCustomValidator{Config: &config.Config{}, Client: c, Clock: clock}
+		})
+
+		short := func(r *rbaccontrollerv1alpha1.RBACRule) *rbaccontrollerv1alpha1.RBACRule {
+			return ending(r, now, now.Add(time.Hour))
+		}
+
+		It("doesn't warn about short lived rules binding existing roles", func() {
+			r := short(rule(withRoleBindings(binding("dev"),
+				clusterRoleIn("view", "team-a"),
+				rbaccontrollerv1alpha1.RoleBinding{Role: "deployer", Namespaces: []string{"team-a"}},
+				rbaccontrollerv1alpha1.RoleBinding{ClusterRole: "view", NameSpaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			)))
+			Expect(v.warnings(ctx, r)).To(BeEmpty())
+		})
+
+		It("warns about unbounded and long lifetimes", func() {
+			Expect(v.warnings(ctx, rule())).To(ConsistOf(ContainSubstring("has no end time")))
+			Expect(v.warnings(ctx, ending(rule(), now, now.Add(40*24*time.Hour)))).To(ConsistOf(ContainSubstring("grants access for 40 days")))
+		})
+
+		It("warns about missing roles", func() {
+			r := short(rule(withRoleBindings(binding("dev"),
+				clusterRoleIn("edit", "team-a"),
+				rbaccontrollerv1alpha1.RoleBinding{Role: "deployer", Namespaces: []string{"team-a", "team-b"}},
+			)))
+			Expect(v.warnings(ctx, r)).To(ConsistOf(
+				"bindings[0].roleBindings[0]: ClusterRole edit doesn't exist",
+				"bindings[0].roleBindings[1]: Role deployer doesn't exist in namespace team-b",
+			))
+		})
+
+		It("doesn't look up ClusterRoles in namespace-restricted mode", func() {
+			v.Config = &config.Config{Namespaces: []string{"team-a"}}
+			r := short(rule(withRoleBindings(binding("dev"), clusterRoleIn("edit", "team-a"))))
+			Expect(v.warnings(ctx, r)).To(BeEmpty())
+		})
+
+		It("warns about selections matching no namespace", func() {
+			r := short(rule(withRoleBindings(binding("dev"),
+				rbaccontrollerv1alpha1.RoleBinding{ClusterRole: "view", NameSpaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}},
+				rbaccontrollerv1alpha1.RoleBinding{ClusterRole: "view", NamespaceMatchExpression: "team-.*"},
+			)))
+			Expect(v.warnings(ctx, r)).To(ConsistOf("bindings[0].roleBindings[0]: the namespace selection doesn't match any namespace yet"))
+		})
+
+		It("warns about ServiceAccount selectors matching nothing", func() {
+			r := short(rule(binding("dev",
+				rbaccontrollerv1alpha1.Subject{
+					Kind:                   rbaccontrollerv1alpha1.ServiceAccount,
+					ServiceAccountSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ci"}},
+					Namespaces:             []string{"team-a"},
+				},
+				rbaccontrollerv1alpha1.Subject{
+					Kind:                   rbaccontrollerv1alpha1.ServiceAccount,
+					ServiceAccountSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "deploy"}},
+					Namespaces:             []string{"team-a"},
+				},
+			)))
+			Expect(v.warnings(ctx, r)).To(ConsistOf(
+				"bindings[0].subjects[1]: the serviceAccountSelector doesn't match any ServiceAccount yet",
+			))
+		})
+
+		It("warns about ServiceAccount patterns matching nothing", func() {
+			r := short(rule(binding("dev", serviceAccount("ci-*", "team-a"), serviceAccount("deploy-*", "team-a"))))
+			Expect(v.warnings(ctx, r)).To(ConsistOf("bindings[0].subjects[1]: pattern deploy-* doesn't match any ServiceAccount yet"))
+		})
 	})
 
-	AfterEach(func() {
-		// TODO (user): Add any teardown logic common to all tests
+	Describe("ValidateUpdate", func() {
+		It("rejects renamed bindings and roles swapped in place", func() {
+			v := &RBACRuleCustomValidator{Config: &config.Config{}, Clock: clock}
+			old := rule(withRoleBindings(binding("dev"), clusterRoleIn("view", "team-a")))
+
+			renamed := old.DeepCopy()
+			renamed.Spec.Bindings[0].Name = "developers"
+			_, err := v.ValidateUpdate(ctx, old, renamed)
+			Expect(err).To(MatchError(ContainSubstring("name can't be changed from dev to developers")))
+
+			swapped := old.DeepCopy()
+			swapped.Spec.Bindings[0].RoleBindings[0].ClusterRole = "edit"
+			_, err = v.ValidateUpdate(ctx, old, swapped)
+			Expect(err).To(MatchError(ContainSubstring("the bound role can't be changed")))
+
+			widened := old.DeepCopy()
+			widened.Spec.Bindings[0].RoleBindings[0].Namespaces = []string{"team-a", "team-b"}
+			widened.Spec.EndTime = metav1.NewTime(now.Add(time.Hour))
+			Expect(v.ValidateUpdate(ctx, old, widened)).Error().NotTo(HaveOccurred())
+		})
 	})
+})
 
-	Context("When creating RBACRule under Defaulting Webhook", func() {
-		// TODO (user): Add logic for defaulting webhooks
-		// Example:
-		// It("Should apply defaults when a required field is empty", func() {
-		//     By("simulating a scenario where defaults should be applied")
-		//     obj.SomeFieldWithDefault = ""
-		//     By("calling the Default method to apply defaults")
-		//     defaulter.Default(ctx, obj)
-		//     By("checking that the default values are set")
-		//     Expect(obj.SomeFieldWithDefault).To(Equal("default_value"))
-		// })
+// the webhook is served by envtest , the API server calling it on every write
+// of an RBACRule.
+var _ = Describe("RBACRule Webhook server", Ordered, func() {
+	BeforeAll(func() {
+		startTestEnv(&config.Config{ProtectedNamespaces: []string{"kube-system"}})
 	})
+	AfterAll(stopTestEnv)
 
-	Context("When creating or updating RBACRule under Validating Webhook", func() {
-		// TODO (user): Add logic for validating webhooks
-		// Example:
-		// It("Should deny creation if a required field is missing", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = ""
-		//     Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
-		// })
-		//
-		// It("Should admit creation if all required fields are present", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = "valid_value"
-		//     Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
-		// })
-		//
-		// It("Should validate updates correctly", func() {
-		//     By("simulating a valid update scenario")
-		//     oldObj.SomeRequiredField = "updated_value"
-		//     obj.SomeRequiredField = "updated_value"
-		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
-		// })
+	rule := func(name, namespace string) *rbaccontrollerv1alpha1.RBACRule {
+		return &rbaccontrollerv1alpha1.RBACRule{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: rbaccontrollerv1alpha1.RBACRuleSpec{Bindings: []rbaccontrollerv1alpha1.Binding{{
+				Name:         "dev",
+				Subjects:     []rbaccontrollerv1alpha1.Subject{{Kind: rbaccontrollerv1alpha1.User, Name: "alice"}},
+				RoleBindings: []rbaccontrollerv1alpha1.RoleBinding{{ClusterRole: "view", Namespaces: []string{namespace}}},
+			}}},
+		}
+	}
+
+	It("admits the rules binding namespaces that aren't protected", func() {
+		r := rule("team-a", "team-a")
+		Expect(k8sClient.Create(ctx, r)).To(Succeed())
+		Expect(k8sClient.Delete(ctx, r)).To(Succeed())
 	})
 
+	It("denies the rules binding a protected namespace", func() {
+		Expect(k8sClient.Create(ctx, rule("kube-system", "kube-system"))).To(MatchError(ContainSubstring("namespace kube-system is protected")))
+	})
 })
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides builders of RBACRules , a fake clock and helpers
+// to inspect the bindings generated for a rule , for the tests of code
+// embedding or extending the controller.
+package testing
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+)
+
+// RuleBuilder builds an RBACRule.
+type RuleBuilder struct {
+	rule rbaccontrollerv1.RBACRule
+}
+
+// NewRule starts building a rule named name.
+func NewRule(name string) *RuleBuilder {
+	return &RuleBuilder{rule: rbaccontrollerv1.RBACRule{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbaccontrollerv1.GroupVersion.String(), Kind: "RBACRule"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}}
+}
+
+// WithLabel sets a label on the rule.
+func (b *RuleBuilder) WithLabel(key, value string) *RuleBuilder {
+	if b.rule.Labels == nil {
+		b.rule.Labels = map[string]string{}
+	}
+	b.rule.Labels[key] = value
+	return b
+}
+
+// WithAnnotation sets an annotation on the rule.
+func (b *RuleBuilder) WithAnnotation(key, value string) *RuleBuilder {
+	if b.rule.Annotations == nil {
+		b.rule.Annotations = map[string]string{}
+	}
+	b.rule.Annotations[key] = value
+	return b
+}
+
+// StartingAt sets the start time of the rule.
+func (b *RuleBuilder) StartingAt(t time.Time) *RuleBuilder {
+	b.rule.Spec.StartTime = metav1.NewTime(t)
+	return b
+}
+
+// EndingAt sets the end time of the rule.
+func (b *RuleBuilder) EndingAt(t time.Time) *RuleBuilder {
+	b.rule.Spec.EndTime = metav1.NewTime(t)
+	return b
+}
+
+// WithBinding adds a binding to the rule.
+func (b *RuleBuilder) WithBinding(binding *BindingBuilder) *RuleBuilder {
+	b.rule.Spec.Bindings = append(b.rule.Spec.Bindings, binding.Build())
+	return b
+}
+
+// WithSpec lets fn set the fields of the spec the builder doesn't cover.
+func (b *RuleBuilder) WithSpec(fn func(*rbaccontrollerv1.RBACRuleSpec)) *RuleBuilder {
+	fn(&b.rule.Spec)
+	return b
+}
+
+// Build returns the rule , each call returns a new copy.
+func (b *RuleBuilder) Build() *rbaccontrollerv1.RBACRule {
+	return b.rule.DeepCopy()
+}
+
+// BindingBuilder builds a binding of an RBACRule.
+type BindingBuilder struct {
+	binding rbaccontrollerv1.Binding
+}
+
+// NewBinding starts building a binding named name.
+func NewBinding(name string) *BindingBuilder {
+	return &BindingBuilder{binding: rbaccontrollerv1.Binding{Name: name}}
+}
+
+// ForUser adds a User subject.
+func (b *BindingBuilder) ForUser(name string) *BindingBuilder {
+	b.binding.Subjects = append(b.binding.Subjects, rbaccontrollerv1.Subject{Kind: rbaccontrollerv1.User, Name: name})
+	return b
+}
+
+// ForGroup adds a Group subject.
+func (b *BindingBuilder) ForGroup(name string) *BindingBuilder {
+	b.binding.Subjects = append(b.binding.Subjects, rbaccontrollerv1.Subject{Kind: rbaccontrollerv1.Group, Name: name})
+	return b
+}
+
+// ForServiceAccount adds a ServiceAccount subject in the namespaces , created
+// when missing.
+func (b *BindingBuilder) ForServiceAccount(name string, namespaces ...string) *BindingBuilder {
+	b.binding.Subjects = append(b.binding.Subjects, rbaccontrollerv1.Subject{
+		Kind:       rbaccontrollerv1.ServiceAccount,
+		Name:       name,
+		Namespaces: namespaces,
+		CreateSA:   true,
+	})
+	return b
+}
+
+// ClusterWide binds the ClusterRole cluster wide.
+func (b *BindingBuilder) ClusterWide(clusterRole string) *BindingBuilder {
+	b.binding.ClusterRoleBindings = append(b.binding.ClusterRoleBindings, rbaccontrollerv1.ClusterRoleBinding{ClusterRole: clusterRole})
+	return b
+}
+
+// ClusterRoleIn binds the ClusterRole in the namespaces.
+func (b *BindingBuilder) ClusterRoleIn(clusterRole string, namespaces ...string) *BindingBuilder {
+	b.binding.RoleBindings = append(b.binding.RoleBindings, rbaccontrollerv1.RoleBinding{ClusterRole: clusterRole, Namespaces: namespaces})
+	return b
+}
+
+// ClusterRoleInSelected binds the ClusterRole in the namespaces matching the
+// labels.
+func (b *BindingBuilder) ClusterRoleInSelected(clusterRole string, nsLabels map[string]string) *BindingBuilder {
+	b.binding.RoleBindings = append(b.binding.RoleBindings, rbaccontrollerv1.RoleBinding{
+		ClusterRole:       clusterRole,
+		NameSpaceSelector: metav1.LabelSelector{MatchLabels: nsLabels},
+	})
+	return b
+}
+
+// RoleIn binds the Role in the namespaces.
+func (b *BindingBuilder) RoleIn(role string, namespaces ...string) *BindingBuilder {
+	b.binding.RoleBindings = append(b.binding.RoleBindings, rbaccontrollerv1.RoleBinding{Role: role, Namespaces: namespaces})
+	return b
+}
+
+// Build returns the binding , each call returns a new copy.
+func (b *BindingBuilder) Build() rbaccontrollerv1.Binding {
+	return *b.binding.DeepCopy()
+}
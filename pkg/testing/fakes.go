@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+// FakeClock is a clock whose time only moves when stepped or set , it can be
+// given as the Clock of the reconciler and of the webhook.
+type FakeClock = clocktesting.FakeClock
+
+// NewFakeClock returns a fake clock set to t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return clocktesting.NewFakeClock(t)
+}
+
+// Scheme returns a scheme holding the Kubernetes types and RBACRules.
+func Scheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rbaccontrollerv1.AddToScheme(scheme)
+	return scheme
+}
+
+// NewFakeClient returns a fake client holding the objects , RBACRules having
+// their status subresource.
+func NewFakeClient(objs ...client.Object) client.WithWatch {
+	return fake.NewClientBuilder().
+		WithScheme(Scheme()).
+		WithStatusSubresource(&rbaccontrollerv1.RBACRule{}).
+		WithObjects(objs...).
+		Build()
+}
+
+// GeneratedRoleBindings returns the RoleBindings generated for the rule.
+func GeneratedRoleBindings(ctx context.Context, c client.Reader, rule string) ([]rbacv1.RoleBinding, error) {
+	rbs := &rbacv1.RoleBindingList{}
+	if err := c.List(ctx, rbs, client.MatchingLabels{constants.RBACRuleLabel: rule}); err != nil {
+		return nil, err
+	}
+	return rbs.Items, nil
+}
+
+// GeneratedClusterRoleBindings returns the ClusterRoleBindings generated for
+// the rule.
+func GeneratedClusterRoleBindings(ctx context.Context, c client.Reader, rule string) ([]rbacv1.ClusterRoleBinding, error) {
+	crbs := &rbacv1.ClusterRoleBindingList{}
+	if err := c.List(ctx, crbs, client.MatchingLabels{constants.RBACRuleLabel: rule}); err != nil {
+		return nil, err
+	}
+	return crbs.Items, nil
+}
+
+// FindRoleBinding returns the RoleBinding of the namespace binding the role ,
+// of kind Role or ClusterRole , nil when there is none.
+func FindRoleBinding(rbs []rbacv1.RoleBinding, namespace, kind, role string) *rbacv1.RoleBinding {
+	i := slices.IndexFunc(rbs, func(rb rbacv1.RoleBinding) bool {
+		return rb.Namespace == namespace && rb.RoleRef.Kind == kind && rb.RoleRef.Name == role
+	})
+	if i < 0 {
+		return nil
+	}
+	return &rbs[i]
+}
+
+// FindClusterRoleBinding returns the ClusterRoleBinding binding the
+// ClusterRole , nil when there is none.
+func FindClusterRoleBinding(crbs []rbacv1.ClusterRoleBinding, clusterRole string) *rbacv1.ClusterRoleBinding {
+	i := slices.IndexFunc(crbs, func(crb rbacv1.ClusterRoleBinding) bool { return crb.RoleRef.Name == clusterRole })
+	if i < 0 {
+		return nil
+	}
+	return &crbs[i]
+}
+
+// HasSubject reports whether the subjects hold the subject of the kind and
+// name , the namespace only applying to ServiceAccounts.
+func HasSubject(subjects []rbacv1.Subject, kind, name, namespace string) bool {
+	return slices.ContainsFunc(subjects, func(s rbacv1.Subject) bool {
+		return s.Kind == kind && s.Name == name && s.Namespace == namespace
+	})
+}
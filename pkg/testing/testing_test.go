@@ -0,0 +1,107 @@
+/*
+Copyright 2025 Ghaith Gtari.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rbaccontrollerv1 "github.com/GGh41th/rbac-controller/api/v1alpha1"
+	"github.com/GGh41th/rbac-controller/internal/constants"
+)
+
+var _ = Describe("RuleBuilder", func() {
+	It("builds rules", func() {
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		rule := NewRule("oncall").
+			WithLabel(constants.TeamLabel, "sre").
+			StartingAt(start).
+			EndingAt(start.Add(time.Hour)).
+			WithBinding(NewBinding("sre").ForGroup("sre").ForServiceAccount("bot", "ops").ClusterRoleIn("edit", "ops").ClusterWide("view")).
+			WithSpec(func(spec *rbaccontrollerv1.RBACRuleSpec) { spec.Justification = "incident" }).
+			Build()
+
+		Expect(rule.Labels).To(HaveKeyWithValue(constants.TeamLabel, "sre"))
+		Expect(rule.Spec.EndTime.Sub(rule.Spec.StartTime.Time)).To(Equal(time.Hour))
+		Expect(rule.Spec.Justification).To(Equal("incident"))
+		Expect(rule.Spec.Bindings).To(HaveLen(1))
+		b := rule.Spec.Bindings[0]
+		Expect(b.Subjects).To(HaveLen(2))
+		Expect(b.Subjects[1].Namespaces).To(Equal([]string{"ops"}))
+		Expect(b.RoleBindings[0].ClusterRole).To(Equal("edit"))
+		Expect(b.ClusterRoleBindings[0].ClusterRole).To(Equal("view"))
+	})
+
+	It("returns copies", func() {
+		builder := NewRule("oncall").WithBinding(NewBinding("sre").ForUser("alice").ClusterWide("view"))
+		rule := builder.Build()
+		rule.Spec.Bindings[0].Name = "changed"
+		Expect(builder.Build().Spec.Bindings[0].Name).To(Equal("sre"))
+	})
+})
+
+var _ = Describe("Generated bindings", func() {
+	ctx := context.Background()
+	labels := map[string]string{constants.RBACRuleLabel: "oncall"}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "bot", Namespace: "ops"}}
+
+	It("finds the bindings of a rule", func() {
+		c := NewFakeClient(
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "oncall-edit", Namespace: "ops", Labels: labels},
+				Subjects:   subjects,
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ops"},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "admin"},
+			},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "oncall-view", Labels: labels},
+				Subjects:   subjects,
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			},
+		)
+
+		rbs, err := GeneratedRoleBindings(ctx, c, "oncall")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rbs).To(HaveLen(1))
+		rb := FindRoleBinding(rbs, "ops", "ClusterRole", "edit")
+		Expect(rb).NotTo(BeNil())
+		Expect(HasSubject(rb.Subjects, rbacv1.ServiceAccountKind, "bot", "ops")).To(BeTrue())
+		Expect(FindRoleBinding(rbs, "ops", "Role", "edit")).To(BeNil())
+
+		crbs, err := GeneratedClusterRoleBindings(ctx, c, "oncall")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(FindClusterRoleBinding(crbs, "view")).NotTo(BeNil())
+		Expect(FindClusterRoleBinding(crbs, "edit")).To(BeNil())
+	})
+})
+
+var _ = Describe("FakeClock", func() {
+	It("only moves when stepped", func() {
+		start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		clock := NewFakeClock(start)
+		clock.Step(time.Minute)
+		Expect(clock.Now()).To(Equal(start.Add(time.Minute)))
+	})
+})